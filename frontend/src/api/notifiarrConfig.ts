@@ -759,6 +759,10 @@ export interface State {
   month?: number;
   week?: number;
   day?: number;
+  /**
+   * Usenet failure reasons and their counts.
+   */
+  failures?: Record<string, number>;
 };
 
 /**
@@ -1380,14 +1384,181 @@ export interface Config extends LogConfig, AppsConfig {
   watchFiles?: WatchFile[];
   endpoints?: Endpoint[];
   commands?: Command[];
+  stalled?: StalledConfig;
+  stuck?: StuckConfig;
+  imports?: ImportConfig;
+  seeding?: SeedingConfig;
+  orphans?: OrphansConfig;
+  usenet?: UsenetConfig;
   version: number;
 };
 
+/**
+ * Config enables the orphaned file finder. Every is how often to scan; 0 only scans when triggered.
+ * @see golang: <github.com/Notifiarr/notifiarr/pkg/triggers/orphans.Config>
+ */
+export interface OrphansConfig {
+  every: string;
+  /**
+   * Paths are download folders to scan in addition to the torrent clients' save paths.
+   * Usenet clients do not provide their completed folder, so add it here.
+   */
+  paths?: string[];
+  /**
+   * MinAge ignores files and folders modified more recently than this. Default is 24 hours.
+   */
+  minAge: string;
+  /**
+   * Exclude ignores files and folders whose name matches any of these glob patterns.
+   */
+  exclude?: string[];
+  /**
+   * RootFolders also reports unmapped folders in the Lidarr, Radarr and Sonarr root folders.
+   */
+  rootFolders: boolean;
+};
+
+/**
+ * Config enables the usenet failure analysis. Failures in the window are counted.
+ * An alert is logged when at least min_samples downloads from an indexer, or min_articles
+ * articles from a provider, fail at a rate of threshold percent or more.
+ * @see golang: <github.com/Notifiarr/notifiarr/pkg/triggers/usenet.Config>
+ */
+export interface UsenetConfig {
+  interval: string;
+  window: string;
+  threshold: number;
+  minSamples: number;
+  minArticles: number;
+  /**
+   * Indexers looks up which indexer each download came from in the starr apps' grab history.
+   */
+  indexers: boolean;
+};
+
+/**
+ * Config is the seeding policy configuration. Policies are evaluated in order; the first match wins.
+ * The embedded CronJob schedules the enforcement. Every is used if the CronJob has no frequency or cron expression.
+ * @see golang: <github.com/Notifiarr/notifiarr/pkg/triggers/seeding.Config>
+ */
+export interface SeedingConfig extends CronJob {
+  every: string;
+  dryRun: boolean;
+  policies?: Policy[];
+};
+
+/**
+ * Policy matches torrents by tracker and category, and pauses or removes them once the minimum
+ * ratio and seed time are both satisfied. A zero minimum is always satisfied.
+ * @see golang: <github.com/Notifiarr/notifiarr/pkg/triggers/seeding.Policy>
+ */
+export interface Policy {
+  name: string;
+  /**
+   * Trackers match any part of the torrent's tracker URL. Empty matches every tracker.
+   */
+  trackers?: string[];
+  /**
+   * Categories match the torrent's category or label exactly. Empty matches every category.
+   */
+  categories?: string[];
+  /**
+   * Clients limits the policy to these torrent clients, like qbit or deluge. Empty means all.
+   */
+  clients?: string[];
+  ratio: number;
+  seedTime: string;
+  /**
+   * Action is pause or remove. DeleteData removes the torrent's data too.
+   */
+  action: string;
+  deleteData: boolean;
+  /**
+   * KeepLinked skips torrents with any hard-linked file; those were imported into a library.
+   */
+  keepLinked: boolean;
+  /**
+   * Disabled policies still match torrents (so later policies don't), but take no action.
+   */
+  disabled: boolean;
+};
+
+/**
+ * ImportConfig enables the import tracker. Overdue is how long after a download completes
+ * before an import is taking too long. Apps limits tracking to these starr apps; empty means all.
+ * @see golang: <github.com/Notifiarr/notifiarr/pkg/triggers/starrqueue.ImportConfig>
+ */
+export interface ImportConfig {
+  interval: string;
+  overdue: string;
+  apps?: string[];
+};
+
+/**
+ * StuckConfig is the local stuck queue item rule engine. Rules are checked in order; the first match wins.
+ * @see golang: <github.com/Notifiarr/notifiarr/pkg/triggers/starrqueue.StuckConfig>
+ */
+export interface StuckConfig {
+  interval: string;
+  /**
+   * DryRun logs the actions that would be taken, and takes none.
+   */
+  dryRun: boolean;
+  rules?: StuckRule[];
+};
+
+/**
+ * StuckRule matches stuck queue items by status message or tracked download state.
+ * A rule without messages or states never matches.
+ * @see golang: <github.com/Notifiarr/notifiarr/pkg/triggers/starrqueue.StuckRule>
+ */
+export interface StuckRule {
+  name: string;
+  /**
+   * Messages match any part of the item's status or error messages. Case-insensitive.
+   */
+  messages?: string[];
+  /**
+   * States match the tracked download state, like importPending, importBlocked or failedPending.
+   */
+  states?: string[];
+  /**
+   * Apps limits the rule to these starr apps, like sonarr or radarr. Empty means all.
+   */
+  apps?: string[];
+  /**
+   * Wait is how long an item must match the rule before the action is taken.
+   */
+  wait: string;
+  action: string;
+  disabled: boolean;
+};
+
+/**
+ * StalledConfig enables the stalled torrent detector. Every duration is how long a torrent must be in
+ * that condition before it's considered stalled. A zero value disables that check.
+ * @see golang: <github.com/Notifiarr/notifiarr/pkg/triggers/starrqueue.StalledConfig>
+ */
+export interface StalledConfig {
+  interval: string;
+  noSeeds: string;
+  metadata: string;
+  noEta: string;
+  /**
+   * Action is notify, remove, blocklist or research.
+   */
+  action: string;
+  /**
+   * Clients limits the detector to these torrent clients, like qbit or deluge. Empty means all.
+   */
+  clients?: string[];
+};
+
 /**
  * Config determines which checks to run, etc.
  * @see golang: <github.com/Notifiarr/notifiarr/pkg/snapshot.Config>
  */
-export interface SnapshotConfig extends Plugins {
+export interface SnapshotConfig extends Plugins, CronExpr {
   timeout: string;
   interval: string;
   zfsPools?: string[];
@@ -1464,6 +1635,12 @@ export interface ServiceConfig {
 
 /**
  * WatchFile is the input data needed to watch files.
+ * Path may be a glob pattern or a directory; every matching file is watched, including new files.
+ * Setting StartRegexp or ContinueRegexp groups multi-line events, like stack traces, into one match.
+ * Setting Format parses each line as JSON or logfmt, and Expression matches on the parsed fields.
+ * Setting Source reads the systemd journal or a docker container's log instead of a file.
+ * Every watcher has its own rate limit, and may de-duplicate repeated matches or send them in digests.
+ * Actions run a command, an endpoint or a trigger when a line matches.
  * @see golang: <github.com/Notifiarr/notifiarr/pkg/triggers/filewatch.WatchFile>
  */
 export interface WatchFile {
@@ -1475,11 +1652,66 @@ export interface WatchFile {
   mustExist: boolean;
   logMatch: boolean;
   disabled: boolean;
+  /**
+   * StartRegexp matches the first line of a multi-line event.
+   */
+  startRegex: string;
+  /**
+   * ContinueRegexp matches the following lines of a multi-line event.
+   */
+  continueRegex: string;
+  /**
+   * MaxLines ends a multi-line event at this many lines. Default is 50.
+   */
+  maxLines: number;
+  /**
+   * Flush ends a multi-line event when no new line is written for this long. Default is 2 seconds.
+   */
+  flush: string;
+  /**
+   * Format parses each line as structured data: json or logfmt. The parsed fields are sent with a match.
+   */
+  format: string;
+  /**
+   * Expression matches on parsed fields, like: level == "error" && logger =~ 'Import'.
+   * Regexp is optional when this is set. If both are set, both must match.
+   */
+  expression: string;
+  /**
+   * Source is where lines are read from: file (default), journald or docker.
+   * For journald, Path is a unit name or a journal match like SYSLOG_IDENTIFIER=sonarr.
+   * For docker, Path is a container name or ID.
+   */
+  source: string;
+  /**
+   * Burst is how many matches may be sent at once before the rate limit applies. Default is 6.
+   */
+  burst: number;
+  /**
+   * Rate allows one match per this duration after the burst. Default is 1.5 seconds.
+   */
+  rate: string;
+  /**
+   * Dedup sends identical matches once within this window, then sends how many times they repeated.
+   */
+  dedup: string;
+  /**
+   * Digest collects matches, and sends them together once per this duration.
+   */
+  digest: string;
+  /**
+   * Actions run when a line matches, like command:restart-plex, endpoint:my-endpoint or trigger:TrigDashboard.
+   * Commands are found by name or hash. Capture groups from the regexp are passed as command arguments.
+   * Actions do not run for repeated matches in the dedup window. They have their own rate limit using Burst and Rate.
+   */
+  actions?: string[];
 };
 
 /**
  * Endpoint contains the cronjob definition and url query parameters.
  * This is the input data to poll a url on a frequency.
+ * The Expect fields make the endpoint a check: each run succeeds or fails, and changes are reported.
+ * Render makes the request dynamic, with access to time, environment, secrets, app data and step variables.
  * @see golang: <github.com/Notifiarr/notifiarr/pkg/triggers/endpoints/epconfig.Endpoint>
  */
 export interface Endpoint extends CronJob {
@@ -1493,6 +1725,58 @@ export interface Endpoint extends CronJob {
   follow: boolean;
   validSsl: boolean;
   timeout: string;
+  /**
+   * ExpectStatus is a list of allowed response status codes.
+   */
+  expectStatus?: number[];
+  /**
+   * ExpectBody is a regular expression the response body must match.
+   */
+  expectBody?: string;
+  /**
+   * ExpectJSON is a list of assertions on the JSON response: path==value, path!=value, path=~regexp,
+   * or a path that must exist. Paths use dots, and array indexes, like: data.items.0.status.
+   */
+  expectJson?: string[];
+  /**
+   * Extract is a list of name=json.path or name=~regexp. The values are sent to the website as variables.
+   */
+  extract?: string[];
+  /**
+   * Render parses the url, body, header values and query values as Go templates, and renders them on every run.
+   */
+  render?: boolean;
+  /**
+   * Steps are requests that run in order before this endpoint's request, like a login that returns a token.
+   */
+  steps?: Step[];
+};
+
+/**
+ * Step is one request in an endpoint workflow. The url, body, header values and query values are
+ * always templates, and may use .Variables extracted by earlier steps. Cookies are kept between steps.
+ * @see golang: <github.com/Notifiarr/notifiarr/pkg/triggers/endpoints/epconfig.Step>
+ */
+export interface Step {
+  name: string;
+  url: string;
+  method: string;
+  body: string;
+  header?: Record<string, null | string[]>;
+  query?: Record<string, null | string[]>;
+  /**
+   * When is a template condition. The step is skipped if it renders empty, false or 0.
+   */
+  when?: string;
+  /**
+   * OnError is stop (default) or continue. A step fails if the request fails, an expectation fails,
+   * or the response status is 400 or more and ExpectStatus is empty. Stopping skips the endpoint request.
+   */
+  onError?: string;
+  expectStatus?: number[];
+  expectBody?: string;
+  expectJson?: string[];
+  extract?: string[];
 };
 
 /**
@@ -1504,9 +1788,10 @@ export interface Endpoint extends CronJob {
  * 3 `Daily` uses Hours, Minutes and Seconds.
  * 4 `Weekly` uses DaysOfWeek, Hours, Minutes and Seconds.
  * 5 `Monthly` uses DaysOfMonth, Hours, Minutes and Seconds.
+ * A Cron expression replaces all of these when it is set.
  * @see golang: <github.com/Notifiarr/notifiarr/pkg/triggers/common/scheduler.CronJob>
  */
-export interface CronJob {
+export interface CronJob extends CronExpr {
   /**
    * Frequency to configure the job. Pass 0 disable the cron.
    */
@@ -1534,6 +1819,22 @@ export interface CronJob {
   months?: number[];
 };
 
+/**
+ * CronExpr is a cron expression and its timezone. Schedules without frequency settings use it alone.
+ * @see golang: <github.com/Notifiarr/notifiarr/pkg/triggers/common/scheduler.CronExpr>
+ */
+export interface CronExpr {
+  /**
+   * Cron is a standard cron expression with 5 fields, or 6 with seconds first, or a descriptor
+   * like @daily or @every 1h30m. Frequency and the other fields are ignored when this is set.
+   */
+  cron?: string;
+  /**
+   * Timezone for the Cron expression, like America/New_York. Defaults to the local timezone.
+   */
+  timezone?: string;
+};
+
 /**
  * Command contains the input data for a defined command.
  * It also contains some saved data about the command being run.
@@ -1552,6 +1853,47 @@ export interface CmdconfigConfig {
   log: boolean;
   notify: boolean;
   timeout: string;
+  /**
+   * Env is a list of NAME=value environment variables added to the command's environment.
+   */
+  env?: string[];
+  /**
+   * Secrets is a list of NAME=/path/to/file. The file's contents become the NAME environment variable.
+   * The files are read every time the command runs.
+   */
+  secrets?: string[];
+  /**
+   * Dir is the working directory. The default is this app's working directory.
+   */
+  dir?: string;
+  /**
+   * RunAs is a user name or uid to run the command as. This app must run as root to use it. Not on Windows.
+   */
+  runAs?: string;
+  /**
+   * Stdin is written to the command's standard input.
+   */
+  stdin?: string;
+  /**
+   * ExitCodes is a list of code=state, like 1=warning. A mapped exit code is not an error; its state is reported.
+   */
+  exitCodes?: string[];
+  /**
+   * JSON parses the command's standard output as JSON, and sends it to the website as structured data.
+   */
+  json: boolean;
+  /**
+   * Concurrency is what happens when the command is triggered while it's running: queue, parallel, reject or kill.
+   */
+  concurrency?: string;
+  /**
+   * History is how many runs to keep in the history. Default is 20, -1 disables the history.
+   */
+  history: number;
+  /**
+   * HistoryAge is how long to keep runs in the history. Default is 30 days.
+   */
+  historyAge: string;
   /**
    * Args and ArgValues are not config items. They are calculated on startup.
    */
@@ -1740,7 +2082,34 @@ export interface TautulliConfig0 {
 /**
  * @see golang: <github.com/Notifiarr/notifiarr/pkg/apps.PlexConfig>
  */
-export interface PlexConfig extends PlexConfig0, ExtraConfig {};
+export interface PlexConfig extends PlexConfig0, ExtraConfig {
+  throttle?: PlexThrottle;
+};
+
+/**
+ * PlexThrottle applies alternate download client speed limits while Plex is busy.
+ * Throttling starts when either threshold is reached, and the limits each client had before
+ * are restored after the sessions stay below both restore thresholds for the Restore duration.
+ * The restore thresholds default to the throttle thresholds, and may be lower so a stream
+ * count or bandwidth near a threshold does not toggle the limits.
+ * Speed limits are in KiB/s; zero means unlimited. Non-zero normal limits replace the restored limits.
+ * @see golang: <github.com/Notifiarr/notifiarr/pkg/apps.PlexThrottle>
+ */
+export interface PlexThrottle {
+  sessions: number;
+  bandwidth: number;
+  restoreSessions: number;
+  restoreBandwidth: number;
+  download: number;
+  upload: number;
+  normalDownload: number;
+  normalUpload: number;
+  restore: string;
+  /**
+   * Clients limits throttling to these download clients, like qbit or sabnzbd. Empty means all.
+   */
+  clients: string[];
+};
 
 /**
  * @see golang: <github.com/Notifiarr/notifiarr/pkg/apps/apppkg/plex.Config>
@@ -1856,6 +2225,7 @@ export interface DashConfig {
   interval: string;
   deluge: boolean;
   lidarr: boolean;
+  prowlarr: boolean;
   qbit: boolean;
   radarr: boolean;
   readarr: boolean;
@@ -1905,7 +2275,7 @@ export interface GapsConfig {
  * Used to offload crons to clients.
  * @see golang: <github.com/Notifiarr/notifiarr/pkg/website/clientinfo.CronConfig>
  */
-export interface CronConfig {
+export interface CronConfig extends CronExpr {
   name: string;
   interval: string;
   endpoint: string;
@@ -1945,6 +2315,10 @@ export interface TriggerInfo {
   cron?: CronJob;
   runs: number;
   kind: string;
+  /**
+   * Next contains the next few run times of a schedule.
+   */
+  next?: Date[];
 };
 
 /**
@@ -2084,6 +2458,8 @@ export interface Stats {
   lastCmd: string;
   lastTime: Date;
   lastArgs?: string[];
+  lastCode: number;
+  lastState: string;
 };
 
 /**
@@ -26,6 +26,9 @@ const empty: Command = {
   args: 0,
   timeout: '10s',
   command: '',
+  json: false,
+  history: 0,
+  historyAge: '0s',
 }
 
 const merge = (index: number, form: Command): Config => {
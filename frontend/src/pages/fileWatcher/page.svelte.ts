@@ -25,6 +25,17 @@ const empty: WatchFile = {
   mustExist: false,
   logMatch: false,
   disabled: false,
+  startRegex: '',
+  continueRegex: '',
+  maxLines: 0,
+  flush: '0s',
+  format: '',
+  expression: '',
+  source: '',
+  burst: 0,
+  rate: '0s',
+  dedup: '0s',
+  digest: '0s',
 }
 
 const merge = (index: number, form: WatchFile): Config => {
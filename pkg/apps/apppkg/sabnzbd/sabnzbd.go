@@ -243,6 +243,82 @@ func (s *SabNZB) GetQueue(ctx context.Context) (*Queue, error) {
 	return que.Queue, nil
 }
 
+// ServerStats is the json response from the SABnzbd server_stats API mode.
+// Daily maps are keyed by date, like 2024-12-31.
+//
+//nolint:tagliatelle
+type ServerStats struct {
+	Total   int64                  `json:"total"`
+	Month   int64                  `json:"month"`
+	Week    int64                  `json:"week"`
+	Day     int64                  `json:"day"`
+	Servers map[string]*ServerStat `json:"servers"`
+}
+
+// ServerStat is the download and article statistics for one usenet server (provider).
+//
+//nolint:tagliatelle
+type ServerStat struct {
+	Total           int64            `json:"total"`
+	Month           int64            `json:"month"`
+	Week            int64            `json:"week"`
+	Day             int64            `json:"day"`
+	Daily           map[string]int64 `json:"daily"`
+	ArticlesTried   map[string]int64 `json:"articles_tried"`
+	ArticlesSuccess map[string]int64 `json:"articles_success"`
+}
+
+// GetServerStats returns the per-server (provider) download statistics from SABnzbd.
+func (s *SabNZB) GetServerStats(ctx context.Context) (*ServerStats, error) {
+	if s == nil || s.URL == "" {
+		return &ServerStats{}, nil
+	}
+
+	params := url.Values{}
+	params.Add("output", "json")
+	params.Add("mode", "server_stats")
+	params.Add("apikey", s.APIKey)
+
+	var stats ServerStats
+
+	err := s.GetURLInto(ctx, params, &stats)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// ErrCommandFailed is returned when SABnzbd reports a failed queue command.
+var ErrCommandFailed = errors.New("sabnzbd command failed")
+
+// Command runs an API mode (like queue or change_cat) with the provided parameters.
+// Used to pause, resume, delete and categorize queue items, and set the speed limit.
+func (s *SabNZB) Command(ctx context.Context, mode string, params url.Values) error {
+	if params == nil {
+		params = url.Values{}
+	}
+
+	params.Set("output", "json")
+	params.Set("mode", mode)
+	params.Set("apikey", s.APIKey)
+
+	var status struct {
+		Status bool   `json:"status"`
+		Error  string `json:"error"`
+	}
+
+	if err := s.GetURLInto(ctx, params, &status); err != nil {
+		return err
+	}
+
+	if !status.Status && status.Error != "" {
+		return fmt.Errorf("%w: %s", ErrCommandFailed, status.Error)
+	}
+
+	return nil
+}
+
 // GetURLInto gets a url and unmarshals the contents into the provided interface pointer.
 func (s *SabNZB) GetURLInto(ctx context.Context, params url.Values, into any) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL+"/api", nil)
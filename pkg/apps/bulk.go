@@ -0,0 +1,595 @@
+package apps
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+
+	"golift.io/starr"
+	"golift.io/starr/radarr"
+	"golift.io/starr/sonarr"
+)
+
+/* The bulk endpoints in this file let the website act on many movies or series at once. */
+
+// bulkSearchWorkers is how many Sonarr series search commands are sent at once.
+const bulkSearchWorkers = 4
+
+// Errors returned by the bulk endpoints.
+var (
+	ErrNoBulkItems = errors.New("no items matched the provided ids or filter")
+	ErrNoBulkValue = errors.New("bulk action requires a qualityProfileId or tags value")
+)
+
+// BulkInput is the input payload for all bulk endpoints.
+// Provide a list of IDs, a filter, or both. When both are provided, the IDs are filtered.
+type BulkInput struct {
+	// IDs is a list of movie or series IDs to act on.
+	IDs []int64 `json:"ids"`
+	// Filter selects items by tag, root folder or quality profile.
+	Filter *BulkFilter `json:"filter,omitempty"`
+	// QualityProfileID is required for the quality profile endpoint.
+	QualityProfileID int64 `json:"qualityProfileId,omitempty"`
+	// Tags are required for the tag add and remove endpoints.
+	Tags []int `json:"tags,omitempty"`
+	// DeleteFiles is used by the delete endpoint to also remove files from disk.
+	DeleteFiles bool `json:"deleteFiles,omitempty"`
+	// AddImportExclusion is used by the delete endpoint to prevent re-adding by import lists.
+	AddImportExclusion bool `json:"addImportExclusion,omitempty"`
+}
+
+// BulkFilter selects items for a bulk request. Every non-empty member must match.
+type BulkFilter struct {
+	Tag              int    `json:"tag,omitempty"`
+	RootFolder       string `json:"rootFolder,omitempty"`
+	QualityProfileID int64  `json:"qualityProfileId,omitempty"`
+}
+
+// BulkResponse is returned by the bulk endpoints.
+type BulkResponse struct {
+	// How many items were selected by the ids and filter.
+	Matched int `json:"matched"`
+	// The IDs that were acted on.
+	IDs []int64 `json:"ids"`
+	// Command status for search requests.
+	Status []string `json:"status,omitempty"`
+}
+
+// match returns true if an item's tags, path and profile satisfy the filter.
+func (f *BulkFilter) match(tags []int, itemPath string, profileID int64) bool {
+	if f == nil {
+		return true
+	}
+
+	if f.Tag != 0 && !slices.Contains(tags, f.Tag) {
+		return false
+	}
+
+	if f.QualityProfileID != 0 && f.QualityProfileID != profileID {
+		return false
+	}
+
+	root := strings.TrimRight(f.RootFolder, `/\`)
+
+	return root == "" || strings.HasPrefix(itemPath, root+"/") || strings.HasPrefix(itemPath, root+`\`)
+}
+
+// needsLookup returns true if the item list must be fetched to resolve the selection.
+func (b *BulkInput) needsLookup() bool {
+	return b.Filter != nil && (b.Filter.Tag != 0 || b.Filter.RootFolder != "" || b.Filter.QualityProfileID != 0)
+}
+
+// selected returns true if an ID was requested, or no IDs were requested.
+func (b *BulkInput) selected(id int64) bool {
+	return len(b.IDs) == 0 || slices.Contains(b.IDs, id)
+}
+
+func decodeBulkInput(req *http.Request) (*BulkInput, error) {
+	var input BulkInput
+	if err := json.NewDecoder(req.Body).Decode(&input); err != nil {
+		return nil, fmt.Errorf("decoding payload: %w", err)
+	}
+
+	if len(input.IDs) == 0 && !input.needsLookup() {
+		return nil, ErrNoBulkItems
+	}
+
+	return &input, nil
+}
+
+// radarrBulkIDs resolves the requested movie IDs from the input.
+func radarrBulkIDs(req *http.Request, input *BulkInput) ([]int64, error) {
+	if !input.needsLookup() {
+		return input.IDs, nil
+	}
+
+	movies, err := getRadarr(req).GetMovieContext(req.Context(), &radarr.GetMovie{ExcludeLocalCovers: true})
+	if err != nil {
+		return nil, fmt.Errorf("getting movies: %w", err)
+	}
+
+	ids := []int64{}
+
+	for _, movie := range movies {
+		if input.selected(movie.ID) && input.Filter.match(movie.Tags, movie.Path, movie.QualityProfileID) {
+			ids = append(ids, movie.ID)
+		}
+	}
+
+	return ids, nil
+}
+
+// radarrBulk returns a handler that applies an edit to many movies.
+func radarrBulk(edit func(input *BulkInput, bulk *radarr.BulkEdit) error) APIHandler {
+	return func(req *http.Request) (int, any) {
+		input, err := decodeBulkInput(req)
+		if err != nil {
+			return apiError(http.StatusBadRequest, "bulk input", err)
+		}
+
+		ids, err := radarrBulkIDs(req, input)
+		if err != nil {
+			return apiError(http.StatusServiceUnavailable, "selecting movies", err)
+		} else if len(ids) == 0 {
+			return http.StatusNotFound, ErrNoBulkItems
+		}
+
+		bulk := &radarr.BulkEdit{MovieIDs: ids}
+		if err := edit(input, bulk); err != nil {
+			return apiError(http.StatusBadRequest, "bulk input", err)
+		}
+
+		if _, err = getRadarr(req).EditMoviesContext(req.Context(), bulk); err != nil {
+			return apiError(http.StatusServiceUnavailable, "editing movies", err)
+		}
+
+		return http.StatusOK, &BulkResponse{Matched: len(ids), IDs: ids}
+	}
+}
+
+// @Description	Monitors many Radarr movies at once. Select movies with a list of IDs, a filter, or both.
+// @Summary		Bulk Monitor Radarr Movies
+// @Tags			Radarr
+// @Produce		json
+// @Accept			json
+// @Param			instance	path		int64									true	"instance ID"
+// @Param			PUT			body		apps.BulkInput							true	"ids and/or filter"
+// @Success		200			{object}	apps.APIResponse{message=apps.BulkResponse}	"edited items"
+// @Failure		400			{object}	apps.APIResponse{message=string}		"bad json input"
+// @Failure		404			{object}	apps.APIResponse{message=string}		"no items matched"
+// @Failure		503			{object}	apps.APIResponse{message=string}		"instance error"
+// @Router			/radarr/{instance}/bulk/monitor [put]
+// @Security		ApiKeyAuth
+func _() {}
+
+// @Description	Unmonitors many Radarr movies at once. Select movies with a list of IDs, a filter, or both.
+// @Summary		Bulk Unmonitor Radarr Movies
+// @Tags			Radarr
+// @Produce		json
+// @Accept			json
+// @Param			instance	path		int64									true	"instance ID"
+// @Param			PUT			body		apps.BulkInput							true	"ids and/or filter"
+// @Success		200			{object}	apps.APIResponse{message=apps.BulkResponse}	"edited items"
+// @Failure		400			{object}	apps.APIResponse{message=string}		"bad json input"
+// @Failure		404			{object}	apps.APIResponse{message=string}		"no items matched"
+// @Failure		503			{object}	apps.APIResponse{message=string}		"instance error"
+// @Router			/radarr/{instance}/bulk/unmonitor [put]
+// @Security		ApiKeyAuth
+func _() {}
+
+// @Description	Changes the quality profile on many Radarr movies at once. qualityProfileId is required.
+// @Summary		Bulk Change Radarr Quality Profile
+// @Tags			Radarr
+// @Produce		json
+// @Accept			json
+// @Param			instance	path		int64									true	"instance ID"
+// @Param			PUT			body		apps.BulkInput							true	"ids and/or filter, and qualityProfileId"
+// @Success		200			{object}	apps.APIResponse{message=apps.BulkResponse}	"edited items"
+// @Failure		400			{object}	apps.APIResponse{message=string}		"bad json input"
+// @Failure		404			{object}	apps.APIResponse{message=string}		"no items matched"
+// @Failure		503			{object}	apps.APIResponse{message=string}		"instance error"
+// @Router			/radarr/{instance}/bulk/qualityProfile [put]
+// @Security		ApiKeyAuth
+func _() {}
+
+// @Description	Adds or removes tags on many Radarr movies at once. tags are required.
+// @Summary		Bulk Add or Remove Radarr Tags
+// @Tags			Radarr
+// @Produce		json
+// @Accept			json
+// @Param			instance	path		int64									true	"instance ID"
+// @Param			action		path		string									true	"add or remove"
+// @Param			PUT			body		apps.BulkInput							true	"ids and/or filter, and tags"
+// @Success		200			{object}	apps.APIResponse{message=apps.BulkResponse}	"edited items"
+// @Failure		400			{object}	apps.APIResponse{message=string}		"bad json input"
+// @Failure		404			{object}	apps.APIResponse{message=string}		"no items matched"
+// @Failure		503			{object}	apps.APIResponse{message=string}		"instance error"
+// @Router			/radarr/{instance}/bulk/tags/{action} [put]
+// @Security		ApiKeyAuth
+func radarrBulkEdit(action string) APIHandler {
+	return radarrBulk(func(input *BulkInput, bulk *radarr.BulkEdit) error {
+		switch action {
+		case "monitor":
+			bulk.Monitored = starr.True()
+		case "unmonitor":
+			bulk.Monitored = starr.False()
+		case "qualityProfile":
+			if input.QualityProfileID == 0 {
+				return ErrNoBulkValue
+			}
+
+			bulk.QualityProfileID = &input.QualityProfileID
+		case string(starr.TagsAdd), string(starr.TagsRemove):
+			if len(input.Tags) == 0 {
+				return ErrNoBulkValue
+			}
+
+			bulk.Tags = input.Tags
+			bulk.ApplyTags = starr.ApplyTags(action)
+		}
+
+		return nil
+	})
+}
+
+// @Description	Triggers a search for many Radarr movies at once. Select movies with a list of IDs, a filter, or both.
+// @Summary		Bulk Search Radarr Movies
+// @Tags			Radarr
+// @Produce		json
+// @Accept			json
+// @Param			instance	path		int64									true	"instance ID"
+// @Param			POST		body		apps.BulkInput							true	"ids and/or filter"
+// @Success		200			{object}	apps.APIResponse{message=apps.BulkResponse}	"searched items"
+// @Failure		400			{object}	apps.APIResponse{message=string}		"bad json input"
+// @Failure		404			{object}	apps.APIResponse{message=string}		"no items matched"
+// @Failure		503			{object}	apps.APIResponse{message=string}		"instance error"
+// @Router			/radarr/{instance}/bulk/search [post]
+// @Security		ApiKeyAuth
+func radarrBulkSearch(req *http.Request) (int, any) {
+	input, err := decodeBulkInput(req)
+	if err != nil {
+		return apiError(http.StatusBadRequest, "bulk input", err)
+	}
+
+	ids, err := radarrBulkIDs(req, input)
+	if err != nil {
+		return apiError(http.StatusServiceUnavailable, "selecting movies", err)
+	} else if len(ids) == 0 {
+		return http.StatusNotFound, ErrNoBulkItems
+	}
+
+	output, err := getRadarr(req).SendCommandContext(req.Context(), &radarr.CommandRequest{
+		Name:     "MoviesSearch",
+		MovieIDs: ids,
+	})
+	if err != nil {
+		return apiError(http.StatusServiceUnavailable, "commanding movie search", err)
+	}
+
+	return http.StatusOK, &BulkResponse{Matched: len(ids), IDs: ids, Status: []string{output.Status}}
+}
+
+// @Description	Deletes many Radarr movies at once, optionally with their files. Counts against the delete rate limit.
+// @Summary		Bulk Delete Radarr Movies
+// @Tags			Radarr
+// @Produce		json
+// @Accept			json
+// @Param			instance	path		int64									true	"instance ID"
+// @Param			DELETE		body		apps.BulkInput							true	"ids and/or filter, and delete options"
+// @Success		200			{object}	apps.APIResponse{message=apps.BulkResponse}	"deleted items"
+// @Failure		400			{object}	apps.APIResponse{message=string}		"bad json input, or more items than deletes allows"
+// @Failure		404			{object}	apps.APIResponse{message=string}		"no items matched"
+// @Failure		423			{object}	apps.APIResponse{message=string}		"rate limit reached"
+// @Failure		503			{object}	apps.APIResponse{message=string}		"instance error"
+// @Router			/radarr/{instance}/bulk/delete [delete]
+// @Security		ApiKeyAuth
+func radarrBulkDelete(req *http.Request) (int, any) {
+	input, err := decodeBulkInput(req)
+	if err != nil {
+		return apiError(http.StatusBadRequest, "bulk input", err)
+	}
+
+	ids, err := radarrBulkIDs(req, input)
+	if err != nil {
+		return apiError(http.StatusServiceUnavailable, "selecting movies", err)
+	} else if len(ids) == 0 {
+		return http.StatusNotFound, ErrNoBulkItems
+	}
+
+	if err := getRadarr(req).DelAllowN(len(ids)); errors.Is(err, ErrDeleteBurst) {
+		return http.StatusBadRequest, err
+	} else if err != nil {
+		return http.StatusLocked, err
+	}
+
+	err = getRadarr(req).DeleteMoviesContext(req.Context(), &radarr.BulkEdit{
+		MovieIDs:           ids,
+		DeleteFiles:        &input.DeleteFiles,
+		AddImportExclusion: &input.AddImportExclusion,
+	})
+	if err != nil {
+		return apiError(http.StatusServiceUnavailable, "deleting movies", err)
+	}
+
+	return http.StatusOK, &BulkResponse{Matched: len(ids), IDs: ids}
+}
+
+// sonarrSeriesEditor is the input for the Sonarr series editor endpoint.
+// The starr library does not wrap this endpoint, so it lives here.
+type sonarrSeriesEditor struct {
+	SeriesIDs              []int64         `json:"seriesIds"`
+	Monitored              *bool           `json:"monitored,omitempty"`
+	QualityProfileID       *int64          `json:"qualityProfileId,omitempty"`
+	Tags                   []int           `json:"tags,omitempty"`
+	ApplyTags              starr.ApplyTags `json:"applyTags,omitempty"`
+	DeleteFiles            *bool           `json:"deleteFiles,omitempty"`
+	AddImportListExclusion *bool           `json:"addImportListExclusion,omitempty"`
+}
+
+// sonarrBulkIDs resolves the requested series IDs from the input.
+func sonarrBulkIDs(req *http.Request, input *BulkInput) ([]int64, error) {
+	if !input.needsLookup() {
+		return input.IDs, nil
+	}
+
+	series, err := getSonarr(req).GetAllSeriesContext(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("getting series: %w", err)
+	}
+
+	ids := []int64{}
+
+	for _, item := range series {
+		if input.selected(item.ID) && input.Filter.match(item.Tags, item.Path, item.QualityProfileID) {
+			ids = append(ids, item.ID)
+		}
+	}
+
+	return ids, nil
+}
+
+// sendSonarrEditor sends a PUT or DELETE to the Sonarr series editor.
+func sendSonarrEditor(req *http.Request, method string, edit *sonarrSeriesEditor) error {
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(edit); err != nil {
+		return fmt.Errorf("encoding series editor body: %w", err)
+	}
+
+	request := starr.Request{URI: path.Join(sonarr.APIver, "series", "editor"), Body: &body}
+
+	if method == http.MethodDelete {
+		if err := getSonarr(req).DeleteAny(req.Context(), request); err != nil {
+			return fmt.Errorf("api.Delete(%s): %w", &request, err)
+		}
+
+		return nil
+	}
+
+	var output []*sonarr.Series
+	if err := getSonarr(req).PutInto(req.Context(), request, &output); err != nil {
+		return fmt.Errorf("api.Put(%s): %w", &request, err)
+	}
+
+	return nil
+}
+
+// @Description	Monitors many Sonarr series at once. Select series with a list of IDs, a filter, or both.
+// @Summary		Bulk Monitor Sonarr Series
+// @Tags			Sonarr
+// @Produce		json
+// @Accept			json
+// @Param			instance	path		int64									true	"instance ID"
+// @Param			PUT			body		apps.BulkInput							true	"ids and/or filter"
+// @Success		200			{object}	apps.APIResponse{message=apps.BulkResponse}	"edited items"
+// @Failure		400			{object}	apps.APIResponse{message=string}		"bad json input"
+// @Failure		404			{object}	apps.APIResponse{message=string}		"no items matched"
+// @Failure		503			{object}	apps.APIResponse{message=string}		"instance error"
+// @Router			/sonarr/{instance}/bulk/monitor [put]
+// @Security		ApiKeyAuth
+func _() {}
+
+// @Description	Unmonitors many Sonarr series at once. Select series with a list of IDs, a filter, or both.
+// @Summary		Bulk Unmonitor Sonarr Series
+// @Tags			Sonarr
+// @Produce		json
+// @Accept			json
+// @Param			instance	path		int64									true	"instance ID"
+// @Param			PUT			body		apps.BulkInput							true	"ids and/or filter"
+// @Success		200			{object}	apps.APIResponse{message=apps.BulkResponse}	"edited items"
+// @Failure		400			{object}	apps.APIResponse{message=string}		"bad json input"
+// @Failure		404			{object}	apps.APIResponse{message=string}		"no items matched"
+// @Failure		503			{object}	apps.APIResponse{message=string}		"instance error"
+// @Router			/sonarr/{instance}/bulk/unmonitor [put]
+// @Security		ApiKeyAuth
+func _() {}
+
+// @Description	Changes the quality profile on many Sonarr series at once. qualityProfileId is required.
+// @Summary		Bulk Change Sonarr Quality Profile
+// @Tags			Sonarr
+// @Produce		json
+// @Accept			json
+// @Param			instance	path		int64									true	"instance ID"
+// @Param			PUT			body		apps.BulkInput							true	"ids and/or filter, and qualityProfileId"
+// @Success		200			{object}	apps.APIResponse{message=apps.BulkResponse}	"edited items"
+// @Failure		400			{object}	apps.APIResponse{message=string}		"bad json input"
+// @Failure		404			{object}	apps.APIResponse{message=string}		"no items matched"
+// @Failure		503			{object}	apps.APIResponse{message=string}		"instance error"
+// @Router			/sonarr/{instance}/bulk/qualityProfile [put]
+// @Security		ApiKeyAuth
+func _() {}
+
+// @Description	Adds or removes tags on many Sonarr series at once. tags are required.
+// @Summary		Bulk Add or Remove Sonarr Tags
+// @Tags			Sonarr
+// @Produce		json
+// @Accept			json
+// @Param			instance	path		int64									true	"instance ID"
+// @Param			action		path		string									true	"add or remove"
+// @Param			PUT			body		apps.BulkInput							true	"ids and/or filter, and tags"
+// @Success		200			{object}	apps.APIResponse{message=apps.BulkResponse}	"edited items"
+// @Failure		400			{object}	apps.APIResponse{message=string}		"bad json input"
+// @Failure		404			{object}	apps.APIResponse{message=string}		"no items matched"
+// @Failure		503			{object}	apps.APIResponse{message=string}		"instance error"
+// @Router			/sonarr/{instance}/bulk/tags/{action} [put]
+// @Security		ApiKeyAuth
+func sonarrBulkEdit(action string) APIHandler {
+	return func(req *http.Request) (int, any) {
+		input, err := decodeBulkInput(req)
+		if err != nil {
+			return apiError(http.StatusBadRequest, "bulk input", err)
+		}
+
+		ids, err := sonarrBulkIDs(req, input)
+		if err != nil {
+			return apiError(http.StatusServiceUnavailable, "selecting series", err)
+		} else if len(ids) == 0 {
+			return http.StatusNotFound, ErrNoBulkItems
+		}
+
+		edit := &sonarrSeriesEditor{SeriesIDs: ids}
+
+		switch action {
+		case "monitor":
+			edit.Monitored = starr.True()
+		case "unmonitor":
+			edit.Monitored = starr.False()
+		case "qualityProfile":
+			if input.QualityProfileID == 0 {
+				return apiError(http.StatusBadRequest, "bulk input", ErrNoBulkValue)
+			}
+
+			edit.QualityProfileID = &input.QualityProfileID
+		case string(starr.TagsAdd), string(starr.TagsRemove):
+			if len(input.Tags) == 0 {
+				return apiError(http.StatusBadRequest, "bulk input", ErrNoBulkValue)
+			}
+
+			edit.Tags = input.Tags
+			edit.ApplyTags = starr.ApplyTags(action)
+		}
+
+		if err := sendSonarrEditor(req, http.MethodPut, edit); err != nil {
+			return apiError(http.StatusServiceUnavailable, "editing series", err)
+		}
+
+		return http.StatusOK, &BulkResponse{Matched: len(ids), IDs: ids}
+	}
+}
+
+// @Description	Triggers a search for many Sonarr series at once. Select series with a list of IDs, a filter, or both.
+// @Description	Sonarr has no multi-series search, so this sends one SeriesSearch command for each series,
+// @Description	a few at a time.
+// @Summary		Bulk Search Sonarr Series
+// @Tags			Sonarr
+// @Produce		json
+// @Accept			json
+// @Param			instance	path		int64									true	"instance ID"
+// @Param			POST		body		apps.BulkInput							true	"ids and/or filter"
+// @Success		200			{object}	apps.APIResponse{message=apps.BulkResponse}	"searched items"
+// @Failure		400			{object}	apps.APIResponse{message=string}		"bad json input"
+// @Failure		404			{object}	apps.APIResponse{message=string}		"no items matched"
+// @Failure		503			{object}	apps.APIResponse{message=string}		"instance error"
+// @Router			/sonarr/{instance}/bulk/search [post]
+// @Security		ApiKeyAuth
+func sonarrBulkSearch(req *http.Request) (int, any) {
+	input, err := decodeBulkInput(req)
+	if err != nil {
+		return apiError(http.StatusBadRequest, "bulk input", err)
+	}
+
+	ids, err := sonarrBulkIDs(req, input)
+	if err != nil {
+		return apiError(http.StatusServiceUnavailable, "selecting series", err)
+	} else if len(ids) == 0 {
+		return http.StatusNotFound, ErrNoBulkItems
+	}
+
+	var (
+		status = make([]string, len(ids))
+		sent   = make([]bool, len(ids))
+		limit  = make(chan struct{}, bulkSearchWorkers)
+		wtgrp  sync.WaitGroup
+	)
+
+	for idx, seriesID := range ids {
+		wtgrp.Go(func() {
+			limit <- struct{}{}
+			defer func() { <-limit }()
+
+			cmd, err := getSonarr(req).SendCommandContext(req.Context(), &sonarr.CommandRequest{
+				Name:     "SeriesSearch",
+				SeriesID: seriesID,
+			})
+			if err != nil {
+				status[idx] = fmt.Sprintf("%d: %v", seriesID, err)
+				return
+			}
+
+			sent[idx] = true
+			status[idx] = fmt.Sprintf("%d: %s", seriesID, cmd.Status)
+		})
+	}
+
+	wtgrp.Wait()
+
+	output := &BulkResponse{Matched: len(ids), IDs: []int64{}, Status: status}
+
+	for idx, seriesID := range ids {
+		if sent[idx] {
+			output.IDs = append(output.IDs, seriesID)
+		}
+	}
+
+	return http.StatusOK, output
+}
+
+// @Description	Deletes many Sonarr series at once, optionally with their files. Counts against the delete rate limit.
+// @Summary		Bulk Delete Sonarr Series
+// @Tags			Sonarr
+// @Produce		json
+// @Accept			json
+// @Param			instance	path		int64									true	"instance ID"
+// @Param			DELETE		body		apps.BulkInput							true	"ids and/or filter, and delete options"
+// @Success		200			{object}	apps.APIResponse{message=apps.BulkResponse}	"deleted items"
+// @Failure		400			{object}	apps.APIResponse{message=string}		"bad json input, or more items than deletes allows"
+// @Failure		404			{object}	apps.APIResponse{message=string}		"no items matched"
+// @Failure		423			{object}	apps.APIResponse{message=string}		"rate limit reached"
+// @Failure		503			{object}	apps.APIResponse{message=string}		"instance error"
+// @Router			/sonarr/{instance}/bulk/delete [delete]
+// @Security		ApiKeyAuth
+func sonarrBulkDelete(req *http.Request) (int, any) {
+	input, err := decodeBulkInput(req)
+	if err != nil {
+		return apiError(http.StatusBadRequest, "bulk input", err)
+	}
+
+	ids, err := sonarrBulkIDs(req, input)
+	if err != nil {
+		return apiError(http.StatusServiceUnavailable, "selecting series", err)
+	} else if len(ids) == 0 {
+		return http.StatusNotFound, ErrNoBulkItems
+	}
+
+	if err := getSonarr(req).DelAllowN(len(ids)); errors.Is(err, ErrDeleteBurst) {
+		return http.StatusBadRequest, err
+	} else if err != nil {
+		return http.StatusLocked, err
+	}
+
+	err = sendSonarrEditor(req, http.MethodDelete, &sonarrSeriesEditor{
+		SeriesIDs:              ids,
+		DeleteFiles:            &input.DeleteFiles,
+		AddImportListExclusion: &input.AddImportExclusion,
+	})
+	if err != nil {
+		return apiError(http.StatusServiceUnavailable, "deleting series", err)
+	}
+
+	return http.StatusOK, &BulkResponse{Matched: len(ids), IDs: ids}
+}
@@ -0,0 +1,73 @@
+package apps //nolint:testpackage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+func TestBulkFilterMatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		filter  *BulkFilter
+		tags    []int
+		path    string
+		profile int64
+		want    bool
+	}{
+		{name: "nil filter", filter: nil, path: "/movies/a", want: true},
+		{name: "empty filter", filter: &BulkFilter{}, path: "/movies/a", want: true},
+		{name: "tag match", filter: &BulkFilter{Tag: 2}, tags: []int{1, 2}, want: true},
+		{name: "tag miss", filter: &BulkFilter{Tag: 3}, tags: []int{1, 2}, want: false},
+		{name: "profile match", filter: &BulkFilter{QualityProfileID: 4}, profile: 4, want: true},
+		{name: "profile miss", filter: &BulkFilter{QualityProfileID: 4}, profile: 5, want: false},
+		{name: "root match", filter: &BulkFilter{RootFolder: "/movies"}, path: "/movies/a", want: true},
+		{name: "root trailing slash", filter: &BulkFilter{RootFolder: "/movies/"}, path: "/movies/a", want: true},
+		{name: "root prefix only", filter: &BulkFilter{RootFolder: "/movies"}, path: "/movies2/a", want: false},
+		{name: "windows root", filter: &BulkFilter{RootFolder: `D:\Movies\`}, path: `D:\Movies\a`, want: true},
+		{
+			name:    "all must match",
+			filter:  &BulkFilter{Tag: 1, RootFolder: "/tv", QualityProfileID: 2},
+			tags:    []int{1},
+			path:    "/tv/show",
+			profile: 3,
+			want:    false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.want, test.filter.match(test.tags, test.path, test.profile))
+		})
+	}
+}
+
+func TestBulkInputNeedsLookup(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	assert.False((&BulkInput{IDs: []int64{1}}).needsLookup(), "ids without a filter need no lookup")
+	assert.False((&BulkInput{Filter: &BulkFilter{}}).needsLookup(), "an empty filter needs no lookup")
+	assert.True((&BulkInput{Filter: &BulkFilter{Tag: 1}}).needsLookup())
+	assert.True((&BulkInput{Filter: &BulkFilter{RootFolder: "/tv"}}).needsLookup())
+	assert.True((&BulkInput{Filter: &BulkFilter{QualityProfileID: 1}}).needsLookup())
+	assert.True((&BulkInput{}).selected(5), "no ids selects everything")
+	assert.False((&BulkInput{IDs: []int64{1, 2}}).selected(5))
+}
+
+func TestDelAllowN(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	app := StarrApp{Deletes: 3, delLimit: rate.NewLimiter(rate.Every(time.Hour/3), 3)}
+	assert.ErrorIs(app.DelAllowN(4), ErrDeleteBurst, "more than the burst can never be allowed")
+	assert.NoError(app.DelAllowN(2))
+	assert.ErrorIs(app.DelAllowN(2), ErrRateLimit, "only one delete remains")
+	assert.NoError(app.DelAllowN(1))
+	assert.ErrorIs(StarrApp{}.DelAllowN(1), ErrRateLimit, "deletes are disabled")
+}
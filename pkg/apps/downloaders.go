@@ -0,0 +1,386 @@
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"golift.io/starr"
+)
+
+/* The downloader control API lets the website list and control transfers in every download client. */
+
+// Download client app names. These are used in API paths, like /api/qbit/1/transfers.
+const (
+	AppDeluge       starr.App = "Deluge"
+	AppNZBGet       starr.App = "NZBGet"
+	AppQbit         starr.App = "Qbit"
+	AppRtorrent     starr.App = "Rtorrent"
+	AppSabNZB       starr.App = "SabNZBd"
+	AppTransmission starr.App = "Transmission"
+)
+
+// Errors returned by the downloader control methods.
+var (
+	ErrNoDownloader  = errors.New("configured download client ID not found")
+	ErrUnsupported   = errors.New("this download client does not support the requested action")
+	ErrNoTransferIDs = errors.New("at least one transfer ID must be provided")
+)
+
+// Downloader is the common interface for every download client.
+// Transfer IDs are hashes for torrent clients, and queue IDs for usenet clients.
+type Downloader interface {
+	Enabled() bool
+	// Transfers returns every transfer in the client.
+	Transfers(ctx context.Context) ([]*Transfer, error)
+	// PauseTransfers pauses (stops) the provided transfers.
+	PauseTransfers(ctx context.Context, ids []string) error
+	// ResumeTransfers resumes (starts) the provided transfers.
+	ResumeTransfers(ctx context.Context, ids []string) error
+	// RemoveTransfers removes the provided transfers, and their data if deleteData is true.
+	RemoveTransfers(ctx context.Context, ids []string, deleteData bool) error
+	// SetTransferCategory sets the category or label on the provided transfers.
+	SetTransferCategory(ctx context.Context, ids []string, category string) error
+	// SpeedLimits returns the current global speed limits. Usenet clients have no upload limit.
+	SpeedLimits(ctx context.Context) (*SpeedLimits, error)
+	// SetSpeedLimits sets the global speed limits. Usenet clients ignore the upload limit.
+	SetSpeedLimits(ctx context.Context, limits *SpeedLimits) error
+}
+
+// Transfer is a single download in any download client.
+type Transfer struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	State      string    `json:"state"`
+	Category   string    `json:"category"`
+	Path       string    `json:"path,omitempty"`
+	Size       int64     `json:"size"`
+	Downloaded int64     `json:"downloaded"`
+	Uploaded   int64     `json:"uploaded,omitempty"`
+	Progress   float64   `json:"progress"` // 0-100
+	Ratio      float64   `json:"ratio,omitempty"`
+	DownRate   int64     `json:"downRate"`
+	UpRate     int64     `json:"upRate,omitempty"`
+	Paused     bool      `json:"paused"`
+	Added      time.Time `json:"added,omitzero"`
+	// The following are only provided by torrent clients.
+	Seeds    int64  `json:"seeds"`              // Connected seeds.
+	ETA      int64  `json:"eta"`                // Seconds remaining; 0 is unknown or done, -1 is infinite.
+	SeedTime int64  `json:"seedTime,omitempty"` // Seconds spent seeding.
+	Metadata bool   `json:"metadata,omitempty"` // True while the torrent is downloading metadata.
+	Tracker  string `json:"tracker,omitempty"`  // Current tracker URL or host. rTorrent provides the first tracker.
+}
+
+// SpeedLimits are global speed limits in bytes per second. Zero means unlimited.
+type SpeedLimits struct {
+	Download int64 `json:"download"`
+	Upload   int64 `json:"upload"`
+}
+
+// TransferInput is the input payload for the transfer control endpoints.
+type TransferInput struct {
+	IDs        []string `json:"ids"`
+	DeleteData bool     `json:"deleteData,omitempty"`
+	Category   string   `json:"category,omitempty"`
+}
+
+// DownloaderApps returns the app names of every download client.
+func DownloaderApps() []starr.App {
+	return []starr.App{AppDeluge, AppNZBGet, AppQbit, AppRtorrent, AppSabNZB, AppTransmission}
+}
+
+// Downloaders returns every configured download client for the provided apps, or all of them if none are provided.
+// Disabled clients are included; check Enabled() before using one.
+func (a *Apps) Downloaders(app ...starr.App) []Downloader {
+	if len(app) == 0 {
+		app = DownloaderApps()
+	}
+
+	output := []Downloader{}
+
+	for _, name := range app {
+		for idx := range a.downloaderCount(name) {
+			output = append(output, a.Downloader(name, idx))
+		}
+	}
+
+	return output
+}
+
+// Downloader returns a single download client by app name and 0-based index.
+// Returns nil if the index is out of range.
+func (a *Apps) Downloader(app starr.App, idx int) Downloader {
+	if idx < 0 || idx >= a.downloaderCount(app) {
+		return nil
+	}
+
+	switch app { //nolint:exhaustive // Only download clients.
+	case AppDeluge:
+		return &a.Deluge[idx]
+	case AppNZBGet:
+		return &a.NZBGet[idx]
+	case AppQbit:
+		return &a.Qbit[idx]
+	case AppRtorrent:
+		return &a.Rtorrent[idx]
+	case AppSabNZB:
+		return &a.SabNZB[idx]
+	case AppTransmission:
+		return &a.Transmission[idx]
+	default:
+		return nil
+	}
+}
+
+func (a *Apps) downloaderCount(app starr.App) int {
+	switch app { //nolint:exhaustive // Only download clients.
+	case AppDeluge:
+		return len(a.Deluge)
+	case AppNZBGet:
+		return len(a.NZBGet)
+	case AppQbit:
+		return len(a.Qbit)
+	case AppRtorrent:
+		return len(a.Rtorrent)
+	case AppSabNZB:
+		return len(a.SabNZB)
+	case AppTransmission:
+		return len(a.Transmission)
+	default:
+		return 0
+	}
+}
+
+// downloaderHandlers is called once on startup to register the web API paths.
+func (a *Apps) downloaderHandlers() {
+	for _, app := range DownloaderApps() {
+		a.HandleAPIpath(app, "/transfers", a.downloaderAPI(app, downloaderTransfers), "GET")
+		a.HandleAPIpath(app, "/transfers/pause", a.downloaderAPI(app, downloaderPause), "PUT")
+		a.HandleAPIpath(app, "/transfers/resume", a.downloaderAPI(app, downloaderResume), "PUT")
+		a.HandleAPIpath(app, "/transfers/category", a.downloaderAPI(app, downloaderCategory), "PUT")
+		a.HandleAPIpath(app, "/transfers", a.downloaderAPI(app, downloaderRemove), "DELETE")
+		a.HandleAPIpath(app, "/speedlimits", a.downloaderAPI(app, downloaderSpeedLimits), "GET")
+		a.HandleAPIpath(app, "/speedlimits", a.downloaderAPI(app, downloaderSetSpeedLimits), "PUT")
+	}
+}
+
+// downloaderAPI finds the requested download client and passes it to the handler.
+func (a *Apps) downloaderAPI(
+	app starr.App,
+	handler func(req *http.Request, client Downloader) (int, any),
+) APIHandler {
+	return func(req *http.Request) (int, any) {
+		idx, _ := req.Context().Value(app).(int)
+
+		client := a.Downloader(app, idx)
+		if client == nil || !client.Enabled() {
+			return http.StatusNotFound, fmt.Errorf("%s %d: %w", app, idx+1, ErrNoDownloader)
+		}
+
+		return handler(req, client)
+	}
+}
+
+func decodeTransferInput(req *http.Request) (*TransferInput, error) {
+	var input TransferInput
+	if err := json.NewDecoder(req.Body).Decode(&input); err != nil {
+		return nil, fmt.Errorf("decoding payload: %w", err)
+	}
+
+	if len(input.IDs) == 0 {
+		return nil, ErrNoTransferIDs
+	}
+
+	return &input, nil
+}
+
+// downloaderError returns a better code for unsupported actions.
+func downloaderError(msg string, err error) (int, error) {
+	if errors.Is(err, ErrUnsupported) {
+		return http.StatusNotImplemented, fmt.Errorf("%s: %w", msg, err)
+	}
+
+	return http.StatusServiceUnavailable, fmt.Errorf("%s: %w", msg, err)
+}
+
+// @Description	Returns every transfer in a download client.
+// @Summary		Get Download Client Transfers
+// @Tags			Downloaders
+// @Produce		json
+// @Param			app			path		string									true	"download client"	Enums(deluge, nzbget, qbit, rtorrent, sabnzbd, transmission)
+// @Param			instance	path		int64									true	"instance ID"
+// @Success		200			{object}	apps.APIResponse{message=[]apps.Transfer}	"transfers"
+// @Failure		404			{object}	apps.APIResponse{message=string}		"instance not found"
+// @Failure		503			{object}	apps.APIResponse{message=string}		"instance error"
+// @Router			/{app}/{instance}/transfers [get]
+// @Security		ApiKeyAuth
+func downloaderTransfers(req *http.Request, client Downloader) (int, any) {
+	transfers, err := client.Transfers(req.Context())
+	if err != nil {
+		return downloaderError("getting transfers", err)
+	}
+
+	return http.StatusOK, transfers
+}
+
+// @Description	Pauses transfers in a download client.
+// @Summary		Pause Download Client Transfers
+// @Tags			Downloaders
+// @Produce		json
+// @Accept			json
+// @Param			app			path		string								true	"download client"	Enums(deluge, nzbget, qbit, rtorrent, sabnzbd, transmission)
+// @Param			instance	path		int64								true	"instance ID"
+// @Param			PUT			body		apps.TransferInput					true	"transfer ids"
+// @Success		200			{object}	apps.APIResponse{message=string}	"success"
+// @Failure		400			{object}	apps.APIResponse{message=string}	"bad json input"
+// @Failure		404			{object}	apps.APIResponse{message=string}	"instance not found"
+// @Failure		503			{object}	apps.APIResponse{message=string}	"instance error"
+// @Router			/{app}/{instance}/transfers/pause [put]
+// @Security		ApiKeyAuth
+func downloaderPause(req *http.Request, client Downloader) (int, any) {
+	input, err := decodeTransferInput(req)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if err := client.PauseTransfers(req.Context(), input.IDs); err != nil {
+		return downloaderError("pausing transfers", err)
+	}
+
+	return http.StatusOK, mnd.Success
+}
+
+// @Description	Resumes transfers in a download client.
+// @Summary		Resume Download Client Transfers
+// @Tags			Downloaders
+// @Produce		json
+// @Accept			json
+// @Param			app			path		string								true	"download client"	Enums(deluge, nzbget, qbit, rtorrent, sabnzbd, transmission)
+// @Param			instance	path		int64								true	"instance ID"
+// @Param			PUT			body		apps.TransferInput					true	"transfer ids"
+// @Success		200			{object}	apps.APIResponse{message=string}	"success"
+// @Failure		400			{object}	apps.APIResponse{message=string}	"bad json input"
+// @Failure		404			{object}	apps.APIResponse{message=string}	"instance not found"
+// @Failure		503			{object}	apps.APIResponse{message=string}	"instance error"
+// @Router			/{app}/{instance}/transfers/resume [put]
+// @Security		ApiKeyAuth
+func downloaderResume(req *http.Request, client Downloader) (int, any) {
+	input, err := decodeTransferInput(req)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if err := client.ResumeTransfers(req.Context(), input.IDs); err != nil {
+		return downloaderError("resuming transfers", err)
+	}
+
+	return http.StatusOK, mnd.Success
+}
+
+// @Description	Sets the category (or label) on transfers in a download client.
+// @Summary		Set Download Client Transfer Category
+// @Tags			Downloaders
+// @Produce		json
+// @Accept			json
+// @Param			app			path		string								true	"download client"	Enums(deluge, nzbget, qbit, rtorrent, sabnzbd, transmission)
+// @Param			instance	path		int64								true	"instance ID"
+// @Param			PUT			body		apps.TransferInput					true	"transfer ids and category"
+// @Success		200			{object}	apps.APIResponse{message=string}	"success"
+// @Failure		400			{object}	apps.APIResponse{message=string}	"bad json input"
+// @Failure		404			{object}	apps.APIResponse{message=string}	"instance not found"
+// @Failure		503			{object}	apps.APIResponse{message=string}	"instance error"
+// @Router			/{app}/{instance}/transfers/category [put]
+// @Security		ApiKeyAuth
+func downloaderCategory(req *http.Request, client Downloader) (int, any) {
+	input, err := decodeTransferInput(req)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if err := client.SetTransferCategory(req.Context(), input.IDs, input.Category); err != nil {
+		return downloaderError("setting transfer category", err)
+	}
+
+	return http.StatusOK, mnd.Success
+}
+
+// @Description	Removes transfers from a download client, optionally with their data.
+// @Summary		Remove Download Client Transfers
+// @Tags			Downloaders
+// @Produce		json
+// @Accept			json
+// @Param			app			path		string								true	"download client"	Enums(deluge, nzbget, qbit, rtorrent, sabnzbd, transmission)
+// @Param			instance	path		int64								true	"instance ID"
+// @Param			DELETE		body		apps.TransferInput					true	"transfer ids and deleteData"
+// @Success		200			{object}	apps.APIResponse{message=string}	"success"
+// @Failure		400			{object}	apps.APIResponse{message=string}	"bad json input"
+// @Failure		404			{object}	apps.APIResponse{message=string}	"instance not found"
+// @Failure		501			{object}	apps.APIResponse{message=string}	"action not supported"
+// @Failure		503			{object}	apps.APIResponse{message=string}	"instance error"
+// @Router			/{app}/{instance}/transfers [delete]
+// @Security		ApiKeyAuth
+func downloaderRemove(req *http.Request, client Downloader) (int, any) {
+	input, err := decodeTransferInput(req)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if err := client.RemoveTransfers(req.Context(), input.IDs, input.DeleteData); err != nil {
+		return downloaderError("removing transfers", err)
+	}
+
+	return http.StatusOK, mnd.Success
+}
+
+// @Description	Returns the global download and upload speed limits in bytes per second. Zero is no limit.
+// @Description	Usenet clients have no upload limit.
+// @Summary		Get Download Client Speed Limits
+// @Tags			Downloaders
+// @Produce		json
+// @Param			app			path		string									true	"download client"	Enums(deluge, nzbget, qbit, rtorrent, sabnzbd, transmission)
+// @Param			instance	path		int64									true	"instance ID"
+// @Success		200			{object}	apps.APIResponse{message=apps.SpeedLimits}	"speed limits"
+// @Failure		404			{object}	apps.APIResponse{message=string}		"instance not found"
+// @Failure		503			{object}	apps.APIResponse{message=string}		"instance error"
+// @Router			/{app}/{instance}/speedlimits [get]
+// @Security		ApiKeyAuth
+func downloaderSpeedLimits(req *http.Request, client Downloader) (int, any) {
+	limits, err := client.SpeedLimits(req.Context())
+	if err != nil {
+		return downloaderError("getting speed limits", err)
+	}
+
+	return http.StatusOK, limits
+}
+
+// @Description	Sets global download and upload speed limits in bytes per second. Zero removes the limit.
+// @Description	Usenet clients ignore the upload limit.
+// @Summary		Set Download Client Speed Limits
+// @Tags			Downloaders
+// @Produce		json
+// @Accept			json
+// @Param			app			path		string								true	"download client"	Enums(deluge, nzbget, qbit, rtorrent, sabnzbd, transmission)
+// @Param			instance	path		int64								true	"instance ID"
+// @Param			PUT			body		apps.SpeedLimits					true	"speed limits"
+// @Success		200			{object}	apps.APIResponse{message=string}	"success"
+// @Failure		400			{object}	apps.APIResponse{message=string}	"bad json input"
+// @Failure		404			{object}	apps.APIResponse{message=string}	"instance not found"
+// @Failure		503			{object}	apps.APIResponse{message=string}	"instance error"
+// @Router			/{app}/{instance}/speedlimits [put]
+// @Security		ApiKeyAuth
+func downloaderSetSpeedLimits(req *http.Request, client Downloader) (int, any) {
+	var limits SpeedLimits
+	if err := json.NewDecoder(req.Body).Decode(&limits); err != nil {
+		return apiError(http.StatusBadRequest, "decoding payload", err)
+	}
+
+	if err := client.SetSpeedLimits(req.Context(), &limits); err != nil {
+		return downloaderError("setting speed limits", err)
+	}
+
+	return http.StatusOK, mnd.Success
+}
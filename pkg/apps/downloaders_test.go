@@ -0,0 +1,225 @@
+package apps //nolint:testpackage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hekmon/transmissionrpc/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golift.io/qbit"
+)
+
+var errOffline = errors.New("client offline")
+
+func TestKiB(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		bytes int64
+		kib   int64
+		float float64
+	}{
+		{bytes: -5, kib: 0, float: -1},
+		{bytes: 0, kib: 0, float: -1},
+		{bytes: 1, kib: 1, float: 1.0 / 1024},
+		{bytes: 1023, kib: 1, float: 1023.0 / 1024},
+		{bytes: 1024, kib: 1, float: 1},
+		{bytes: 1025, kib: 2, float: 1025.0 / 1024},
+		{bytes: 10 * 1024, kib: 10, float: 10},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.kib, kib(test.bytes), "limits under 1 KiB must not become 0: %d", test.bytes)
+		assert.InDelta(t, test.float, kibOrUnlimited(test.bytes), 0.0001, test.bytes)
+	}
+}
+
+// qbitServer is a qBittorrent web API that requires a session cookie.
+type qbitServer struct {
+	logins atomic.Int32
+	sid    atomic.Value
+	limits map[string]string
+	calls  []string
+}
+
+func (q *qbitServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	uri := strings.TrimPrefix(r.URL.Path, "/api/v2/")
+	_ = r.ParseForm()
+
+	if uri == "auth/login" {
+		if r.PostForm.Get("username") != "admin" || r.PostForm.Get("password") != "secret" {
+			_, _ = w.Write([]byte("Fails."))
+			return
+		}
+
+		sid := fmt.Sprint("sid", q.logins.Add(1))
+		q.sid.Store(sid)
+		http.SetCookie(w, &http.Cookie{Name: "SID", Value: sid, Path: "/"})
+		_, _ = w.Write([]byte("Ok."))
+
+		return
+	}
+
+	if cookie, err := r.Cookie("SID"); err != nil || cookie.Value != q.sid.Load() {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	q.calls = append(q.calls, uri)
+
+	switch uri {
+	case "torrents/info":
+		_, _ = w.Write([]byte(`[{"hash":"abc","name":"Movie","state":"stalledDL","progress":0.5,"eta":8640000,` +
+			`"save_path":"/downloads","num_seeds":0},{"hash":"def","name":"Show","state":"stoppedUP","progress":1,"eta":0}]`))
+	case "torrents/stop":
+		w.WriteHeader(http.StatusNotFound) // qBittorrent v4 has pause instead.
+	case "transfer/setDownloadLimit", "transfer/setUploadLimit":
+		q.limits[uri] = r.PostForm.Get("limit")
+	case "transfer/downloadLimit":
+		_, _ = w.Write([]byte(q.limits["transfer/setDownloadLimit"]))
+	case "transfer/uploadLimit":
+		_, _ = w.Write([]byte(q.limits["transfer/setUploadLimit"]))
+	}
+}
+
+func TestQbit(t *testing.T) {
+	t.Parallel()
+
+	server := &qbitServer{limits: map[string]string{}}
+	httpd := httptest.NewServer(server)
+	defer httpd.Close()
+
+	jar, _ := cookiejar.New(nil)
+	client := &Qbit{QbitConfig: QbitConfig{Config: qbit.Config{
+		URL: httpd.URL, User: "admin", Pass: "secret", Client: &http.Client{Jar: jar},
+	}}}
+
+	xfers, err := client.Transfers(t.Context())
+	require.NoError(t, err)
+	require.Len(t, xfers, 2)
+	assert.Equal(t, &Transfer{
+		ID: "abc", Name: "Movie", State: "stalledDL", Path: "/downloads", Progress: 50, ETA: -1,
+		Added: xfers[0].Added,
+	}, xfers[0], "an infinite ETA is -1")
+	assert.True(t, xfers[1].Paused, "stopped torrents are paused")
+
+	require.NoError(t, client.PauseTransfers(t.Context(), []string{"abc", "def"}))
+	require.NoError(t, client.SetSpeedLimits(t.Context(), &SpeedLimits{Download: 2048, Upload: -1}))
+
+	limits, err := client.SpeedLimits(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, &SpeedLimits{Download: 2048}, limits, "negative limits are unlimited")
+	assert.Equal(t, int32(1), server.logins.Load(), "the session is reused")
+	assert.Contains(t, server.calls, "torrents/pause", "v4 pause is tried after v5 stop")
+
+	server.sid.Store("expired")
+
+	_, err = client.Transfers(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), server.logins.Load(), "an expired session logs in again")
+
+	client.Pass = "wrong"
+	server.sid.Store("expired")
+
+	_, err = client.Transfers(t.Context())
+	require.ErrorIs(t, err, ErrDownloaderRequest)
+}
+
+func TestXmissionSetSpeedLimits(t *testing.T) {
+	t.Parallel()
+
+	var args map[string]any
+
+	httpd := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Arguments map[string]any `json:"arguments"`
+			Tag       int            `json:"tag"`
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &req)
+		args = req.Arguments
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"result": "success", "tag": req.Tag, "arguments": map[string]any{}})
+	}))
+	defer httpd.Close()
+
+	endpoint, _ := url.Parse(httpd.URL)
+	rpc, err := transmissionrpc.New(endpoint, nil)
+	require.NoError(t, err)
+
+	client := &Xmission{Client: rpc}
+	require.NoError(t, client.SetSpeedLimits(t.Context(), &SpeedLimits{Download: 100, Upload: 0}))
+	assert.InDelta(t, 1, args["speed-limit-down"], 0, "limits under 1 KiB are rounded up, not 0")
+	assert.Equal(t, true, args["speed-limit-down-enabled"])
+	assert.Equal(t, false, args["speed-limit-up-enabled"], "0 disables the limit")
+}
+
+// fakeDownloader is a download client for the API handlers.
+type fakeDownloader struct {
+	Downloader
+	limits *SpeedLimits
+	paused []string
+	err    error
+}
+
+func (f *fakeDownloader) Enabled() bool { return true }
+
+func (f *fakeDownloader) SpeedLimits(_ context.Context) (*SpeedLimits, error) {
+	return f.limits, f.err
+}
+
+func (f *fakeDownloader) SetSpeedLimits(_ context.Context, limits *SpeedLimits) error {
+	f.limits = limits
+	return f.err
+}
+
+func (f *fakeDownloader) PauseTransfers(_ context.Context, ids []string) error {
+	f.paused = ids
+	return f.err
+}
+
+func (f *fakeDownloader) RemoveTransfers(_ context.Context, _ []string, _ bool) error {
+	return f.err
+}
+
+func TestDownloaderHandlers(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	request := func(body string) *http.Request {
+		return httptest.NewRequestWithContext(t.Context(), http.MethodPut, "/", strings.NewReader(body))
+	}
+
+	client := &fakeDownloader{}
+	code, _ := downloaderSetSpeedLimits(request(`{"download":1024,"upload":512}`), client)
+	assert.Equal(http.StatusOK, code)
+
+	code, msg := downloaderSpeedLimits(request(""), client)
+	assert.Equal(http.StatusOK, code)
+	assert.Equal(&SpeedLimits{Download: 1024, Upload: 512}, msg)
+
+	code, _ = downloaderPause(request(`{"ids":[]}`), client)
+	assert.Equal(http.StatusBadRequest, code, "transfer ids are required")
+
+	code, _ = downloaderPause(request(`{"ids":["abc"]}`), client)
+	assert.Equal(http.StatusOK, code)
+	assert.Equal([]string{"abc"}, client.paused)
+
+	code, _ = downloaderRemove(request(`{"ids":["abc"]}`), &fakeDownloader{err: ErrUnsupported})
+	assert.Equal(http.StatusNotImplemented, code)
+
+	code, _ = downloaderSpeedLimits(request(""), &fakeDownloader{err: errOffline})
+	assert.Equal(http.StatusServiceUnavailable, code)
+}
@@ -0,0 +1,730 @@
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/hekmon/transmissionrpc/v3"
+	"golift.io/deluge"
+	"golift.io/qbit"
+)
+
+/* Downloader interface implementations for the torrent clients. */
+
+// ErrDownloaderRequest is returned when a download client request fails.
+var ErrDownloaderRequest = errors.New("download client request failed")
+
+// errQbitForbidden is returned by qBittorrent when there is no session cookie, or it expired.
+var errQbitForbidden = fmt.Errorf("%w: forbidden", ErrDownloaderRequest)
+
+// Deluge 1.x and 2.x have different method names for multi-item actions.
+// These try the 2.x method first and fall back to the 1.x method.
+func (d *Deluge) call(ctx context.Context, method, fallback string, params ...any) error {
+	_, err := d.Get(ctx, method, params)
+	if err != nil && fallback != "" {
+		_, err = d.Get(ctx, fallback, params)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+
+	return nil
+}
+
+// Transfers returns every transfer in Deluge.
+func (d *Deluge) Transfers(ctx context.Context) ([]*Transfer, error) {
+	xfers, err := d.GetXfersContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting transfers: %w", err)
+	}
+
+	output := make([]*Transfer, 0, len(xfers))
+
+	for hash, xfer := range xfers {
+		output = append(output, &Transfer{
+			ID:         hash,
+			Name:       xfer.Name,
+			State:      xfer.State,
+			Category:   xfer.Label,
+			Path:       xfer.SavePath,
+			Size:       xfer.TotalSize,
+			Downloaded: xfer.TotalDone,
+			Uploaded:   xfer.TotalUploaded,
+			Progress:   xfer.Progress,
+			Ratio:      xfer.Ratio,
+			DownRate:   xfer.DownloadPayloadRate,
+			UpRate:     xfer.UploadPayloadRate,
+			Paused:     xfer.Paused,
+			Added:      time.Unix(int64(xfer.TimeAdded), 0),
+			Seeds:      xfer.NumSeeds,
+			ETA:        delugeETA(xfer),
+			SeedTime:   xfer.SeedingTime,
+			Metadata:   xfer.TotalSize == 0 && !xfer.Paused,
+			Tracker:    xfer.TrackerHost,
+		})
+	}
+
+	return output, nil
+}
+
+// delugeETA returns -1 for an incomplete transfer that is not moving. Deluge uses 0 for done and unknown.
+func delugeETA(xfer *deluge.XferStatus) int64 {
+	eta, _ := xfer.Eta.Int64()
+	if eta == 0 && xfer.Progress < 100 && xfer.DownloadPayloadRate == 0 && !xfer.Paused { //nolint:mnd // percent
+		return -1
+	}
+
+	return eta
+}
+
+// PauseTransfers pauses transfers in Deluge.
+func (d *Deluge) PauseTransfers(ctx context.Context, ids []string) error {
+	return d.call(ctx, "core.pause_torrents", "core.pause_torrent", ids)
+}
+
+// ResumeTransfers resumes transfers in Deluge.
+func (d *Deluge) ResumeTransfers(ctx context.Context, ids []string) error {
+	return d.call(ctx, "core.resume_torrents", "core.resume_torrent", ids)
+}
+
+// RemoveTransfers removes transfers from Deluge.
+func (d *Deluge) RemoveTransfers(ctx context.Context, ids []string, deleteData bool) error {
+	if err := d.call(ctx, "core.remove_torrents", "", ids, deleteData); err == nil {
+		return nil
+	}
+
+	for _, id := range ids { // Deluge 1.x removes one at a time.
+		if err := d.call(ctx, "core.remove_torrent", "", id, deleteData); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetTransferCategory sets the label on transfers in Deluge. Requires the Label plugin.
+func (d *Deluge) SetTransferCategory(ctx context.Context, ids []string, category string) error {
+	for _, id := range ids {
+		if err := d.call(ctx, "label.set_torrent", "", id, category); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetSpeedLimits sets the global speed limits in Deluge.
+func (d *Deluge) SetSpeedLimits(ctx context.Context, limits *SpeedLimits) error {
+	return d.call(ctx, "core.set_config", "", map[string]float64{
+		"max_download_speed": kibOrUnlimited(limits.Download),
+		"max_upload_speed":   kibOrUnlimited(limits.Upload),
+	})
+}
+
+// SpeedLimits returns the global speed limits in Deluge.
+func (d *Deluge) SpeedLimits(ctx context.Context) (*SpeedLimits, error) {
+	resp, err := d.Get(ctx, "core.get_config_values", []any{[]string{"max_download_speed", "max_upload_speed"}})
+	if err != nil {
+		return nil, fmt.Errorf("core.get_config_values: %w", err)
+	}
+
+	var config map[string]float64
+	if err := json.Unmarshal(resp.Result, &config); err != nil {
+		return nil, fmt.Errorf("decoding core.get_config_values response: %w", err)
+	}
+
+	return &SpeedLimits{
+		Download: int64(max(config["max_download_speed"], 0) * mnd.Kilobyte),
+		Upload:   int64(max(config["max_upload_speed"], 0) * mnd.Kilobyte),
+	}, nil
+}
+
+// kibOrUnlimited converts bytes to KiB, and 0 to -1 (unlimited).
+func kibOrUnlimited(bytes int64) float64 {
+	if bytes <= 0 {
+		return -1
+	}
+
+	return float64(bytes) / mnd.Kilobyte
+}
+
+// kib converts bytes to whole KiB, and rounds up. A limit under 1 KiB must not become 0 (unlimited or stopped).
+func kib(bytes int64) int64 {
+	if bytes <= 0 {
+		return 0
+	}
+
+	return (bytes + mnd.Kilobyte - 1) / mnd.Kilobyte
+}
+
+// qbitTorrent is the torrent info returned by the qBittorrent web API.
+//
+//nolint:tagliatelle
+type qbitTorrent struct {
+	Hash       string  `json:"hash"`
+	Name       string  `json:"name"`
+	State      string  `json:"state"`
+	Category   string  `json:"category"`
+	SavePath   string  `json:"save_path"`
+	Size       int64   `json:"size"`
+	Downloaded int64   `json:"downloaded"`
+	Uploaded   int64   `json:"uploaded"`
+	Progress   float64 `json:"progress"`
+	Ratio      float64 `json:"ratio"`
+	DLSpeed    int64   `json:"dlspeed"`
+	UPSpeed    int64   `json:"upspeed"`
+	AddedOn    int64   `json:"added_on"`
+	NumSeeds   int64   `json:"num_seeds"`
+	ETA        int64   `json:"eta"`
+	SeedTime   int64   `json:"seeding_time"`
+	Tracker    string  `json:"tracker"`
+}
+
+// qbitInfinity is the ETA qBittorrent returns for a stalled torrent (100 days).
+const qbitInfinity = 8640000
+
+// qbitLogin logs in with the qbit library. The client's cookie jar keeps the session for every call.
+func (q *Qbit) qbitLogin(ctx context.Context) error {
+	if _, err := qbit.New(ctx, &q.Config); err != nil {
+		return fmt.Errorf("%w: qbit login: %w", ErrDownloaderRequest, err)
+	}
+
+	return nil
+}
+
+// qbitRequest makes a qBittorrent web API request. The session cookie comes from the client's cookie jar.
+func (q *Qbit) qbitRequest(ctx context.Context, uri string, form url.Values) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		strings.TrimRight(q.URL, "/")+"/api/v2/"+uri, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Referer", q.URL)
+
+	if q.HTTPUser != "" {
+		req.SetBasicAuth(q.HTTPUser, q.HTTPPass)
+	}
+
+	resp, err := q.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("making request: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusForbidden {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %s", errQbitForbidden, uri)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		return nil, fmt.Errorf("%w: %s: %s: %s", ErrDownloaderRequest, uri, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return resp, nil
+}
+
+// qbitAPI makes a qBittorrent API call with the library's session.
+// Each uri is tried in order until one succeeds; this handles renamed methods between versions.
+func (q *Qbit) qbitAPI(ctx context.Context, form url.Values, into any, uris ...string) error {
+	var err error
+
+	for _, uri := range uris {
+		if err = q.qbitCall(ctx, uri, form, into); err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
+
+// qbitCall makes one API call, and logs in if there is no session yet, or if it expired.
+func (q *Qbit) qbitCall(ctx context.Context, uri string, form url.Values, into any) error {
+	resp, err := q.qbitRequest(ctx, uri, form)
+	if errors.Is(err, errQbitForbidden) && q.User != "" {
+		if err = q.qbitLogin(ctx); err != nil {
+			return err
+		}
+
+		resp, err = q.qbitRequest(ctx, uri, form)
+	}
+
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if into == nil {
+		return nil
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(into); err != nil {
+		return fmt.Errorf("decoding %s response: %w", uri, err)
+	}
+
+	return nil
+}
+
+// Transfers returns every transfer in qBittorrent.
+func (q *Qbit) Transfers(ctx context.Context) ([]*Transfer, error) {
+	var torrents []*qbitTorrent
+	if err := q.qbitAPI(ctx, url.Values{}, &torrents, "torrents/info"); err != nil {
+		return nil, err
+	}
+
+	output := make([]*Transfer, len(torrents))
+
+	for idx, xfer := range torrents {
+		output[idx] = &Transfer{
+			ID:         xfer.Hash,
+			Name:       xfer.Name,
+			State:      xfer.State,
+			Category:   xfer.Category,
+			Path:       xfer.SavePath,
+			Size:       xfer.Size,
+			Downloaded: xfer.Downloaded,
+			Uploaded:   xfer.Uploaded,
+			Progress:   xfer.Progress * 100, //nolint:mnd // percent
+			Ratio:      xfer.Ratio,
+			DownRate:   xfer.DLSpeed,
+			UpRate:     xfer.UPSpeed,
+			Paused:     strings.HasPrefix(xfer.State, "paused") || strings.HasPrefix(xfer.State, "stopped"),
+			Added:      time.Unix(xfer.AddedOn, 0),
+			Seeds:      xfer.NumSeeds,
+			ETA:        xfer.ETA,
+			SeedTime:   xfer.SeedTime,
+			Metadata:   strings.HasSuffix(xfer.State, "metaDL"),
+			Tracker:    xfer.Tracker,
+		}
+
+		if xfer.ETA >= qbitInfinity {
+			output[idx].ETA = -1
+		}
+	}
+
+	return output, nil
+}
+
+// PauseTransfers pauses transfers in qBittorrent. v5 renamed pause to stop.
+func (q *Qbit) PauseTransfers(ctx context.Context, ids []string) error {
+	return q.qbitAPI(ctx, url.Values{"hashes": {strings.Join(ids, "|")}}, nil, "torrents/stop", "torrents/pause")
+}
+
+// ResumeTransfers resumes transfers in qBittorrent. v5 renamed resume to start.
+func (q *Qbit) ResumeTransfers(ctx context.Context, ids []string) error {
+	return q.qbitAPI(ctx, url.Values{"hashes": {strings.Join(ids, "|")}}, nil, "torrents/start", "torrents/resume")
+}
+
+// RemoveTransfers removes transfers from qBittorrent.
+func (q *Qbit) RemoveTransfers(ctx context.Context, ids []string, deleteData bool) error {
+	return q.qbitAPI(ctx, url.Values{
+		"hashes":      {strings.Join(ids, "|")},
+		"deleteFiles": {fmt.Sprint(deleteData)},
+	}, nil, "torrents/delete")
+}
+
+// SetTransferCategory sets the category on transfers in qBittorrent. The category is created if missing.
+func (q *Qbit) SetTransferCategory(ctx context.Context, ids []string, category string) error {
+	if category != "" {
+		// This fails if the category exists; that's fine.
+		_ = q.qbitAPI(ctx, url.Values{"category": {category}}, nil, "torrents/createCategory")
+	}
+
+	return q.qbitAPI(ctx, url.Values{
+		"hashes":   {strings.Join(ids, "|")},
+		"category": {category},
+	}, nil, "torrents/setCategory")
+}
+
+// SetSpeedLimits sets the global speed limits in qBittorrent.
+func (q *Qbit) SetSpeedLimits(ctx context.Context, limits *SpeedLimits) error {
+	err := q.qbitAPI(ctx, url.Values{"limit": {fmt.Sprint(max(limits.Download, 0))}}, nil, "transfer/setDownloadLimit")
+	if err != nil {
+		return err
+	}
+
+	return q.qbitAPI(ctx, url.Values{"limit": {fmt.Sprint(max(limits.Upload, 0))}}, nil, "transfer/setUploadLimit")
+}
+
+// SpeedLimits returns the global speed limits in qBittorrent.
+func (q *Qbit) SpeedLimits(ctx context.Context) (*SpeedLimits, error) {
+	var limits SpeedLimits
+
+	if err := q.qbitAPI(ctx, url.Values{}, &limits.Download, "transfer/downloadLimit"); err != nil {
+		return nil, err
+	}
+
+	if err := q.qbitAPI(ctx, url.Values{}, &limits.Upload, "transfer/uploadLimit"); err != nil {
+		return nil, err
+	}
+
+	return &limits, nil
+}
+
+// rtorrentCall makes a single call for each transfer ID.
+func (r *Rtorrent) rtorrentCall(method string, ids []string, args ...any) error {
+	for _, id := range ids {
+		if _, err := r.Call(method, append([]any{id}, args...)...); err != nil {
+			return fmt.Errorf("%s XMLRPC call failed: %w", method, err)
+		}
+	}
+
+	return nil
+}
+
+// Transfers returns every transfer in rTorrent.
+func (r *Rtorrent) Transfers(_ context.Context) ([]*Transfer, error) {
+	results, err := r.Call("d.multicall2", "", "main", "d.hash=", "d.name=", "d.custom1=", "d.directory=",
+		"d.size_bytes=", "d.completed_bytes=", "d.up.total=", "d.ratio=", "d.down.rate=", "d.up.rate=",
+		"d.state=", "d.message=", "d.creation_date=", "d.peers_complete=", "d.timestamp.finished=")
+	if err != nil {
+		return nil, fmt.Errorf("d.multicall2 XMLRPC call failed: %w", err)
+	}
+
+	output := []*Transfer{}
+	str := func(v any) string { s, _ := v.(string); return s }
+	num := func(v any) int64 { i, _ := v.(int); return int64(i) }
+
+	outer, _ := results.([]any)
+	for _, result := range outer {
+		inner, _ := result.([]any)
+		for _, item := range inner {
+			data, ok := item.([]any)
+			if !ok || len(data) < 15 { //nolint:mnd // fields requested above.
+				continue
+			}
+
+			xfer := &Transfer{
+				ID:         str(data[0]),
+				Name:       str(data[1]),
+				Category:   str(data[2]),
+				Path:       str(data[3]),
+				Size:       num(data[4]),
+				Downloaded: num(data[5]),
+				Uploaded:   num(data[6]),
+				Ratio:      float64(num(data[7])) / 1000, //nolint:mnd // rtorrent ratio is * 1000.
+				DownRate:   num(data[8]),
+				UpRate:     num(data[9]),
+				Paused:     num(data[10]) == 0,
+				State:      str(data[11]),
+				Added:      time.Unix(num(data[12]), 0),
+				Seeds:      num(data[13]),
+			}
+
+			if finished := num(data[14]); finished > 0 {
+				xfer.SeedTime = max(time.Now().Unix()-finished, 0)
+			}
+
+			switch {
+			case xfer.Downloaded >= xfer.Size:
+			case xfer.DownRate > 0:
+				xfer.ETA = (xfer.Size - xfer.Downloaded) / xfer.DownRate
+			case !xfer.Paused:
+				xfer.ETA = -1
+			}
+
+			if xfer.State == "" {
+				xfer.State = map[bool]string{true: "stopped", false: "started"}[xfer.Paused]
+			}
+
+			if xfer.Size > 0 {
+				xfer.Progress = float64(xfer.Downloaded) / float64(xfer.Size) * 100 //nolint:mnd // percent
+			}
+
+			output = append(output, xfer)
+		}
+	}
+
+	return output, r.rtorrentTrackers(output)
+}
+
+// rtorrentTrackers sets the first tracker URL on each transfer. rTorrent lists trackers per torrent,
+// so this requests them all in one system.multicall.
+func (r *Rtorrent) rtorrentTrackers(xfers []*Transfer) error {
+	if len(xfers) == 0 {
+		return nil
+	}
+
+	calls := make([]any, len(xfers))
+	for idx, xfer := range xfers {
+		calls[idx] = map[string]any{"methodName": "t.multicall", "params": []any{xfer.ID, "", "t.url="}}
+	}
+
+	results, err := r.Call("system.multicall", calls)
+	if err != nil {
+		return fmt.Errorf("system.multicall XMLRPC call failed: %w", err)
+	}
+
+	// The response is one param with a result for each call. Each result is wrapped
+	// in an array, like [[url], [url]]; failed calls are fault structs.
+	for idx, result := range toSlice(toSlice(results)[0]) {
+		if idx >= len(xfers) {
+			break
+		}
+
+		wrapped, _ := result.([]any)
+		if len(wrapped) == 0 {
+			continue
+		}
+
+		for _, tracker := range toSlice(wrapped[0]) {
+			if url, _ := toSlice(tracker)[0].(string); url != "" && !strings.HasPrefix(url, "dht://") {
+				xfers[idx].Tracker = url
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// toSlice returns v as a slice, or a slice with one nil item if it is not a slice.
+func toSlice(v any) []any {
+	if list, ok := v.([]any); ok && len(list) > 0 {
+		return list
+	}
+
+	return []any{nil}
+}
+
+// PauseTransfers stops transfers in rTorrent.
+func (r *Rtorrent) PauseTransfers(_ context.Context, ids []string) error {
+	return r.rtorrentCall("d.stop", ids)
+}
+
+// ResumeTransfers starts transfers in rTorrent.
+func (r *Rtorrent) ResumeTransfers(_ context.Context, ids []string) error {
+	return r.rtorrentCall("d.start", ids)
+}
+
+// RemoveTransfers removes transfers from rTorrent. rTorrent cannot delete data remotely.
+func (r *Rtorrent) RemoveTransfers(_ context.Context, ids []string, deleteData bool) error {
+	if deleteData {
+		return fmt.Errorf("%w: rtorrent cannot delete data", ErrUnsupported)
+	}
+
+	return r.rtorrentCall("d.erase", ids)
+}
+
+// SetTransferCategory sets the label (custom1) on transfers in rTorrent.
+func (r *Rtorrent) SetTransferCategory(_ context.Context, ids []string, category string) error {
+	return r.rtorrentCall("d.custom1.set", ids, category)
+}
+
+// SetSpeedLimits sets the global speed limits in rTorrent.
+func (r *Rtorrent) SetSpeedLimits(_ context.Context, limits *SpeedLimits) error {
+	if _, err := r.Call("throttle.global_down.max_rate.set", "", max(limits.Download, 0)); err != nil {
+		return fmt.Errorf("throttle.global_down.max_rate.set XMLRPC call failed: %w", err)
+	}
+
+	if _, err := r.Call("throttle.global_up.max_rate.set", "", max(limits.Upload, 0)); err != nil {
+		return fmt.Errorf("throttle.global_up.max_rate.set XMLRPC call failed: %w", err)
+	}
+
+	return nil
+}
+
+// SpeedLimits returns the global speed limits in rTorrent.
+func (r *Rtorrent) SpeedLimits(_ context.Context) (*SpeedLimits, error) {
+	down, err := r.Call("throttle.global_down.max_rate", "")
+	if err != nil {
+		return nil, fmt.Errorf("throttle.global_down.max_rate XMLRPC call failed: %w", err)
+	}
+
+	up, err := r.Call("throttle.global_up.max_rate", "")
+	if err != nil {
+		return nil, fmt.Errorf("throttle.global_up.max_rate XMLRPC call failed: %w", err)
+	}
+
+	downRate, _ := down.(int)
+	upRate, _ := up.(int)
+
+	return &SpeedLimits{Download: int64(downRate), Upload: int64(upRate)}, nil
+}
+
+// Transfers returns every transfer in Transmission.
+func (x *Xmission) Transfers(ctx context.Context) ([]*Transfer, error) {
+	xfers, err := x.TorrentGetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting transfers: %w", err)
+	}
+
+	output := make([]*Transfer, len(xfers))
+
+	for idx, xfer := range xfers {
+		output[idx] = &Transfer{
+			ID:         deref(xfer.HashString),
+			Name:       deref(xfer.Name),
+			Path:       deref(xfer.DownloadDir),
+			Downloaded: deref(xfer.DownloadedEver),
+			Uploaded:   deref(xfer.UploadedEver),
+			Progress:   deref(xfer.PercentDone) * 100, //nolint:mnd // percent
+			Ratio:      deref(xfer.UploadRatio),
+			DownRate:   deref(xfer.RateDownload),
+			UpRate:     deref(xfer.RateUpload),
+			Added:      deref(xfer.AddedDate),
+			Seeds:      deref(xfer.PeersSendingToUs),
+			ETA:        deref(xfer.ETA),
+			SeedTime:   int64(deref(xfer.TimeSeeding).Seconds()),
+			Metadata:   xfer.MetadataPercentComplete != nil && *xfer.MetadataPercentComplete < 1,
+		}
+
+		if output[idx].ETA < 0 { // -1 is not available, -2 is unknown.
+			output[idx].ETA = -1
+			if output[idx].Progress >= 100 { //nolint:mnd // percent
+				output[idx].ETA = 0
+			}
+		}
+
+		if len(xfer.Labels) > 0 {
+			output[idx].Category = xfer.Labels[0]
+		}
+
+		if len(xfer.Trackers) > 0 {
+			output[idx].Tracker = xfer.Trackers[0].Announce
+		}
+
+		if xfer.TotalSize != nil {
+			output[idx].Size = int64(xfer.TotalSize.Byte())
+		}
+
+		if xfer.Status != nil {
+			output[idx].State = xfer.Status.String()
+			output[idx].Paused = *xfer.Status == transmissionrpc.TorrentStatusStopped
+		}
+	}
+
+	return output, nil
+}
+
+// transmissionIDs converts hashes into Transmission torrent IDs.
+func (x *Xmission) transmissionIDs(ctx context.Context, hashes []string) ([]int64, error) {
+	xfers, err := x.TorrentGetHashes(ctx, []string{"id"}, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("getting transfer IDs: %w", err)
+	}
+
+	ids := []int64{}
+
+	for _, xfer := range xfers {
+		if xfer.ID != nil {
+			ids = append(ids, *xfer.ID)
+		}
+	}
+
+	return ids, nil
+}
+
+// PauseTransfers stops transfers in Transmission.
+func (x *Xmission) PauseTransfers(ctx context.Context, ids []string) error {
+	if err := x.TorrentStopHashes(ctx, ids); err != nil {
+		return fmt.Errorf("stopping transfers: %w", err)
+	}
+
+	return nil
+}
+
+// ResumeTransfers starts transfers in Transmission.
+func (x *Xmission) ResumeTransfers(ctx context.Context, ids []string) error {
+	if err := x.TorrentStartHashes(ctx, ids); err != nil {
+		return fmt.Errorf("starting transfers: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveTransfers removes transfers from Transmission.
+func (x *Xmission) RemoveTransfers(ctx context.Context, ids []string, deleteData bool) error {
+	torrentIDs, err := x.transmissionIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	err = x.TorrentRemove(ctx, transmissionrpc.TorrentRemovePayload{IDs: torrentIDs, DeleteLocalData: deleteData})
+	if err != nil {
+		return fmt.Errorf("removing transfers: %w", err)
+	}
+
+	return nil
+}
+
+// SetTransferCategory replaces the labels on transfers in Transmission with the category.
+func (x *Xmission) SetTransferCategory(ctx context.Context, ids []string, category string) error {
+	torrentIDs, err := x.transmissionIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	labels := []string{}
+	if category != "" {
+		labels = append(labels, category)
+	}
+
+	if err = x.TorrentSet(ctx, transmissionrpc.TorrentSetPayload{IDs: torrentIDs, Labels: labels}); err != nil {
+		return fmt.Errorf("setting labels: %w", err)
+	}
+
+	return nil
+}
+
+// SetSpeedLimits sets the global speed limits in Transmission.
+func (x *Xmission) SetSpeedLimits(ctx context.Context, limits *SpeedLimits) error {
+	down, up := kib(limits.Download), kib(limits.Upload)
+	downOn, upOn := limits.Download > 0, limits.Upload > 0
+
+	err := x.SessionArgumentsSet(ctx, transmissionrpc.SessionArguments{
+		SpeedLimitDown:        &down,
+		SpeedLimitDownEnabled: &downOn,
+		SpeedLimitUp:          &up,
+		SpeedLimitUpEnabled:   &upOn,
+	})
+	if err != nil {
+		return fmt.Errorf("setting session arguments: %w", err)
+	}
+
+	return nil
+}
+
+// SpeedLimits returns the global speed limits in Transmission. Disabled limits are returned as 0.
+func (x *Xmission) SpeedLimits(ctx context.Context) (*SpeedLimits, error) {
+	args, err := x.SessionArgumentsGet(ctx, []string{
+		"speed-limit-down", "speed-limit-down-enabled", "speed-limit-up", "speed-limit-up-enabled",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting session arguments: %w", err)
+	}
+
+	limits := &SpeedLimits{}
+
+	if deref(args.SpeedLimitDownEnabled) {
+		limits.Download = deref(args.SpeedLimitDown) * mnd.Kilobyte
+	}
+
+	if deref(args.SpeedLimitUpEnabled) {
+		limits.Upload = deref(args.SpeedLimitUp) * mnd.Kilobyte
+	}
+
+	return limits, nil
+}
+
+// deref returns the value of a pointer, or the zero value if the pointer is nil.
+func deref[T any](ptr *T) T {
+	var zero T
+	if ptr == nil {
+		return zero
+	}
+
+	return *ptr
+}
@@ -0,0 +1,191 @@
+package apps
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"golift.io/nzbget"
+)
+
+/* Downloader interface implementations for the usenet clients. */
+
+// Transfers returns every queued item in NZBGet.
+func (n *NZBGet) Transfers(ctx context.Context) ([]*Transfer, error) {
+	groups, err := n.ListGroupsContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting queue: %w", err)
+	}
+
+	output := make([]*Transfer, len(groups))
+
+	for idx, group := range groups {
+		output[idx] = &Transfer{
+			ID:         strconv.FormatInt(group.NZBID, mnd.Base10),
+			Name:       group.NZBName,
+			State:      string(group.Status),
+			Category:   group.Category,
+			Path:       group.DestDir,
+			Size:       group.FileSizeMB * mnd.Megabyte,
+			Downloaded: group.DownloadedSizeMB * mnd.Megabyte,
+			Paused:     group.Status == nzbget.GroupPAUSED,
+		}
+
+		if group.FileSizeMB > 0 {
+			output[idx].Progress = float64(group.FileSizeMB-group.RemainingSizeMB) /
+				float64(group.FileSizeMB) * 100 //nolint:mnd // percent
+		}
+	}
+
+	return output, nil
+}
+
+// editQueue runs an NZBGet queue command against transfer IDs.
+func (n *NZBGet) editQueue(ctx context.Context, command, param string, ids []string) error {
+	nzbIDs := make([]int64, 0, len(ids))
+
+	for _, id := range ids {
+		nzbID, err := strconv.ParseInt(id, mnd.Base10, mnd.Bits64)
+		if err != nil {
+			return fmt.Errorf("%w: invalid nzb id: %s", ErrNoTransferIDs, id)
+		}
+
+		nzbIDs = append(nzbIDs, nzbID)
+	}
+
+	if ok, err := n.EditQueueContext(ctx, command, param, nzbIDs); err != nil {
+		return fmt.Errorf("%s: %w", command, err)
+	} else if !ok {
+		return fmt.Errorf("%w: %s returned false", ErrDownloaderRequest, command)
+	}
+
+	return nil
+}
+
+// PauseTransfers pauses queued items in NZBGet.
+func (n *NZBGet) PauseTransfers(ctx context.Context, ids []string) error {
+	return n.editQueue(ctx, "GroupPause", "", ids)
+}
+
+// ResumeTransfers resumes queued items in NZBGet.
+func (n *NZBGet) ResumeTransfers(ctx context.Context, ids []string) error {
+	return n.editQueue(ctx, "GroupResume", "", ids)
+}
+
+// RemoveTransfers removes queued items from NZBGet. Without deleteData, downloaded files are kept.
+func (n *NZBGet) RemoveTransfers(ctx context.Context, ids []string, deleteData bool) error {
+	if deleteData {
+		return n.editQueue(ctx, "GroupDelete", "", ids)
+	}
+
+	return n.editQueue(ctx, "GroupParkDelete", "", ids)
+}
+
+// SetTransferCategory sets the category on queued items in NZBGet.
+func (n *NZBGet) SetTransferCategory(ctx context.Context, ids []string, category string) error {
+	return n.editQueue(ctx, "GroupSetCategory", category, ids)
+}
+
+// SpeedLimits returns the download speed limit in NZBGet.
+func (n *NZBGet) SpeedLimits(ctx context.Context) (*SpeedLimits, error) {
+	status, err := n.StatusContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting status: %w", err)
+	}
+
+	return &SpeedLimits{Download: status.DownloadLimit}, nil
+}
+
+// SetSpeedLimits sets the download speed limit in NZBGet. NZBGet has no upload limit.
+func (n *NZBGet) SetSpeedLimits(ctx context.Context, limits *SpeedLimits) error {
+	if ok, err := n.RateContext(ctx, kib(limits.Download)); err != nil {
+		return fmt.Errorf("setting rate: %w", err)
+	} else if !ok {
+		return fmt.Errorf("%w: rate returned false", ErrDownloaderRequest)
+	}
+
+	return nil
+}
+
+// Transfers returns every queued item in SABnzbd.
+func (s *SabNZB) Transfers(ctx context.Context) ([]*Transfer, error) {
+	queue, err := s.GetQueue(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting queue: %w", err)
+	}
+
+	output := make([]*Transfer, len(queue.Slots))
+
+	for idx, slot := range queue.Slots {
+		output[idx] = &Transfer{
+			ID:         slot.NzoID,
+			Name:       slot.Filename,
+			State:      slot.Status,
+			Category:   slot.Cat,
+			Size:       slot.Size.Bytes,
+			Downloaded: slot.Size.Bytes - slot.Sizeleft.Bytes,
+			Progress:   float64(slot.Percentage),
+			Paused:     strings.EqualFold(slot.Status, "paused"),
+		}
+	}
+
+	return output, nil
+}
+
+// PauseTransfers pauses queued items in SABnzbd.
+func (s *SabNZB) PauseTransfers(ctx context.Context, ids []string) error {
+	return s.Command(ctx, "queue", url.Values{"name": {"pause"}, "value": {strings.Join(ids, ",")}})
+}
+
+// ResumeTransfers resumes queued items in SABnzbd.
+func (s *SabNZB) ResumeTransfers(ctx context.Context, ids []string) error {
+	return s.Command(ctx, "queue", url.Values{"name": {"resume"}, "value": {strings.Join(ids, ",")}})
+}
+
+// RemoveTransfers removes queued items from SABnzbd.
+func (s *SabNZB) RemoveTransfers(ctx context.Context, ids []string, deleteData bool) error {
+	params := url.Values{"name": {"delete"}, "value": {strings.Join(ids, ",")}}
+	if deleteData {
+		params.Set("del_files", "1")
+	}
+
+	return s.Command(ctx, "queue", params)
+}
+
+// SetTransferCategory sets the category on queued items in SABnzbd.
+func (s *SabNZB) SetTransferCategory(ctx context.Context, ids []string, category string) error {
+	if category == "" {
+		category = "*" // SABnzbd's default category.
+	}
+
+	return s.Command(ctx, "change_cat", url.Values{"value": {strings.Join(ids, ",")}, "value2": {category}})
+}
+
+// SpeedLimits returns the download speed limit in SABnzbd.
+func (s *SabNZB) SpeedLimits(ctx context.Context) (*SpeedLimits, error) {
+	queue, err := s.GetQueue(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting queue: %w", err)
+	}
+
+	if queue.Speedlimit >= 100 { //nolint:mnd // 100 percent is unlimited.
+		return &SpeedLimits{}, nil
+	}
+
+	limit, _ := strconv.ParseFloat(queue.SpeedlimitAbs, mnd.Bits64)
+
+	return &SpeedLimits{Download: int64(limit)}, nil
+}
+
+// SetSpeedLimits sets the download speed limit in SABnzbd. SABnzbd has no upload limit.
+func (s *SabNZB) SetSpeedLimits(ctx context.Context, limits *SpeedLimits) error {
+	limit := "100" // 100 percent is unlimited.
+	if limits.Download > 0 {
+		limit = strconv.FormatInt(kib(limits.Download), mnd.Base10) + "K"
+	}
+
+	return s.Command(ctx, "config", url.Values{"name": {"speedlimit"}, "value": {limit}})
+}
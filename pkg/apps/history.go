@@ -0,0 +1,227 @@
+package apps
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/gorilla/mux"
+	"golift.io/starr"
+	"golift.io/starr/lidarr"
+	"golift.io/starr/radarr"
+	"golift.io/starr/readarr"
+	"golift.io/starr/sonarr"
+)
+
+/* History and block list routes are identical for Lidarr, Radarr, Readarr and Sonarr. */
+
+// starrHistorian is satisfied by Lidarr, Radarr, Readarr and Sonarr.
+// H is the app's History type, B is its BlockList type, and R is its HistoryRecord type.
+type starrHistorian[H, B, R any] interface {
+	GetInto(ctx context.Context, req starr.Request, output any) error
+	GetHistoryPageContext(ctx context.Context, params *starr.PageReq) (H, error)
+	FailContext(ctx context.Context, historyID int64) error
+	GetBlockListPageContext(ctx context.Context, params *starr.PageReq) (B, error)
+	DeleteBlockListsContext(ctx context.Context, ids []int64) error
+}
+
+// historyHandlers is called once on startup to register the history and block list paths.
+func (a *Apps) historyHandlers() {
+	registerHistory(a, starr.Lidarr, lidarr.APIver,
+		func(r *http.Request) starrHistorian[*lidarr.History, *lidarr.BlockList, *lidarr.HistoryRecord] {
+			return getLidarr(r)
+		})
+	registerHistory(a, starr.Radarr, radarr.APIver,
+		func(r *http.Request) starrHistorian[*radarr.History, *radarr.BlockList, *radarr.HistoryRecord] {
+			return getRadarr(r)
+		})
+	registerHistory(a, starr.Readarr, readarr.APIver,
+		func(r *http.Request) starrHistorian[*readarr.History, *readarr.BlockList, readarr.HistoryRecord] {
+			return getReadarr(r)
+		})
+	registerHistory(a, starr.Sonarr, sonarr.APIver,
+		func(r *http.Request) starrHistorian[*sonarr.History, *sonarr.BlockList, *sonarr.HistoryRecord] {
+			return getSonarr(r)
+		})
+}
+
+func registerHistory[H, B, R any](
+	a *Apps,
+	app starr.App,
+	apiVer string,
+	get func(*http.Request) starrHistorian[H, B, R],
+) {
+	a.HandleAPIpath(app, "/history", starrGetHistory(apiVer, get), "GET")
+	a.HandleAPIpath(app, "/history/failed/{historyid:[0-9]+}", starrFailHistory(get), "POST")
+	a.HandleAPIpath(app, "/blocklist", starrGetBlockList(get), "GET")
+	a.HandleAPIpath(app, "/blocklist/{ids:(?:[0-9],?)+}", starrDeleteBlockList(get), "DELETE")
+}
+
+// historyPageReq turns request query parameters into a starr page request.
+// Unknown parameters, like movieId or seriesId, are passed to the app as-is.
+func historyPageReq(req *http.Request) *starr.PageReq {
+	query := req.URL.Query()
+	params := &starr.PageReq{Values: make(url.Values)}
+
+	for key, val := range query {
+		switch value := val[0]; key {
+		case "page":
+			params.Page, _ = strconv.Atoi(value)
+		case "pageSize":
+			params.PageSize, _ = strconv.Atoi(value)
+		case "sortKey":
+			params.SortKey = value
+		case "sortDirection":
+			params.SortDir = starr.Sorting(value)
+		case "eventType":
+			filter, _ := strconv.Atoi(value)
+			params.Filter = starr.Filtering(filter)
+		case "since": // handled by the caller.
+		default:
+			params.Values[key] = val
+		}
+	}
+
+	return params
+}
+
+// @Description	Returns history from a Starr app. Provide eventType to filter by event, and any item ID
+// @Description	parameter the app supports (movieId, seriesId, episodeId, artistId, authorId, etc).
+// @Description	When since is provided, all records since that date are returned and paging is ignored.
+// @Summary		Get Starr History
+// @Tags			Starr
+// @Produce		json
+// @Param			app				path		string								true	"app name"	Enums(lidarr, radarr, readarr, sonarr)
+// @Param			instance		path		int64								true	"instance ID"
+// @Param			page			query		int64								false	"page number"
+// @Param			pageSize		query		int64								false	"records per page"
+// @Param			eventType		query		int64								false	"app specific event type"
+// @Param			since			query		string								false	"RFC3339 date or YYYY-MM-DD"
+// @Success		200				{object}	apps.APIResponse{message=any}		"history page, or list of records with since"
+// @Failure		400				{object}	apps.APIResponse{message=string}	"invalid since date"
+// @Failure		503				{object}	apps.APIResponse{message=string}	"instance error"
+// @Failure		404				{object}	string								"bad token or api key"
+// @Router			/{app}/{instance}/history [get]
+// @Security		ApiKeyAuth
+func starrGetHistory[H, B, R any](apiVer string, get func(*http.Request) starrHistorian[H, B, R]) APIHandler {
+	return func(req *http.Request) (int, any) {
+		params := historyPageReq(req)
+
+		since := req.URL.Query().Get("since")
+		if since == "" {
+			history, err := get(req).GetHistoryPageContext(req.Context(), params)
+			if err != nil {
+				return apiError(http.StatusServiceUnavailable, "getting history", err)
+			}
+
+			return http.StatusOK, history
+		}
+
+		date, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			if date, err = time.Parse(time.DateOnly, since); err != nil {
+				return apiError(http.StatusBadRequest, "parsing since date", err)
+			}
+		}
+
+		query := url.Values{"date": []string{date.UTC().Format(time.RFC3339)}}
+		if params.Filter > 0 {
+			query.Set("eventType", params.Filter.Param())
+		}
+
+		records := []R{}
+
+		err = get(req).GetInto(req.Context(),
+			starr.Request{URI: path.Join(apiVer, "history", "since"), Query: query}, &records)
+		if err != nil {
+			return apiError(http.StatusServiceUnavailable, "getting history", err)
+		}
+
+		return http.StatusOK, records
+	}
+}
+
+// @Description	Marks a history item (a grabbed download) as failed. The app blocklists the release
+// @Description	and searches for a replacement if its Redownload Failed setting is enabled.
+// @Summary		Mark Starr Download Failed
+// @Tags			Starr
+// @Produce		json
+// @Param			app				path		string								true	"app name"	Enums(lidarr, radarr, readarr, sonarr)
+// @Param			instance		path		int64								true	"instance ID"
+// @Param			historyID		path		int64								true	"history record ID"
+// @Success		200				{object}	apps.APIResponse{message=string}	"ok"
+// @Failure		503				{object}	apps.APIResponse{message=string}	"instance error"
+// @Failure		404				{object}	string								"bad token or api key"
+// @Router			/{app}/{instance}/history/failed/{historyID} [post]
+// @Security		ApiKeyAuth
+func starrFailHistory[H, B, R any](get func(*http.Request) starrHistorian[H, B, R]) APIHandler {
+	return func(req *http.Request) (int, any) {
+		historyID, _ := strconv.ParseInt(mux.Vars(req)["historyid"], mnd.Base10, mnd.Bits64)
+
+		if err := get(req).FailContext(req.Context(), historyID); err != nil {
+			return apiError(http.StatusServiceUnavailable, "marking history failed", err)
+		}
+
+		return http.StatusOK, "ok"
+	}
+}
+
+// @Description	Returns a page of block list records from a Starr app.
+// @Summary		Get Starr Block List
+// @Tags			Starr
+// @Produce		json
+// @Param			app				path		string								true	"app name"	Enums(lidarr, radarr, readarr, sonarr)
+// @Param			instance		path		int64								true	"instance ID"
+// @Param			page			query		int64								false	"page number"
+// @Param			pageSize		query		int64								false	"records per page"
+// @Success		200				{object}	apps.APIResponse{message=any}		"block list page"
+// @Failure		503				{object}	apps.APIResponse{message=string}	"instance error"
+// @Failure		404				{object}	string								"bad token or api key"
+// @Router			/{app}/{instance}/blocklist [get]
+// @Security		ApiKeyAuth
+func starrGetBlockList[H, B, R any](get func(*http.Request) starrHistorian[H, B, R]) APIHandler {
+	return func(req *http.Request) (int, any) {
+		list, err := get(req).GetBlockListPageContext(req.Context(), historyPageReq(req))
+		if err != nil {
+			return apiError(http.StatusServiceUnavailable, "getting block list", err)
+		}
+
+		return http.StatusOK, list
+	}
+}
+
+// @Description	Removes one or more records from a Starr app's block list.
+// @Summary		Delete Starr Block List Records
+// @Tags			Starr
+// @Produce		json
+// @Param			app				path		string								true	"app name"	Enums(lidarr, radarr, readarr, sonarr)
+// @Param			instance		path		int64								true	"instance ID"
+// @Param			ids				path		string								true	"comma separated list of block list IDs"
+// @Success		200				{object}	apps.APIResponse{message=string}	"deleted ids"
+// @Failure		503				{object}	apps.APIResponse{message=string}	"instance error"
+// @Failure		404				{object}	string								"bad token or api key"
+// @Router			/{app}/{instance}/blocklist/{ids} [delete]
+// @Security		ApiKeyAuth
+func starrDeleteBlockList[H, B, R any](get func(*http.Request) starrHistorian[H, B, R]) APIHandler {
+	return func(req *http.Request) (int, any) {
+		ids := mux.Vars(req)["ids"]
+		listIDs := []int64{}
+
+		for s := range strings.SplitSeq(ids, ",") {
+			if i, err := strconv.ParseInt(s, mnd.Base10, mnd.Bits64); err == nil {
+				listIDs = append(listIDs, i)
+			}
+		}
+
+		if err := get(req).DeleteBlockListsContext(req.Context(), listIDs); err != nil {
+			return apiError(http.StatusServiceUnavailable, "deleting block list records", err)
+		}
+
+		return http.StatusOK, mnd.Deleted + strings.Join(strings.Split(ids, ","), ", ")
+	}
+}
@@ -0,0 +1,47 @@
+package apps //nolint:testpackage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golift.io/starr"
+)
+
+func TestHistoryPageReq(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		query string
+		want  *starr.PageReq
+	}{
+		{name: "empty", query: "", want: &starr.PageReq{Values: url.Values{}}},
+		{
+			name:  "paging",
+			query: "page=2&pageSize=50&sortKey=date&sortDirection=descending",
+			want: &starr.PageReq{
+				Page: 2, PageSize: 50, SortKey: "date", SortDir: starr.SortDescend, Values: url.Values{},
+			},
+		},
+		{name: "event type", query: "eventType=4", want: &starr.PageReq{Filter: starr.Filtering(4), Values: url.Values{}}},
+		{name: "since is skipped", query: "since=2024-01-01", want: &starr.PageReq{Values: url.Values{}}},
+		{
+			name:  "unknown parameters pass through",
+			query: "movieId=7&page=1",
+			want:  &starr.PageReq{Page: 1, Values: url.Values{"movieId": []string{"7"}}},
+		},
+		{name: "bad numbers", query: "page=two&eventType=x", want: &starr.PageReq{Values: url.Values{}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/radarr/1/history?"+test.query, nil)
+			assert.Equal(t, test.want, historyPageReq(req))
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package apps
+
+import (
+	"context"
+	"path"
+
+	"golift.io/starr"
+	"golift.io/starr/lidarr"
+	"golift.io/starr/prowlarr"
+	"golift.io/starr/radarr"
+	"golift.io/starr/readarr"
+	"golift.io/starr/sonarr"
+)
+
+// StarrClient contains the API methods every starr app library provides.
+type StarrClient interface {
+	starr.APIer
+	GetBackupFiles() ([]*starr.BackupFile, error)
+	GetBackupFilesContext(ctx context.Context) ([]*starr.BackupFile, error)
+}
+
+// StarrInstance is a single configured starr app. Lidarr, Prowlarr, Radarr, Readarr
+// and Sonarr all satisfy this interface, so triggers can iterate every instance of
+// every app with one loop. A new starr app plugs into the triggers by implementing
+// this interface and being returned from StarrInstances().
+type StarrInstance interface {
+	// App returns the type of starr app, ie. starr.Radarr.
+	App() starr.App
+	// Index returns the zero-based position of this instance in the app's config list.
+	Index() int
+	// Instance returns the instance ID the website uses; it's Index() + 1.
+	Instance() int
+	// Starr returns the instance configuration; name, url, deletes, etc.
+	Starr() StarrApp
+	// Enabled returns true if the instance is configured well enough to use.
+	Enabled() bool
+	// StarrClient returns the API interface for this instance.
+	StarrClient() StarrClient
+}
+
+// StarrApps returns the list of starr apps that implement StarrInstance.
+// This is the order triggers process them in.
+func StarrApps() []starr.App {
+	return []starr.App{starr.Lidarr, starr.Prowlarr, starr.Radarr, starr.Readarr, starr.Sonarr}
+}
+
+// App returns the type of starr app this is. Promoted onto Lidarr/Radarr/etc.
+func (e StarrApp) App() starr.App { return e.app }
+
+// Index returns the zero-based config index for this instance. Promoted onto Lidarr/Radarr/etc.
+func (e StarrApp) Index() int { return e.index }
+
+// Instance returns the instance ID for this app. This is what the website uses. Promoted onto Lidarr/Radarr/etc.
+func (e StarrApp) Instance() int { return e.index + 1 }
+
+// APIPath joins the app's API version, like v3, with the path elements. Use it with StarrClient requests.
+func (e StarrApp) APIPath(elem ...string) string {
+	var version string
+
+	switch e.app { //nolint:exhaustive // Only starr apps.
+	case starr.Lidarr:
+		version = lidarr.APIver
+	case starr.Prowlarr:
+		version = prowlarr.APIver
+	case starr.Radarr:
+		version = radarr.APIver
+	case starr.Readarr:
+		version = readarr.APIver
+	default:
+		version = sonarr.APIver
+	}
+
+	return path.Join(append([]string{version}, elem...)...)
+}
+
+// StarrClient returns the Lidarr API interface.
+func (l Lidarr) StarrClient() StarrClient { return l.Lidarr }
+
+// StarrClient returns the Prowlarr API interface.
+func (p Prowlarr) StarrClient() StarrClient { return p.Prowlarr }
+
+// StarrClient returns the Radarr API interface.
+func (r Radarr) StarrClient() StarrClient { return r.Radarr }
+
+// StarrClient returns the Readarr API interface.
+func (r Readarr) StarrClient() StarrClient { return r.Readarr }
+
+// StarrClient returns the Sonarr API interface.
+func (s Sonarr) StarrClient() StarrClient { return s.Sonarr }
+
+// StarrInstances returns every configured instance of the requested starr apps.
+// All starr app instances are returned if no apps are provided.
+func (a *Apps) StarrInstances(app ...starr.App) []StarrInstance {
+	if len(app) == 0 {
+		app = StarrApps()
+	}
+
+	output := []StarrInstance{}
+
+	for _, name := range app {
+		switch name { //nolint:exhaustive // We only return starr apps.
+		case starr.Lidarr:
+			output = appendInstances(output, a.Lidarr)
+		case starr.Prowlarr:
+			output = appendInstances(output, a.Prowlarr)
+		case starr.Radarr:
+			output = appendInstances(output, a.Radarr)
+		case starr.Readarr:
+			output = appendInstances(output, a.Readarr)
+		case starr.Sonarr:
+			output = appendInstances(output, a.Sonarr)
+		}
+	}
+
+	return output
+}
+
+func appendInstances[T StarrInstance](output []StarrInstance, list []T) []StarrInstance {
+	for _, app := range list {
+		output = append(output, app)
+	}
+
+	return output
+}
@@ -3,6 +3,7 @@ package apps
 import (
 	"fmt"
 	"net/http"
+	"net/http/cookiejar"
 	"net/url"
 	"strings"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/Notifiarr/notifiarr/pkg/mnd"
 	"github.com/hekmon/transmissionrpc/v3"
 	"github.com/mrobinsn/go-rtorrent/xmlrpc"
+	"golift.io/cnfg"
 	"golift.io/deluge"
 	"golift.io/nzbget"
 	"golift.io/qbit"
@@ -24,6 +26,32 @@ import (
 type PlexConfig struct {
 	plex.Config
 	ExtraConfig
+	Throttle *PlexThrottle `json:"throttle,omitempty" toml:"throttle" xml:"throttle"`
+}
+
+// PlexThrottle applies alternate download client speed limits while Plex is busy.
+// Throttling starts when either threshold is reached, and the limits each client had before
+// are restored after the sessions stay below both restore thresholds for the Restore duration.
+// The restore thresholds default to the throttle thresholds, and may be lower so a stream
+// count or bandwidth near a threshold does not toggle the limits.
+// Speed limits are in KiB/s; zero means unlimited. Non-zero normal limits replace the restored limits.
+type PlexThrottle struct {
+	Sessions         int           `json:"sessions"         toml:"sessions"          xml:"sessions"`
+	Bandwidth        int64         `json:"bandwidth"        toml:"bandwidth"         xml:"bandwidth"` // kbps
+	RestoreSessions  int           `json:"restoreSessions"  toml:"restore_sessions"  xml:"restore_sessions"`
+	RestoreBandwidth int64         `json:"restoreBandwidth" toml:"restore_bandwidth" xml:"restore_bandwidth"` // kbps
+	Download         int64         `json:"download"         toml:"download"          xml:"download"`
+	Upload           int64         `json:"upload"           toml:"upload"            xml:"upload"`
+	NormalDownload   int64         `json:"normalDownload"   toml:"normal_download"   xml:"normal_download"`
+	NormalUpload     int64         `json:"normalUpload"     toml:"normal_upload"     xml:"normal_upload"`
+	Restore          cnfg.Duration `json:"restore"          toml:"restore"           xml:"restore"`
+	// Clients limits throttling to these download clients, like qbit or sabnzbd. Empty means all.
+	Clients []string `json:"clients" toml:"clients" xml:"clients"`
+}
+
+// Enabled returns true if a throttle threshold is configured.
+func (t *PlexThrottle) Enabled() bool {
+	return t != nil && (t.Sessions > 0 || t.Bandwidth > 0)
 }
 
 type Plex struct {
@@ -270,6 +298,9 @@ func (q *QbitConfig) Setup(maxBody int) (*qbit.Qbit, error) {
 		q.Client.Transport = NewMetricsRoundTripper("qBittorrent", q.Client.Transport)
 	}
 
+	// The qbit library's login and the transfer control calls share the session cookie in this jar.
+	q.Client.Jar, _ = cookiejar.New(nil)
+
 	qbit, err := qbit.NewNoAuth(&q.Config)
 	if err != nil {
 		return nil, fmt.Errorf("qbit setup failed: %w", err)
@@ -90,6 +90,8 @@ func (a *AppsConfig) setupLidarr() ([]Lidarr, error) {
 		app.URL = strings.TrimRight(app.URL, "/")
 		output[idx] = Lidarr{
 			StarrConfig: a.Lidarr[idx],
+			app:         starr.Lidarr,
+			index:       idx,
 			Lidarr:      lidarr.New(&app.Config),
 		}
 
@@ -1,11 +1,19 @@
 package apps
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"path"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/gorilla/mux"
 	"golift.io/starr"
 	"golift.io/starr/debuglog"
 	"golift.io/starr/prowlarr"
@@ -16,6 +24,12 @@ func (a *Apps) prowlarrHandlers() {
 	a.HandleAPIpath(starr.Prowlarr, "/notification", prowlarrGetNotifications, "GET")
 	a.HandleAPIpath(starr.Prowlarr, "/notification", prowlarrUpdateNotification, "PUT")
 	a.HandleAPIpath(starr.Prowlarr, "/notification", prowlarrAddNotification, "POST")
+	a.HandleAPIpath(starr.Prowlarr, "/indexers", prowlarrGetIndexers, "GET")
+	a.HandleAPIpath(starr.Prowlarr, "/indexers/stats", prowlarrGetIndexerStats, "GET")
+	a.HandleAPIpath(starr.Prowlarr, "/indexer/{indexerid:[0-9]+}/enable", prowlarrEnableIndexer(true), "PUT")
+	a.HandleAPIpath(starr.Prowlarr, "/indexer/{indexerid:[0-9]+}/disable", prowlarrEnableIndexer(false), "PUT")
+	a.HandleAPIpath(starr.Prowlarr, "/indexer/{indexerid:[0-9]+}/test", prowlarrTestIndexer, "POST")
+	a.HandleAPIpath(starr.Prowlarr, "/search", prowlarrSearch, "POST")
 }
 
 func getProwlarr(r *http.Request) Prowlarr {
@@ -51,6 +65,8 @@ func (a *AppsConfig) setupProwlarr() ([]Prowlarr, error) {
 		app.URL = strings.TrimRight(app.URL, "/")
 		output[idx] = Prowlarr{
 			StarrConfig: a.Prowlarr[idx],
+			app:         starr.Prowlarr,
+			index:       idx,
 			Prowlarr:    prowlarr.New(&app.Config),
 		}
 	}
@@ -142,3 +158,242 @@ func prowlarrAddNotification(req *http.Request) (int, any) {
 
 	return http.StatusOK, id
 }
+
+// ProwlarrIndexerStatus is the failure status for an indexer. Prowlarr only returns these for failing indexers.
+// The starr library does not wrap this endpoint, so it lives here.
+type ProwlarrIndexerStatus struct {
+	ID                int64     `json:"id"`
+	IndexerID         int64     `json:"indexerId"`
+	DisabledTill      time.Time `json:"disabledTill"`
+	MostRecentFailure time.Time `json:"mostRecentFailure"`
+	InitialFailure    time.Time `json:"initialFailure"`
+}
+
+// ProwlarrIndexer is an indexer combined with its failure status.
+type ProwlarrIndexer struct {
+	*prowlarr.IndexerOutput
+	Status *ProwlarrIndexerStatus `json:"status,omitempty"`
+}
+
+// ProwlarrIndexerStats contains query and grab counts for every indexer.
+type ProwlarrIndexerStats struct {
+	Indexers []*ProwlarrIndexerStat `json:"indexers"`
+}
+
+// ProwlarrIndexerStat contains query and grab counts for a single indexer.
+type ProwlarrIndexerStat struct {
+	IndexerID                 int64  `json:"indexerId"`
+	IndexerName               string `json:"indexerName"`
+	AverageResponseTime       int64  `json:"averageResponseTime"`
+	NumberOfQueries           int64  `json:"numberOfQueries"`
+	NumberOfGrabs             int64  `json:"numberOfGrabs"`
+	NumberOfRssQueries        int64  `json:"numberOfRssQueries"`
+	NumberOfAuthQueries       int64  `json:"numberOfAuthQueries"`
+	NumberOfFailedQueries     int64  `json:"numberOfFailedQueries"`
+	NumberOfFailedGrabs       int64  `json:"numberOfFailedGrabs"`
+	NumberOfFailedRssQueries  int64  `json:"numberOfFailedRssQueries"`
+	NumberOfFailedAuthQueries int64  `json:"numberOfFailedAuthQueries"`
+}
+
+// GetIndexerStatusContext returns the status for every failing indexer.
+func (p Prowlarr) GetIndexerStatusContext(ctx context.Context) ([]*ProwlarrIndexerStatus, error) {
+	var output []*ProwlarrIndexerStatus
+
+	req := starr.Request{URI: path.Join(prowlarr.APIver, "indexerstatus")}
+	if err := p.GetInto(ctx, req, &output); err != nil {
+		return nil, fmt.Errorf("api.Get(%s): %w", &req, err)
+	}
+
+	return output, nil
+}
+
+// GetIndexersWithStatusContext returns every indexer combined with its failure status.
+func (p Prowlarr) GetIndexersWithStatusContext(ctx context.Context) ([]*ProwlarrIndexer, error) {
+	indexers, err := p.GetIndexersContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting indexers: %w", err)
+	}
+
+	statuses, err := p.GetIndexerStatusContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting indexer status: %w", err)
+	}
+
+	output := make([]*ProwlarrIndexer, len(indexers))
+
+	for idx, indexer := range indexers {
+		output[idx] = &ProwlarrIndexer{IndexerOutput: indexer}
+
+		for _, status := range statuses {
+			if status.IndexerID == indexer.ID {
+				output[idx].Status = status
+			}
+		}
+	}
+
+	return output, nil
+}
+
+// GetIndexerStatsContext returns indexer statistics. Zero times are not sent to Prowlarr.
+func (p Prowlarr) GetIndexerStatsContext(ctx context.Context, start, end time.Time) (*ProwlarrIndexerStats, error) {
+	var output ProwlarrIndexerStats
+
+	req := starr.Request{URI: path.Join(prowlarr.APIver, "indexerstats"), Query: make(url.Values)}
+	if !start.IsZero() {
+		req.Query.Set("startDate", start.UTC().Format(time.RFC3339))
+	}
+
+	if !end.IsZero() {
+		req.Query.Set("endDate", end.UTC().Format(time.RFC3339))
+	}
+
+	if err := p.GetInto(ctx, req, &output); err != nil {
+		return nil, fmt.Errorf("api.Get(%s): %w", &req, err)
+	}
+
+	return &output, nil
+}
+
+// @Description	Returns all Prowlarr indexers combined with their failure status. Healthy indexers have no status.
+// @Summary		Retrieve Prowlarr Indexers
+// @Tags			Prowlarr
+// @Produce		json
+// @Param			instance	path		int64											true	"instance ID"
+// @Success		200			{object}	apps.APIResponse{message=[]apps.ProwlarrIndexer}	"indexers"
+// @Failure		503			{object}	apps.APIResponse{message=string}				"instance error"
+// @Failure		404			{object}	string											"bad token or api key"
+// @Router			/prowlarr/{instance}/indexers [get]
+// @Security		ApiKeyAuth
+func prowlarrGetIndexers(req *http.Request) (int, any) {
+	indexers, err := getProwlarr(req).GetIndexersWithStatusContext(req.Context())
+	if err != nil {
+		return apiError(http.StatusServiceUnavailable, "getting indexers", err)
+	}
+
+	return http.StatusOK, indexers
+}
+
+// @Description	Returns query, grab and failure counts, and average response time, for every Prowlarr indexer.
+// @Summary		Retrieve Prowlarr Indexer Stats
+// @Tags			Prowlarr
+// @Produce		json
+// @Param			instance	path		int64											true	"instance ID"
+// @Param			startDate	query		string											false	"RFC3339 start date"
+// @Param			endDate		query		string											false	"RFC3339 end date"
+// @Success		200			{object}	apps.APIResponse{message=apps.ProwlarrIndexerStats}	"indexer stats"
+// @Failure		400			{object}	apps.APIResponse{message=string}				"invalid date"
+// @Failure		503			{object}	apps.APIResponse{message=string}				"instance error"
+// @Failure		404			{object}	string											"bad token or api key"
+// @Router			/prowlarr/{instance}/indexers/stats [get]
+// @Security		ApiKeyAuth
+func prowlarrGetIndexerStats(req *http.Request) (int, any) {
+	var start, end time.Time
+
+	for key, date := range map[string]*time.Time{"startDate": &start, "endDate": &end} {
+		if value := req.URL.Query().Get(key); value != "" {
+			var err error
+			if *date, err = time.Parse(time.RFC3339, value); err != nil {
+				return apiError(http.StatusBadRequest, "parsing "+key, err)
+			}
+		}
+	}
+
+	stats, err := getProwlarr(req).GetIndexerStatsContext(req.Context(), start, end)
+	if err != nil {
+		return apiError(http.StatusServiceUnavailable, "getting indexer stats", err)
+	}
+
+	return http.StatusOK, stats
+}
+
+// @Description	Enables or disables a Prowlarr indexer.
+// @Summary		Enable or Disable Prowlarr Indexer
+// @Tags			Prowlarr
+// @Produce		json
+// @Param			instance	path		int64								true	"instance ID"
+// @Param			indexerID	path		int64								true	"indexer ID"
+// @Param			action		path		string								true	"enable or disable"
+// @Success		200			{object}	apps.APIResponse{message=string}	"success"
+// @Failure		503			{object}	apps.APIResponse{message=string}	"instance error"
+// @Failure		404			{object}	string								"bad token or api key"
+// @Router			/prowlarr/{instance}/indexer/{indexerID}/{action} [put]
+// @Security		ApiKeyAuth
+func prowlarrEnableIndexer(enable bool) APIHandler {
+	return func(req *http.Request) (int, any) {
+		indexerID, _ := strconv.ParseInt(mux.Vars(req)["indexerid"], mnd.Base10, mnd.Bits64)
+
+		_, err := getProwlarr(req).UpdateIndexersContext(req.Context(), &prowlarr.BulkIndexer{
+			IDs:    []int64{indexerID},
+			Enable: &enable,
+		})
+		if err != nil {
+			return apiError(http.StatusServiceUnavailable, "updating indexer", err)
+		}
+
+		return http.StatusOK, mnd.Success
+	}
+}
+
+// @Description	Tests a Prowlarr indexer using its saved settings.
+// @Summary		Test Prowlarr Indexer
+// @Tags			Prowlarr
+// @Produce		json
+// @Param			instance	path		int64								true	"instance ID"
+// @Param			indexerID	path		int64								true	"indexer ID"
+// @Success		200			{object}	apps.APIResponse{message=string}	"success"
+// @Failure		400			{object}	apps.APIResponse{message=string}	"test failed"
+// @Failure		503			{object}	apps.APIResponse{message=string}	"instance error"
+// @Failure		404			{object}	string								"bad token or api key"
+// @Router			/prowlarr/{instance}/indexer/{indexerID}/test [post]
+// @Security		ApiKeyAuth
+func prowlarrTestIndexer(req *http.Request) (int, any) {
+	indexerID, _ := strconv.ParseInt(mux.Vars(req)["indexerid"], mnd.Base10, mnd.Bits64)
+
+	indexer, err := getProwlarr(req).GetIndexerContext(req.Context(), indexerID)
+	if err != nil {
+		return apiError(http.StatusServiceUnavailable, "getting indexer", err)
+	}
+
+	// Prowlarr accepts the indexer output as test input, so send it back as-is.
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(indexer); err != nil {
+		return apiError(http.StatusInternalServerError, "encoding indexer", err)
+	}
+
+	var output any
+
+	request := starr.Request{URI: path.Join(prowlarr.APIver, "indexer", "test"), Body: &body}
+	if err := getProwlarr(req).PostInto(req.Context(), request, &output); err != nil {
+		return apiError(http.StatusBadRequest, "testing indexer", err)
+	}
+
+	return http.StatusOK, mnd.Success
+}
+
+// @Description	Searches Prowlarr indexers and returns the results. This is the same as the manual search page.
+// @Summary		Search Prowlarr Indexers
+// @Tags			Prowlarr
+// @Produce		json
+// @Accept			json
+// @Param			instance	path		int64										true	"instance ID"
+// @Param			POST		body		prowlarr.SearchInput						true	"search query, query is required"
+// @Success		200			{object}	apps.APIResponse{message=[]prowlarr.Search}	"search results"
+// @Failure		400			{object}	apps.APIResponse{message=string}			"json input error"
+// @Failure		503			{object}	apps.APIResponse{message=string}			"instance error"
+// @Failure		404			{object}	string										"bad token or api key"
+// @Router			/prowlarr/{instance}/search [post]
+// @Security		ApiKeyAuth
+func prowlarrSearch(req *http.Request) (int, any) {
+	var search prowlarr.SearchInput
+
+	if err := json.NewDecoder(req.Body).Decode(&search); err != nil {
+		return apiError(http.StatusBadRequest, "decoding payload", err)
+	}
+
+	results, err := getProwlarr(req).SearchContext(req.Context(), search)
+	if err != nil {
+		return apiError(http.StatusServiceUnavailable, "searching indexers", err)
+	}
+
+	return http.StatusOK, results
+}
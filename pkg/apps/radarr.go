@@ -56,6 +56,13 @@ func (a *Apps) radarrHandlers() {
 	a.HandleAPIpath(starr.Radarr, "/queue/{queueID}", radarrDeleteQueue, "DELETE")
 	a.HandleAPIpath(starr.Radarr, "/delete/{movieID:[0-9]+}", radarrDeleteMovie, "POST")
 	a.HandleAPIpath(starr.Radarr, "/delete/{movieFileID:[0-9]+}", radarrDeleteContent, "DELETE")
+	a.HandleAPIpath(starr.Radarr, "/bulk/monitor", radarrBulkEdit("monitor"), "PUT")
+	a.HandleAPIpath(starr.Radarr, "/bulk/unmonitor", radarrBulkEdit("unmonitor"), "PUT")
+	a.HandleAPIpath(starr.Radarr, "/bulk/qualityProfile", radarrBulkEdit("qualityProfile"), "PUT")
+	a.HandleAPIpath(starr.Radarr, "/bulk/tags/add", radarrBulkEdit(string(starr.TagsAdd)), "PUT")
+	a.HandleAPIpath(starr.Radarr, "/bulk/tags/remove", radarrBulkEdit(string(starr.TagsRemove)), "PUT")
+	a.HandleAPIpath(starr.Radarr, "/bulk/search", radarrBulkSearch, "POST")
+	a.HandleAPIpath(starr.Radarr, "/bulk/delete", radarrBulkDelete, "DELETE")
 }
 
 type Radarr struct {
@@ -99,6 +106,8 @@ func (a *AppsConfig) setupRadarr() ([]Radarr, error) {
 		output[idx] = Radarr{
 			Radarr:      radarr.New(&app.Config),
 			StarrConfig: a.Radarr[idx],
+			app:         starr.Radarr,
+			index:       idx,
 		}
 
 		if app.Deletes > 0 {
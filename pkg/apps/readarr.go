@@ -74,6 +74,8 @@ func (a *AppsConfig) setupReadarr() ([]Readarr, error) {
 		app.URL = strings.TrimRight(app.URL, "/")
 		output[idx] = Readarr{
 			StarrConfig: a.Readarr[idx],
+			app:         starr.Readarr,
+			index:       idx,
 			Readarr:     readarr.New(&app.Config),
 		}
 
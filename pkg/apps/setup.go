@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/klauspost/compress/gzhttp"
@@ -83,6 +84,8 @@ type StarrConfig struct {
 type StarrApp struct {
 	StarrConfig
 	delLimit *rate.Limiter
+	app      starr.App
+	index    int
 }
 
 // Starr returns the embedded Starr app config. Promoted onto Lidarr/Radarr/etc.
@@ -105,6 +108,8 @@ var (
 	ErrWrongCount = errors.New("wrong item count returned")
 	ErrInvalidApp = errors.New("invalid application configuration provided")
 	ErrRateLimit  = errors.New("rate limit reached")
+	// ErrDeleteBurst is returned when a bulk delete has more items than the deletes setting allows per hour.
+	ErrDeleteBurst = errors.New("more items than the deletes limit allows; raise deletes or delete fewer items")
 )
 
 // CheckURLs validates the configuration for each app.
@@ -277,6 +282,8 @@ func (a *Apps) InitHandlers() {
 	a.radarrHandlers()
 	a.readarrHandlers()
 	a.sonarrHandlers()
+	a.historyHandlers()
+	a.downloaderHandlers()
 }
 
 // DelOK returns true if the delete limit isn't reached.
@@ -284,6 +291,21 @@ func (e StarrApp) DelOK() bool {
 	return e.Deletes > 0 && e.delLimit.Allow()
 }
 
+// DelAllowN returns nil if the delete limit allows deleting count items right now.
+// Returns ErrDeleteBurst if count can never be allowed, and ErrRateLimit if the limit is reached.
+func (e StarrApp) DelAllowN(count int) error {
+	switch {
+	case e.Deletes <= 0:
+		return ErrRateLimit
+	case count > e.delLimit.Burst():
+		return fmt.Errorf("%w: %d > %d", ErrDeleteBurst, count, e.delLimit.Burst())
+	case !e.delLimit.AllowN(time.Now(), count):
+		return ErrRateLimit
+	default:
+		return nil
+	}
+}
+
 // Enabled returns true if the Sonarr instance is enabled and usable.
 func (s StarrConfig) Enabled() bool {
 	return s.URL != "" && s.APIKey != "" && s.Timeout.Duration >= 0
@@ -0,0 +1,225 @@
+package apps //nolint:testpackage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	"golift.io/starr"
+	"golift.io/starr/sonarr"
+)
+
+// sonarrServer is a Sonarr API with one series that has two seasons.
+type sonarrServer struct {
+	series  *sonarrSeriesUpdate // the last series update.
+	command map[string]any      // the last command or episode monitor payload.
+	query   string              // the last manual import query.
+	deleted []string
+}
+
+// sonarrSeriesUpdate is the series update payload, decoded separately so missing values are noticed.
+type sonarrSeriesUpdate struct {
+	ID               int64            `json:"id"`
+	Title            string           `json:"title"`
+	Path             string           `json:"path"`
+	QualityProfileID int64            `json:"qualityProfileId"`
+	Tags             []int            `json:"tags"`
+	Seasons          []*sonarr.Season `json:"seasons"`
+}
+
+func (s *sonarrServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	uri := strings.TrimPrefix(r.URL.Path, "/api/v3/")
+
+	switch {
+	case uri == "series/5" && r.Method == http.MethodGet:
+		_, _ = w.Write([]byte(`{"id":5,"title":"Show","path":"/tv/Show","qualityProfileId":3,"tags":[7],` +
+			`"seasons":[{"seasonNumber":1,"monitored":true},{"seasonNumber":2,"monitored":true}]}`))
+	case uri == "series/5" && r.Method == http.MethodPut:
+		s.series = &sonarrSeriesUpdate{}
+		_ = json.NewDecoder(r.Body).Decode(s.series)
+		_, _ = w.Write([]byte(`{"id":5}`))
+	case uri == "episode":
+		// Every season is returned, like Sonarr does for season 0, so the handler must filter them.
+		_, _ = w.Write([]byte(`[{"id":11,"seasonNumber":1,"episodeFileId":101},` +
+			`{"id":12,"seasonNumber":1,"episodeFileId":101},{"id":13,"seasonNumber":1},` +
+			`{"id":21,"seasonNumber":2,"episodeFileId":201}]`))
+	case uri == "episode/monitor":
+		s.command = map[string]any{}
+		_ = json.NewDecoder(r.Body).Decode(&s.command)
+		_, _ = w.Write([]byte(`[{"id":11,"monitored":false}]`))
+	case strings.HasPrefix(uri, "episodeFile/") && r.Method == http.MethodDelete:
+		s.deleted = append(s.deleted, strings.TrimPrefix(uri, "episodeFile/"))
+	case uri == "manualimport":
+		s.query = r.URL.RawQuery
+		_, _ = w.Write([]byte(`[{"path":"/downloads/Show.S01E01.mkv","seasonNumber":1,"episodes":[{"id":11}]}]`))
+	case uri == "command":
+		s.command = map[string]any{}
+		_ = json.NewDecoder(r.Body).Decode(&s.command)
+		_, _ = w.Write([]byte(`{"id":1,"status":"queued"}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// sonarrRequest returns a request for a Sonarr handler, with the app and route variables it needs.
+func sonarrRequest(t *testing.T, app Sonarr, method, target, body string, vars map[string]string) *http.Request {
+	t.Helper()
+
+	ctx := context.WithValue(t.Context(), starr.Sonarr, app)
+	req := httptest.NewRequestWithContext(ctx, method, target, strings.NewReader(body))
+
+	return mux.SetURLVars(req, vars)
+}
+
+func newTestSonarr(t *testing.T, deletes int) (Sonarr, *sonarrServer) {
+	t.Helper()
+
+	server := &sonarrServer{}
+	httpd := httptest.NewServer(server)
+	t.Cleanup(httpd.Close)
+
+	app := Sonarr{Sonarr: sonarr.New(&starr.Config{URL: httpd.URL, APIKey: "key", Client: httpd.Client()})}
+	if app.Deletes = deletes; deletes > 0 {
+		app.delLimit = rate.NewLimiter(rate.Every(time.Hour/time.Duration(deletes)), deletes)
+	}
+
+	return app, server
+}
+
+func TestSonarrSeriesInput(t *testing.T) {
+	t.Parallel()
+
+	series := &sonarr.Series{
+		Monitored: true, SeasonFolder: true, UseSceneNumbering: true, ID: 1, LanguageProfileID: 2,
+		QualityProfileID: 3, TvdbID: 4, ImdbID: "tt5", TvMazeID: 6, TvRageID: 7, Path: "/tv/Show",
+		SeriesType: "anime", Title: "Show", TitleSlug: "show", RootFolderPath: "/tv", Tags: []int{8},
+		Seasons: []*sonarr.Season{{SeasonNumber: 1}}, Images: []*starr.Image{{URL: "/poster.jpg"}},
+		Overview: "Not part of an update.",
+	}
+
+	assert.Equal(t, &sonarr.AddSeriesInput{
+		Monitored: true, SeasonFolder: true, UseSceneNumbering: true, ID: 1, LanguageProfileID: 2,
+		QualityProfileID: 3, TvdbID: 4, ImdbID: "tt5", TvMazeID: 6, TvRageID: 7, Path: "/tv/Show",
+		SeriesType: "anime", Title: "Show", TitleSlug: "show", RootFolderPath: "/tv", Tags: []int{8},
+		Seasons: []*sonarr.Season{{SeasonNumber: 1}}, Images: []*starr.Image{{URL: "/poster.jpg"}},
+	}, sonarrSeriesInput(series), "every series value must be kept in the update")
+}
+
+func TestSonarrMonitorSeason(t *testing.T) {
+	t.Parallel()
+
+	app, server := newTestSonarr(t, 0)
+	vars := map[string]string{"seriesid": "5", "season": "1"}
+
+	code, _ := sonarrMonitorSeason(false)(sonarrRequest(t, app, http.MethodPut, "/", "", vars))
+	assert.Equal(t, http.StatusOK, code)
+	require.NotNil(t, server.series)
+	assert.Equal(t, &sonarrSeriesUpdate{
+		ID: 5, Title: "Show", Path: "/tv/Show", QualityProfileID: 3, Tags: []int{7},
+		Seasons: []*sonarr.Season{{SeasonNumber: 1, Monitored: false}, {SeasonNumber: 2, Monitored: true}},
+	}, server.series, "only the season is unmonitored")
+	assert.Equal(t, map[string]any{"episodeIds": []any{11.0, 12.0, 13.0}, "monitored": false}, server.command,
+		"every episode in the season is unmonitored")
+
+	code, _ = sonarrMonitorSeason(true)(sonarrRequest(t, app, http.MethodPut, "/", "",
+		map[string]string{"seriesid": "5", "season": "9"}))
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestSonarrDeleteSeasonFiles(t *testing.T) {
+	t.Parallel()
+
+	vars := map[string]string{"seriesid": "5", "season": "1"}
+
+	app, _ := newTestSonarr(t, 0)
+	code, _ := sonarrDeleteSeasonFiles(sonarrRequest(t, app, http.MethodDelete, "/", "", vars))
+	assert.Equal(t, http.StatusLocked, code, "deletes are disabled")
+
+	app, server := newTestSonarr(t, 5)
+	code, msg := sonarrDeleteSeasonFiles(sonarrRequest(t, app, http.MethodDelete, "/", "", vars))
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []int64{101}, msg, "two episodes in one file delete the file once")
+	assert.Equal(t, []string{"101"}, server.deleted)
+
+	app, _ = newTestSonarr(t, 1)
+	vars = map[string]string{"efids": "1,2"}
+	code, _ = sonarrDeleteEpisodeFiles(sonarrRequest(t, app, http.MethodDelete, "/", "", vars))
+	assert.Equal(t, http.StatusBadRequest, code, "more files than the deletes limit")
+}
+
+func TestSonarrManualImportValidate(t *testing.T) {
+	t.Parallel()
+
+	file := &SonarrManualImportFile{Path: "/downloads/a.mkv", SeriesID: 5, EpisodeIDs: []int64{11}}
+	tests := []struct {
+		name  string
+		input SonarrManualImport
+		mode  string
+		err   error
+	}{
+		{name: "default mode", input: SonarrManualImport{Files: []*SonarrManualImportFile{file}}, mode: "auto"},
+		{name: "move", input: SonarrManualImport{ImportMode: "Move", Files: []*SonarrManualImportFile{file}}, mode: "move"},
+		{
+			name:  "bad mode",
+			input: SonarrManualImport{ImportMode: "link", Files: []*SonarrManualImportFile{file}},
+			err:   ErrBadImportMode,
+		},
+		{name: "no files", input: SonarrManualImport{}, err: ErrNoImportFiles},
+		{name: "nil file", input: SonarrManualImport{Files: []*SonarrManualImportFile{nil}}, err: ErrNoImportFiles},
+		{
+			name:  "no episodes",
+			input: SonarrManualImport{Files: []*SonarrManualImportFile{{Path: "/downloads/a.mkv", SeriesID: 5}}},
+			err:   ErrNoImportFiles,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := test.input.validate()
+			require.ErrorIs(t, err, test.err)
+
+			if test.err == nil {
+				assert.Equal(t, test.mode, test.input.ImportMode)
+			}
+		})
+	}
+}
+
+func TestSonarrManualImport(t *testing.T) {
+	t.Parallel()
+
+	app, server := newTestSonarr(t, 0)
+
+	code, _ := sonarrGetManualImport(sonarrRequest(t, app, http.MethodGet, "/?seriesId=5", "", nil))
+	assert.Equal(t, http.StatusBadRequest, code, "a folder or download ID is required")
+
+	code, msg := sonarrGetManualImport(sonarrRequest(t, app, http.MethodGet, "/?downloadId=ABC&seasonNumber=1", "", nil))
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "downloadId=ABC&filterExistingFiles=true&seasonNumber=1", server.query)
+	require.Len(t, msg, 1)
+
+	code, _ = sonarrManualImport(sonarrRequest(t, app, http.MethodPost, "/", `{"files":[]}`, nil))
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, msg = sonarrManualImport(sonarrRequest(t, app, http.MethodPost, "/",
+		`{"files":[{"path":"/downloads/Show.S01E01.mkv","seriesId":5,"episodeIds":[11],"downloadId":"ABC"}]}`, nil))
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "queued", msg)
+	assert.Equal(t, map[string]any{
+		"name":       "ManualImport",
+		"importMode": "auto",
+		"files": []any{map[string]any{
+			"path": "/downloads/Show.S01E01.mkv", "seriesId": 5.0, "episodeIds": []any{11.0}, "downloadId": "ABC",
+		}},
+	}, server.command)
+}
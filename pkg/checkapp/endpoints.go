@@ -1,40 +1,34 @@
 package checkapp
 
 import (
-	"bytes"
 	"context"
-	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
-	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/endpoints"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/endpoints/epconfig"
 )
 
 func Endpoint(ctx context.Context, input *Input) (string, int) {
 	endpoint := getTestEndpoint(input, input.Index)
-	client := endpoint.GetClient()
-	body := bytes.NewBufferString(endpoint.Body)
 
-	req, err := http.NewRequestWithContext(ctx, endpoint.Method, endpoint.GetURL(), body)
+	resp, err := endpoints.NewSchedule(endpoint, nil).Fetch(ctx)
 	if err != nil {
-		return err.Error(), http.StatusInternalServerError
+		return err.Error(), http.StatusFailedDependency
 	}
 
-	endpoint.SetHeaders(req)
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return err.Error(), http.StatusFailedDependency
+	msg := strconv.Itoa(resp.Status) + " " + http.StatusText(resp.Status) +
+		" - Response Size: " + strconv.Itoa(len(resp.Body))
+	if len(resp.Steps) > 0 {
+		msg += " - Steps: " + strconv.Itoa(len(resp.Steps))
 	}
-	defer resp.Body.Close()
 
-	size, err := io.Copy(io.Discard, resp.Body)
-	if err != nil {
-		return err.Error(), http.StatusInternalServerError
+	if len(resp.Failures) > 0 {
+		return msg + " - Check Failed: " + strings.Join(resp.Failures, "; "), http.StatusFailedDependency
 	}
 
-	return resp.Status + " - Response Size: " + strconv.FormatInt(size, mnd.Base10), http.StatusOK
+	return msg, http.StatusOK
 }
 
 func getTestEndpoint(input *Input, index int) *epconfig.Endpoint {
@@ -137,6 +137,12 @@ func (c *Client) httpAPIHandlers() {
 
 	// Aggregate handlers. Non-app specific.
 	c.apps.HandleAPIpath("", "/trash/{app}", c.triggers.CFSync.Handler, "POST")
+	c.apps.HandleAPIpath("", "/seeding/report", c.triggers.Seeding.ReportHandler, "GET")
+	c.apps.HandleAPIpath("", "/orphans", c.triggers.Orphans.ReportHandler, "GET")
+	c.apps.HandleAPIpath("", "/orphans", c.triggers.Orphans.DeleteHandler, "DELETE")
+	c.apps.HandleAPIpath("", "/usenet/health", c.triggers.Usenet.HealthHandler, "GET")
+	c.apps.HandleAPIpath("", "/command/{hash}/runs", c.triggers.Commands.HistoryHandler, "GET")
+	c.apps.HandleAPIpath("", "/command/{hash}/runs/{id:[0-9]+}", c.triggers.Commands.RunHandler, "GET")
 
 	if c.Config.Plex.Enabled() {
 		c.apps.HandleAPIpath(starr.Plex, "sessions", c.apps.Plex.HandleSessions, "GET")
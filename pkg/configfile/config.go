@@ -27,6 +27,10 @@ import (
 	"github.com/Notifiarr/notifiarr/pkg/triggers/commands"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/endpoints/epconfig"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/filewatch"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/orphans"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/seeding"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/starrqueue"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/usenet"
 	"github.com/Notifiarr/notifiarr/pkg/ui"
 	"github.com/Notifiarr/notifiarr/pkg/website"
 	"github.com/Notifiarr/notifiarr/pkg/website/clientinfo"
@@ -49,25 +53,31 @@ const (
 
 // Config represents the data in our config file.
 type Config struct {
-	HostID     string                   `json:"hostId"      toml:"host_id"       xml:"host_id"       yaml:"hostId"`
-	UIPassword CryptPass                `json:"uiPassword"  toml:"ui_password"   xml:"ui_password"   yaml:"uiPassword"`
-	BindAddr   string                   `json:"bindAddr"    toml:"bind_addr"     xml:"bind_addr"     yaml:"bindAddr"`
-	NoCompress bool                     `json:"noCompress"  toml:"no_compress"   xml:"no_compress"   yaml:"noCompress"`
-	SSLCrtFile string                   `json:"sslCertFile" toml:"ssl_cert_file" xml:"ssl_cert_file" yaml:"sslCertFile"`
-	SSLKeyFile string                   `json:"sslKeyFile"  toml:"ssl_key_file"  xml:"ssl_key_file"  yaml:"sslKeyFile"`
-	Upstreams  []string                 `json:"upstreams"   toml:"upstreams"     xml:"upstreams"     yaml:"upstreams"`
-	AutoUpdate string                   `json:"autoUpdate"  toml:"auto_update"   xml:"auto_update"   yaml:"autoUpdate"`
-	UnstableCh bool                     `json:"unstableCh"  toml:"unstable_ch"   xml:"unstable_ch"   yaml:"unstableCh"`
-	Timeout    cnfg.Duration            `json:"timeout"     toml:"timeout"       xml:"timeout"       yaml:"timeout"`
-	Retries    int                      `json:"retries"     toml:"retries"       xml:"retries"       yaml:"retries"`
-	Snapshot   snapshot.Config          `json:"snapshot"    toml:"snapshot"      xml:"snapshot"      yaml:"snapshot"`
-	Services   services.Config          `json:"services"    toml:"services"      xml:"services"      yaml:"services"`
-	Service    []services.ServiceConfig `json:"service"     toml:"service"       xml:"service"       yaml:"service"`
-	EnableApt  bool                     `json:"apt"         toml:"apt"           xml:"apt"           yaml:"apt"`
-	WatchFiles []*filewatch.WatchFile   `json:"watchFiles"  toml:"watch_file"    xml:"watch_file"    yaml:"watchFiles"`
-	Endpoints  []*epconfig.Endpoint     `json:"endpoints"   toml:"endpoint"      xml:"endpoint"      yaml:"endpoints"`
-	Commands   []*commands.Command      `json:"commands"    toml:"command"       xml:"command"       yaml:"commands"`
-	Version    uint                     `json:"version"     toml:"version"       xml:"version"       yaml:"version"`
+	HostID     string                    `json:"hostId"      toml:"host_id"       xml:"host_id"       yaml:"hostId"`
+	UIPassword CryptPass                 `json:"uiPassword"  toml:"ui_password"   xml:"ui_password"   yaml:"uiPassword"`
+	BindAddr   string                    `json:"bindAddr"    toml:"bind_addr"     xml:"bind_addr"     yaml:"bindAddr"`
+	NoCompress bool                      `json:"noCompress"  toml:"no_compress"   xml:"no_compress"   yaml:"noCompress"`
+	SSLCrtFile string                    `json:"sslCertFile" toml:"ssl_cert_file" xml:"ssl_cert_file" yaml:"sslCertFile"`
+	SSLKeyFile string                    `json:"sslKeyFile"  toml:"ssl_key_file"  xml:"ssl_key_file"  yaml:"sslKeyFile"`
+	Upstreams  []string                  `json:"upstreams"   toml:"upstreams"     xml:"upstreams"     yaml:"upstreams"`
+	AutoUpdate string                    `json:"autoUpdate"  toml:"auto_update"   xml:"auto_update"   yaml:"autoUpdate"`
+	UnstableCh bool                      `json:"unstableCh"  toml:"unstable_ch"   xml:"unstable_ch"   yaml:"unstableCh"`
+	Timeout    cnfg.Duration             `json:"timeout"     toml:"timeout"       xml:"timeout"       yaml:"timeout"`
+	Retries    int                       `json:"retries"     toml:"retries"       xml:"retries"       yaml:"retries"`
+	Snapshot   snapshot.Config           `json:"snapshot"    toml:"snapshot"      xml:"snapshot"      yaml:"snapshot"`
+	Services   services.Config           `json:"services"    toml:"services"      xml:"services"      yaml:"services"`
+	Service    []services.ServiceConfig  `json:"service"     toml:"service"       xml:"service"       yaml:"service"`
+	EnableApt  bool                      `json:"apt"         toml:"apt"           xml:"apt"           yaml:"apt"`
+	WatchFiles []*filewatch.WatchFile    `json:"watchFiles"  toml:"watch_file"    xml:"watch_file"    yaml:"watchFiles"`
+	Endpoints  []*epconfig.Endpoint      `json:"endpoints"   toml:"endpoint"      xml:"endpoint"      yaml:"endpoints"`
+	Commands   []*commands.Command       `json:"commands"    toml:"command"       xml:"command"       yaml:"commands"`
+	Stalled    *starrqueue.StalledConfig `json:"stalled"     toml:"stalled"       xml:"stalled"       yaml:"stalled"`
+	Stuck      *starrqueue.StuckConfig   `json:"stuck"       toml:"stuck"         xml:"stuck"         yaml:"stuck"`
+	Imports    *starrqueue.ImportConfig  `json:"imports"     toml:"imports"       xml:"imports"       yaml:"imports"`
+	Seeding    *seeding.Config           `json:"seeding"     toml:"seeding"       xml:"seeding"       yaml:"seeding"`
+	Orphans    *orphans.Config           `json:"orphans"     toml:"orphans"       xml:"orphans"       yaml:"orphans"`
+	Usenet     *usenet.Config            `json:"usenet"      toml:"usenet"        xml:"usenet"        yaml:"usenet"`
+	Version    uint                      `json:"version"     toml:"version"       xml:"version"       yaml:"version"`
 	logs.LogConfig
 	apps.AppsConfig
 }
@@ -234,6 +244,12 @@ func (c *Config) setup(ctx context.Context, flag *Flags, svc *services.Services,
 		WatchFiles: c.WatchFiles,
 		LogFiles:   c.GetActiveLogFilePaths(),
 		Commands:   c.Commands,
+		Stalled:    c.Stalled,
+		Stuck:      c.Stuck,
+		Imports:    c.Imports,
+		Seeding:    c.Seeding,
+		Orphans:    c.Orphans,
+		Usenet:     c.Usenet,
 		ClientInfo: clientinfo,
 		ConfigFile: flag.ConfigFile,
 		AutoUpdate: c.AutoUpdate,
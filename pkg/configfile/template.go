@@ -422,6 +422,21 @@ version = {{.Version}}
   {{- if .Plex.ValidSSL}}
   valid_ssl = true
   {{- end}}
+  {{- if .Plex.Throttle}}
+
+## Apply alternate download client speed limits (KiB/s, 0 = unlimited) while Plex is busy.
+[plex.throttle]
+  sessions          = {{.Plex.Throttle.Sessions}} # remote or transcoding streams needed to throttle.
+  bandwidth         = {{.Plex.Throttle.Bandwidth}} # total stream bandwidth (kbps) needed to throttle.
+  restore_sessions  = {{.Plex.Throttle.RestoreSessions}} # restore below this many streams; 0 uses sessions.
+  restore_bandwidth = {{.Plex.Throttle.RestoreBandwidth}} # restore below this bandwidth (kbps); 0 uses bandwidth.
+  download          = {{.Plex.Throttle.Download}}
+  upload            = {{.Plex.Throttle.Upload}}
+  normal_download   = {{.Plex.Throttle.NormalDownload}} # 0 restores the limit the client had before throttling.
+  normal_upload     = {{.Plex.Throttle.NormalUpload}}
+  restore           = "{{.Plex.Throttle.Restore}}" # how long Plex must be calm before restoring the limits.
+  clients           = [{{range $i, $c := .Plex.Throttle.Clients}}{{if $i}}, {{end}}"{{$c}}"{{end}}]
+  {{- end}}
 {{- else}}#[plex]
 #url     = "http://localhost:32400/" # Your plex URL
 #token   = "" # your plex token; get this from a web inspector
@@ -540,6 +555,25 @@ version = {{.Version}}
 ######################
 
 ## Tail a log file, regex match lines, and send notifications.
+## The path may be a glob, like '/config/logs/*.txt', or a directory to watch every file in it.
+## New matching files are found within 10 seconds and read from the start. Rotated (renamed) files are not read again.
+## Multi-line events, like stack traces, are grouped into one match when start_regex or continue_regex
+## is set. A line matching start_regex, or not matching continue_regex, begins a new event; other lines
+## are added to the current event. An event ends at max_lines (default 50), or when no line is written
+## for the flush duration (default 2s). The regex and skip expressions match the whole event.
+## Set format to json or logfmt to parse each line into fields, and match with an expression instead of
+## a regex, like: level == "error" && logger =~ 'Import'. Operators: == != =~ !~ < <= > >= && || ! ( ).
+## Nested JSON fields use dots, like request.host. The parsed fields are sent with every match.
+## Set source to journald or docker to watch a log that is not a file. For journald, path is a unit,
+## like 'sonarr.service', or a journal match, like 'SYSLOG_IDENTIFIER=sonarr'. For docker, path is a
+## container name; the docker socket must be available (DOCKER_HOST is used if set). Only new lines are read.
+## Each watcher sends up to 'burst' matches at once, then one per 'rate' (defaults: 6 and 1.5s). Set dedup
+## to send identical matches once per window, followed by a "repeated N times" count. Set digest to collect
+## matches and send them together, up to 100 per digest, once per digest interval.
+## Actions run when a line matches: 'command:<name or hash>', 'endpoint:<name>' or 'trigger:<name>[/content]',
+## like 'trigger:TrigDashboard' or 'trigger:backup/sonarr'. Regex capture groups are passed to commands as
+## arguments, and must match the command's argument regexps. Actions do not run for deduplicated repeats,
+## and have their own burst and rate limit, with the same settings as the watcher's matches.
 ## Example:
 
 #[[watch_file]]
@@ -551,6 +585,18 @@ version = {{.Version}}
 #  must_exist = false
 #  log_match  = true
 #  disabled   = false
+#  start_regex    = '''^\d{4}-\d\d-\d\d'''
+#  continue_regex = ''
+#  max_lines      = 50
+#  flush          = "2s"
+#  format         = "json"
+#  expression     = '''level == "error" && logger =~ 'Import''''
+#  source         = "file"
+#  burst          = 6
+#  rate           = "1.5s"
+#  dedup          = "0s"
+#  digest         = "0s"
+#  actions        = ['command:restart-plex']
 {{if .WatchFiles}}
 ## Configured Watch Files:
 {{- range $item := .WatchFiles}}{{if $item}}
@@ -563,9 +609,234 @@ version = {{.Version}}
   pipe  = true{{end}}{{if $item.MustExist}}
   must_exist = true{{end}}{{if $item.LogMatch}}
   log_match = true{{end}}{{if $item.Disabled}}
-  disabled = true{{end}}{{end}}
+  disabled = true{{end}}{{if $item.StartRegexp}}
+  start_regex = '''{{$item.StartRegexp}}'''{{end}}{{if $item.ContinueRegexp}}
+  continue_regex = '''{{$item.ContinueRegexp}}'''{{end}}{{if $item.MaxLines}}
+  max_lines = {{$item.MaxLines}}{{end}}{{if $item.Flush.Duration}}
+  flush = "{{$item.Flush}}"{{end}}{{if $item.Format}}
+  format = "{{$item.Format}}"{{end}}{{if $item.Expression}}
+  expression = '''{{$item.Expression}}'''{{end}}{{if $item.Source}}
+  source = "{{$item.Source}}"{{end}}{{if $item.Burst}}
+  burst = {{$item.Burst}}{{end}}{{if $item.Rate.Duration}}
+  rate = "{{$item.Rate}}"{{end}}{{if $item.Dedup.Duration}}
+  dedup = "{{$item.Dedup}}"{{end}}{{if $item.Digest.Duration}}
+  digest = "{{$item.Digest}}"{{end}}{{if $item.Actions}}
+  actions = [{{range $s := $item.Actions}}'{{$s}}',{{end}}]{{end}}{{end}}
 {{end}}{{end}}
 
+####################
+# Stalled Torrents #
+####################
+
+## The stalled torrent detector inspects torrents in your clients directly.
+## Durations are how long a torrent must be in a condition before it's stalled; 0 disables a check.
+## action may be notify, remove (with data), blocklist (remove and blocklist in the owning starr app),
+## or research (blocklist and search for a replacement). Starr app actions require 'deletes' on that app.
+## Completed torrents are never stalled; use [seeding] policies for ratio and seed time goals.
+## Full Example (remove the leading # hashes to use it):
+
+#[stalled]
+#  interval = "15m"
+#  no_seeds = "12h"
+#  metadata = "1h"
+#  no_eta   = "24h"
+#  action   = "notify"
+#  clients  = ["qbit", "deluge", "transmission", "rtorrent"]
+{{- if .Stalled}}
+
+[stalled]
+  interval = "{{.Stalled.Interval}}"
+  no_seeds = "{{.Stalled.NoSeeds}}"
+  metadata = "{{.Stalled.Metadata}}"
+  no_eta   = "{{.Stalled.NoETA}}"
+  action   = "{{.Stalled.Action}}"
+  clients  = [{{range $i, $c := .Stalled.Clients}}{{if $i}}, {{end}}"{{$c}}"{{end}}]
+{{- end}}
+
+##################
+# Import Tracker #
+##################
+
+## The import tracker polls the Lidarr, Radarr, Readarr and Sonarr queues every interval, and follows
+## each download from finished in the download client to imported by the starr app. It logs events
+## for download completed, import succeeded, import failed and import overdue, with the time since the
+## download completed. overdue is how long an import may take before it's reported; default is 1 hour.
+## apps limits tracking to these starr apps. Remove the leading # hashes to enable it:
+
+#[imports]
+#  interval = "2m"
+#  overdue  = "1h"
+#  apps     = []
+{{- if .Imports}}
+
+[imports]
+  interval = "{{.Imports.Interval}}"
+  overdue  = "{{.Imports.Overdue}}"
+  apps     = [{{range $s := .Imports.Apps}}'{{$s}}',{{end}}]
+{{- end}}
+
+####################
+# Stuck Item Rules #
+####################
+
+## Stuck item rules act on stuck starr app queue items locally, even when the website is unreachable.
+## The queues are fetched every interval. Rules are checked in order; the first match wins.
+## messages match any part of the item's status or error messages (case-insensitive), and states match
+## the tracked download state (importPending, importBlocked, failedPending). A rule needs one of them.
+## wait is how long an item must match before acting. Actions: remove, blocklist, research, import.
+## remove, blocklist and research delete the queue item and count against the app's 'deletes' limit.
+## import imports every file the app matched to media, like the manual import dialog. Files the app
+## rejected are skipped, and import may not be used on sample or "not an upgrade" items.
+## Full Example (remove the leading # hashes to use it):
+
+#[stuck]
+#  interval = "5m"
+#  dry_run  = true
+#
+#[[stuck.rule]]
+#  name     = "no eligible files"
+#  messages = ["No files found are eligible for import", "Sample"]
+#  apps     = ["sonarr", "radarr"]
+#  wait     = "1h"
+#  action   = "research"
+#
+#[[stuck.rule]]
+#  name     = "unknown series"
+#  messages = ["Unknown Series"]
+#  states   = ["importBlocked"]
+#  wait     = "30m"
+#  action   = "remove"
+{{- if .Stuck}}
+
+[stuck]
+  interval = "{{.Stuck.Interval}}"
+  dry_run  = {{.Stuck.DryRun}}
+{{- range $item := .Stuck.Rules}}{{if $item}}
+
+[[stuck.rule]]
+  name     = '{{$item.Name}}'
+  messages = [{{range $s := $item.Messages}}'{{$s}}',{{end}}]
+  states   = [{{range $s := $item.States}}'{{$s}}',{{end}}]
+  apps     = [{{range $s := $item.Apps}}'{{$s}}',{{end}}]
+  wait     = "{{$item.Wait}}"
+  action   = "{{$item.Action}}"
+  disabled = {{$item.Disabled}}{{end}}{{end}}
+{{- end}}
+
+####################
+# Seeding Policies #
+####################
+
+## Seeding policies pause or remove torrents once they satisfy a minimum ratio and seed time.
+## Policies are evaluated in order; the first policy that matches a torrent (by tracker, category
+## and client) decides what happens to it. Empty lists match everything. Enforced every 'every'
+## duration, or on a schedule using the same frequency or cron settings as endpoints (see below).
+## keep_linked skips torrents with hard-linked files (already imported into a library);
+## the torrent paths must be the same inside and outside of this app for that to work.
+## dry_run only logs what would happen. GET /api/seeding/report always returns a dry run report.
+## Full Example (remove the leading # hashes to use it):
+
+#[seeding]
+#  every   = "1h"
+#  dry_run = true
+#
+#[[seeding.policy]]
+#  name        = "private trackers"
+#  trackers    = ["tracker.example.org"]
+#  categories  = ["radarr", "sonarr"]
+#  ratio       = 1.0
+#  seed_time   = "336h"
+#  action      = "remove"
+#  delete_data = true
+#  keep_linked = true
+{{- if .Seeding}}
+
+[seeding]
+  every         = "{{.Seeding.Every}}"
+  dry_run       = {{.Seeding.DryRun}}
+  frequency     = {{.Seeding.Frequency}}
+  interval      = {{.Seeding.Interval}}
+  days_of_week  = [{{range $s := .Seeding.DaysOfWeek}}{{$s}},{{end}}]
+  days_of_month = [{{range $s := .Seeding.DaysOfMonth}}{{$s}},{{end}}]
+  months        = [{{range $s := .Seeding.Months}}{{$s}},{{end}}]
+  at_times      = [{{range $s := .Seeding.AtTimes}}[{{range $j := $s}}{{$j}},{{end}}],{{end}}]
+  {{- if .Seeding.Cron}}
+  cron          = '{{.Seeding.Cron}}'{{end}}{{if .Seeding.Timezone}}
+  timezone      = '{{.Seeding.Timezone}}'{{end}}
+{{- range $item := .Seeding.Policies}}{{if $item}}
+
+[[seeding.policy]]
+  name        = '{{$item.Name}}'
+  trackers    = [{{range $s := $item.Trackers}}'{{$s}}',{{end}}]
+  categories  = [{{range $s := $item.Categories}}'{{$s}}',{{end}}]
+  clients     = [{{range $s := $item.Clients}}'{{$s}}',{{end}}]
+  ratio       = {{$item.Ratio}}
+  seed_time   = "{{$item.SeedTime}}"
+  action      = "{{$item.Action}}"
+  delete_data = {{$item.DeleteData}}
+  keep_linked = {{$item.KeepLinked}}
+  disabled    = {{$item.Disabled}}{{end}}{{end}}
+{{- end}}
+
+##################
+# Orphaned Files #
+##################
+
+## The orphaned file finder reports files and folders in your download folders that no download client
+## knows about, and that are not hard-linked into a library (not imported). The torrent clients' save
+## folders are scanned automatically; add your usenet completed folders to 'paths'. Set root_folders
+## to also report unmapped folders in the Lidarr, Radarr and Sonarr root folders. Nothing is deleted
+## automatically. Review the report with GET /api/orphans, and delete with DELETE /api/orphans.
+## Paths must be the same inside and outside of this app. Every = "0s" only scans when triggered.
+## Download folders are skipped while any enabled download client fails to list its transfers.
+## Full Example (remove the leading # hashes to use it):
+
+#[orphans]
+#  every        = "24h"
+#  paths        = ["/downloads/complete"]
+#  min_age      = "24h"
+#  exclude      = ["*.part", "incomplete"]
+#  root_folders = false
+{{- if .Orphans}}
+
+[orphans]
+  every        = "{{.Orphans.Every}}"
+  paths        = [{{range $s := .Orphans.Paths}}'{{$s}}',{{end}}]
+  min_age      = "{{.Orphans.MinAge}}"
+  exclude      = [{{range $s := .Orphans.Exclude}}'{{$s}}',{{end}}]
+  root_folders = {{.Orphans.RootFolders}}
+{{- end}}
+
+###########################
+# Usenet Failure Analysis #
+###########################
+
+## The usenet failure analysis reads SABnzbd and NZBGet history and groups failed downloads by
+## reason (missing articles, password protected, par repair failed, etc), indexer and category.
+## Provider (usenet server) article failures are counted too. An alert is logged when an indexer
+## has at least min_samples downloads, or a provider has at least min_articles articles, in the
+## window, and fails at least 'threshold' percent of them. Set indexers = true to find each download's indexer in the
+## Lidarr, Radarr, Readarr and Sonarr grab history; otherwise the NZB URL host name is used.
+## Review the report with GET /api/usenet/health. Full Example (remove the leading # hashes to use it):
+
+#[usenet]
+#  interval     = "15m"
+#  window       = "24h"
+#  threshold    = 30
+#  min_samples  = 5
+#  min_articles = 10000
+#  indexers     = false
+{{- if .Usenet}}
+
+[usenet]
+  interval     = "{{.Usenet.Interval}}"
+  window       = "{{.Usenet.Window}}"
+  threshold    = {{.Usenet.Threshold}}
+  min_samples  = {{.Usenet.MinSamples}}
+  min_articles = {{.Usenet.MinArticles}}
+  indexers     = {{.Usenet.Indexers}}
+{{- end}}
+
 ###################
 # Custom Commands #
 ###################
@@ -576,27 +847,61 @@ version = {{.Version}}
 ## The example below allows a user to run any combination of ls -la on /usr, /home, or /tmp:
 ## command = "/bin/ls ({-la|-al|-l|-a}) ({/usr|/home|/tmp})"
 ##
+## Optional settings:
+## @env         - NAME=value environment variables for the command.
+## @secrets     - NAME=/path/to/file; the file's contents become the NAME environment variable.
+## @dir         - Working directory. Default is this app's working directory.
+## @run_as      - User name or uid to run the command as. Requires running this app as root. Not on Windows.
+## @stdin       - Text written to the command's standard input.
+## @exit_codes  - code=state; a mapped exit code is not an error, and its state is sent to the website.
+## @json        - Parse standard output as JSON, and send it to the website as structured data.
+## @concurrency - What to do when the command is triggered while it's running. Default is queue.
+##                queue waits, parallel runs anyway, reject does not run, kill stops the running command.
+## @history     - How many runs to keep in the run history. Default is 20, -1 disables the history.
+## @history_age - How long to keep runs in the run history. Default is 30 days.
+## The run history is saved next to this config file, and is available at GET /api/command/{hash}/runs.
+##
 ## Full Example (remove the leading # hashes to use it):
 
 #[[command]]
-#  name    = 'some-name-for-logs'
-#  command = '/var/log/system.log'
-#  shell   = false
-#  log     = true
-#  notify  = true
-#  timeout = "10s"
+#  name        = 'some-name-for-logs'
+#  command     = '/var/log/system.log'
+#  shell       = false
+#  log         = true
+#  notify      = true
+#  timeout     = "10s"
+#  env         = ["MODE=check"]
+#  secrets     = ["API_KEY=/run/secrets/api_key"]
+#  dir         = "/tmp"
+#  run_as      = "nobody"
+#  stdin       = ""
+#  exit_codes  = ["1=warning", "2=critical"]
+#  json        = false
+#  concurrency = "queue"
+#  history     = 20
+#  history_age = "720h"
 {{if .Commands}}
 ## Configured Commands:
 {{- range $item := .Commands}}{{if $item}}
 
 [[command]]
-  name    = '{{$item.Name}}'
-  hash    = '{{$item.Hash}}'
-  command = '''{{toml $item.Command}}'''
-  shell   = {{$item.Shell}}
-  log     = {{$item.Log}}
-  notify  = {{$item.Notify}}
-  timeout = "{{$item.Timeout}}"{{end}}
+  name        = '{{$item.Name}}'
+  hash        = '{{$item.Hash}}'
+  command     = '''{{toml $item.Command}}'''
+  shell       = {{$item.Shell}}
+  log         = {{$item.Log}}
+  notify      = {{$item.Notify}}
+  timeout     = "{{$item.Timeout}}"
+  json        = {{$item.JSON}}
+  concurrency = '{{$item.Concurrency}}'
+  history     = {{$item.History}}
+  history_age = "{{$item.HistoryAge}}"{{if $item.Env}}
+  env         = [{{range $s := $item.Env}}'''{{toml $s}}''',{{end}}]{{end}}{{if $item.Secrets}}
+  secrets     = [{{range $s := $item.Secrets}}'{{$s}}',{{end}}]{{end}}{{if $item.Dir}}
+  dir         = '{{$item.Dir}}'{{end}}{{if $item.RunAs}}
+  run_as      = '{{$item.RunAs}}'{{end}}{{if $item.Stdin}}
+  stdin       = '''{{toml $item.Stdin}}'''{{end}}{{if $item.ExitCodes}}
+  exit_codes  = [{{range $s := $item.ExitCodes}}'{{$s}}',{{end}}]{{end}}{{end}}
 {{end}}{{end}}
 
 ############################
@@ -620,8 +925,28 @@ version = {{.Version}}
 ## @months        - List of months. Currently not used. Allowed values: 1-12, 1 = January.
 ## @at_times      - List of tuples [hours,minutes,seconds] to schedule when frequency is 3, 4 or 5.
 ##                  Only seconds are used when frequency is 1, only minutes when frequency is 2.
+## @cron          - Standard cron expression, used instead of frequency and the settings above.
+##                  5 fields, 6 with seconds first, or @hourly, @daily, @weekly, @monthly, @every 1h30m.
+## @timezone      - Timezone for the cron expression, like "America/New_York". Default is local time.
 ## @header        - Map of header names to values sent with the http request to the URL.
 ## @query         - Map of query names to values appended to the url in the request.
+## @expect_status - List of allowed response status codes. Setting any expect option makes this a check;
+##                  every run succeeds or fails, and the website is told when the result changes.
+## @expect_body   - Regular expression the response body must match.
+## @expect_json   - List of JSON assertions: 'path==value', 'path!=value', 'path=~regexp', or a 'path' that
+##                  must exist. Paths use dots and array indexes, like 'data.items.0.status'.
+## @extract       - List of 'name=json.path' or 'name=~regexp' values sent to the website as variables.
+##                  A regexp sends its first capture group.
+## @render        - Render the url, body, header values and query values as Go templates on every run.
+##                  Functions: now, since, env "NAME", secret "/path/to/file", json and trim.
+##                  Data: .Name, .Snapshot, .Dashboard, .Services and .Variables from steps and the last extract.
+## @step          - Requests that run in order before the endpoint's request, like a login that returns a token.
+##                  Steps have their own name, url, method, body, header, query, expect and extract options,
+##                  and are always rendered as templates. Values they extract are in .Variables.
+##                  Cookies are kept between steps. A step fails on a request error, a failed expectation,
+##                  or a status of 400 or more when expect_status is not set.
+##   @when        - Template condition. The step is skipped if it renders empty, false or 0.
+##   @on_error    - "stop" (default) skips the remaining steps and the endpoint request. Or "continue".
 ##
 ## Full Example Follows (remove the leading # hashes to use it):
 ##
@@ -640,10 +965,24 @@ version = {{.Version}}
 #  days_of_month = [1]
 #  months        = [1]
 #  at_times      = [[0,0,0]]
+#  cron          = ''
+#  timezone      = ''
+#  expect_status = [200]
+#  expect_body   = '''"status":'''
+#  expect_json   = ['status==ok', 'database']
+#  extract       = ['version=app.version']
+#  render        = false
 #  [endpoint.header]
 #    x-api-key = ["abc123"]
 #  [endpoint.query]
 #    apiKey = ["abc123"]
+#  [[endpoint.step]]
+#    name     = "login"
+#    url      = "http://example.com/api/login"
+#    method   = "POST"
+#    body     = '''{"password":"{{"{{"}}secret "/run/secrets/example"{{"}}"}}"}'''
+#    on_error = "stop"
+#    extract  = ['token=token']
 
 {{if .Endpoints}}
 ## Configured Commands:
@@ -664,13 +1003,43 @@ version = {{.Version}}
   days_of_month = [{{range $s := $item.DaysOfMonth}}{{$s}},{{end}}]
   months        = [{{range $s := $item.Months}}{{$s}},{{end}}]
   at_times      = [{{range $s := $item.AtTimes}}[{{range $j := $s}}{{$j}},{{end}}],{{end}}]
+  {{- if $item.Cron}}
+  cron          = '{{$item.Cron}}'{{end}}{{if $item.Timezone}}
+  timezone      = '{{$item.Timezone}}'{{end}}
+  {{- if $item.ExpectStatus}}
+  expect_status = [{{range $s := $item.ExpectStatus}}{{$s}},{{end}}]{{end}}{{if $item.ExpectBody}}
+  expect_body   = '''{{$item.ExpectBody}}'''{{end}}{{if $item.ExpectJSON}}
+  expect_json   = [{{range $s := $item.ExpectJSON}}'''{{$s}}''',{{end}}]{{end}}{{if $item.Extract}}
+  extract       = [{{range $s := $item.Extract}}'''{{$s}}''',{{end}}]{{end}}{{if $item.Render}}
+  render        = true{{end}}
   {{- if $item.Query}}
   [endpoint.query]
   {{- range $query, $values := $item.Query}}
-    {{$query}} = [{{range $s := $values}}"{{$s}}",{{end}}]{{end}}{{end}}
+    {{$query}} = [{{range $s := $values}}'''{{$s}}''',{{end}}]{{end}}{{end}}
   {{- if $item.Header}}
   [endpoint.header]
   {{- range $header, $values := $item.Header}}
-    {{$header}} = [{{range $s := $values}}"{{$s}}",{{end}}]{{end}}{{end}}{{end}}
+    {{$header}} = [{{range $s := $values}}'''{{$s}}''',{{end}}]{{end}}{{end}}
+  {{- range $step := $item.Steps}}
+  [[endpoint.step]]
+    name     = "{{$step.Name}}"
+    url      = '''{{$step.URL}}'''
+    method   = "{{$step.Method}}"
+    body     = '''{{$step.Body}}'''
+    {{- if $step.When}}
+    when     = '''{{$step.When}}'''{{end}}{{if $step.OnError}}
+    on_error = "{{$step.OnError}}"{{end}}{{if $step.ExpectStatus}}
+    expect_status = [{{range $s := $step.ExpectStatus}}{{$s}},{{end}}]{{end}}{{if $step.ExpectBody}}
+    expect_body   = '''{{$step.ExpectBody}}'''{{end}}{{if $step.ExpectJSON}}
+    expect_json   = [{{range $s := $step.ExpectJSON}}'''{{$s}}''',{{end}}]{{end}}{{if $step.Extract}}
+    extract       = [{{range $s := $step.Extract}}'''{{$s}}''',{{end}}]{{end}}
+    {{- if $step.Query}}
+    [endpoint.step.query]
+    {{- range $query, $values := $step.Query}}
+      {{$query}} = [{{range $s := $values}}'''{{$s}}''',{{end}}]{{end}}{{end}}
+    {{- if $step.Header}}
+    [endpoint.step.header]
+    {{- range $header, $values := $step.Header}}
+      {{$header}} = [{{range $s := $values}}'''{{$s}}''',{{end}}]{{end}}{{end}}{{end}}{{end}}
 {{end}}{{end}}
 `
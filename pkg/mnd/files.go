@@ -0,0 +1,76 @@
+package mnd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// HardLinked returns true if any file in the path has more than one link.
+func HardLinked(path string) (bool, error) {
+	var linked bool
+
+	err := filepath.WalkDir(path, func(file string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || !entry.Type().IsRegular() {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return fmt.Errorf("reading file info: %w", err)
+		}
+
+		count, err := links(file, info)
+		if err != nil {
+			return fmt.Errorf("counting links for %s: %w", file, err)
+		}
+
+		if count > 1 {
+			linked = true
+			return filepath.SkipAll
+		}
+
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("walking %s: %w", path, err)
+	}
+
+	return linked, nil
+}
+
+// DiskUsage returns the total size of the regular files in a path, and when the newest one was modified.
+func DiskUsage(path string) (int64, time.Time, error) {
+	var (
+		size     int64
+		modified time.Time
+	)
+
+	err := filepath.WalkDir(path, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return fmt.Errorf("reading file info: %w", err)
+		}
+
+		if info.ModTime().After(modified) {
+			modified = info.ModTime()
+		}
+
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("walking %s: %w", path, err)
+	}
+
+	return size, modified, nil
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package mnd
+
+import (
+	"os"
+	"syscall"
+)
+
+// links returns the number of hard links to a file.
+func links(_ string, info os.FileInfo) (uint64, error) {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return uint64(stat.Nlink), nil //nolint:unconvert // not uint64 on every platform.
+	}
+
+	return 1, nil
+}
@@ -0,0 +1,24 @@
+package mnd
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// links returns the number of hard links to a file.
+func links(path string, _ os.FileInfo) (uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("opening file: %w", err)
+	}
+	defer file.Close()
+
+	var info windows.ByHandleFileInformation
+	if err := windows.GetFileInformationByHandle(windows.Handle(file.Fd()), &info); err != nil {
+		return 0, fmt.Errorf("reading file information: %w", err)
+	}
+
+	return uint64(info.NumberOfLinks), nil
+}
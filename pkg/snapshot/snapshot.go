@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common/scheduler"
 	"github.com/shirou/gopsutil/v4/cpu"
 	"github.com/shirou/gopsutil/v4/disk"
 	"github.com/shirou/gopsutil/v4/host"
@@ -52,6 +53,8 @@ type Config struct {
 	MyTop     int           `json:"myTop"         toml:"mytop"          xml:"mytop"`          // number of processes to include from mysql servers.
 	IPMI      bool          `json:"ipmi"          toml:"ipmi"           xml:"ipmi"`           // get ipmi sensor info.
 	IPMISudo  bool          `json:"ipmiSudo"      toml:"ipmiSudo"       xml:"ipmiSudo"`       // use sudo to get ipmi sensor info.
+	// CronExpr sends snapshots on a cron schedule instead of the interval.
+	scheduler.CronExpr
 	Plugins
 }
 
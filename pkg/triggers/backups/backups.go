@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Notifiarr/notifiarr/pkg/apps"
 	"github.com/Notifiarr/notifiarr/pkg/mnd"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
 	"github.com/Notifiarr/notifiarr/pkg/website"
@@ -15,133 +16,27 @@ import (
 
 // Backup initializes a backup check for all instances of the provided app.
 func (a *Action) Backup(input *common.ActionInput, app starr.App) error {
-	switch app { //nolint:exhaustive // We only check starr apps.
-	default:
-		return fmt.Errorf("%w: %s", common.ErrInvalidApp, app)
-	case "":
+	switch trigger, ok := triggers[app]; {
+	case app == "":
 		return fmt.Errorf("%w: <no app provided>", common.ErrInvalidApp)
-	case "All":
-		a.cmd.Exec(input, TrigLidarrBackup)
-		a.cmd.Exec(input, TrigProwlarrBackup)
-		a.cmd.Exec(input, TrigRadarrBackup)
-		a.cmd.Exec(input, TrigReadarrBackup)
-		a.cmd.Exec(input, TrigSonarrBackup)
-	case starr.Lidarr:
-		a.cmd.Exec(input, TrigLidarrBackup)
-	case starr.Prowlarr:
-		a.cmd.Exec(input, TrigProwlarrBackup)
-	case starr.Radarr:
-		a.cmd.Exec(input, TrigRadarrBackup)
-	case starr.Readarr:
-		a.cmd.Exec(input, TrigReadarrBackup)
-	case starr.Sonarr:
-		a.cmd.Exec(input, TrigSonarrBackup)
-	}
-
-	return nil
-}
-
-func (c *cmd) makeBackupTriggersLidarr(info *clientinfo.ClientInfo) {
-	action := &common.Action{
-		Name: TrigLidarrBackup,
-		Key:  "TrigLidarrBackup",
-		Fn:   c.sendLidarrBackups,
-		C:    make(chan *common.ActionInput, 1),
-	}
-	defer c.Add(action)
-
-	if info == nil {
-		return
-	}
-
-	for idx, app := range c.Apps.Lidarr {
-		if app.Enabled() && info.Actions.Apps.Lidarr.Backup(idx+1) != mnd.Disabled {
-			randomTime := time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Second +
-				time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Minute
-			action.D = cnfg.Duration{Duration: checkInterval + randomTime}
-
-			break
+	case app == "All":
+		for _, app := range apps.StarrApps() {
+			a.cmd.Exec(input, triggers[app].backup)
 		}
+	case !ok:
+		return fmt.Errorf("%w: %s", common.ErrInvalidApp, app)
+	default:
+		a.cmd.Exec(input, trigger.backup)
 	}
-}
-
-func (c *cmd) makeBackupTriggersRadarr(info *clientinfo.ClientInfo) {
-	action := &common.Action{
-		Name: TrigRadarrBackup,
-		Key:  "TrigRadarrBackup",
-		Fn:   c.sendRadarrBackups,
-		C:    make(chan *common.ActionInput, 1),
-	}
-	defer c.Add(action)
-
-	if info == nil {
-		return
-	}
-
-	for idx, app := range c.Apps.Radarr {
-		if app.Enabled() && info.Actions.Apps.Radarr.Backup(idx+1) != mnd.Disabled {
-			randomTime := time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Second +
-				time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Minute
-			action.D = cnfg.Duration{Duration: checkInterval + randomTime}
-
-			break
-		}
-	}
-}
-
-func (c *cmd) makeBackupTriggersReadarr(info *clientinfo.ClientInfo) {
-	action := &common.Action{
-		Name: TrigReadarrBackup,
-		Key:  "TrigReadarrBackup",
-		Fn:   c.sendReadarrBackups,
-		C:    make(chan *common.ActionInput, 1),
-	}
-	defer c.Add(action)
-
-	if info == nil {
-		return
-	}
-
-	for idx, app := range c.Apps.Readarr {
-		if app.Enabled() && info.Actions.Apps.Readarr.Backup(idx+1) != mnd.Disabled {
-			randomTime := time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Second +
-				time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Minute
-			action.D = cnfg.Duration{Duration: checkInterval + randomTime}
-
-			break
-		}
-	}
-}
-
-func (c *cmd) makeBackupTriggersSonarr(info *clientinfo.ClientInfo) {
-	action := &common.Action{
-		Name: TrigSonarrBackup,
-		Key:  "TrigSonarrBackup",
-		Fn:   c.sendSonarrBackups,
-		C:    make(chan *common.ActionInput, 1),
-	}
-	defer c.Add(action)
-
-	if info == nil {
-		return
-	}
-
-	for idx, app := range c.Apps.Sonarr {
-		if app.Enabled() && info.Actions.Apps.Sonarr.Backup(idx+1) != mnd.Disabled {
-			randomTime := time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Second +
-				time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Minute
-			action.D = cnfg.Duration{Duration: checkInterval + randomTime}
 
-			break
-		}
-	}
+	return nil
 }
 
-func (c *cmd) makeBackupTriggersProwlarr(info *clientinfo.ClientInfo) {
+func (c *cmd) makeBackupTrigger(info *clientinfo.ClientInfo, app starr.App) {
 	action := &common.Action{
-		Name: TrigProwlarrBackup,
-		Key:  "TrigProwlarrBackup",
-		Fn:   c.sendProwlarrBackups,
+		Name: triggers[app].backup,
+		Key:  "Trig" + app.String() + "Backup",
+		Fn:   func(ctx context.Context, input *common.ActionInput) { c.sendAppBackups(ctx, input, app) },
 		C:    make(chan *common.ActionInput, 1),
 	}
 	defer c.Add(action)
@@ -150,8 +45,8 @@ func (c *cmd) makeBackupTriggersProwlarr(info *clientinfo.ClientInfo) {
 		return
 	}
 
-	for idx, app := range c.Apps.Prowlarr {
-		if app.Enabled() && info.Actions.Apps.Prowlarr.Backup(idx+1) != mnd.Disabled {
+	for _, instance := range c.Apps.StarrInstances(app) {
+		if instance.Enabled() && info.Actions.Apps.Get(app).Backup(instance.Instance()) != mnd.Disabled {
 			randomTime := time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Second +
 				time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Minute
 			action.D = cnfg.Duration{Duration: checkInterval + randomTime}
@@ -161,82 +56,11 @@ func (c *cmd) makeBackupTriggersProwlarr(info *clientinfo.ClientInfo) {
 	}
 }
 
-func (c *cmd) sendLidarrBackups(ctx context.Context, input *common.ActionInput) {
-	for idx, app := range c.Apps.Lidarr {
-		if ci := clientinfo.Get(); input.Type != website.EventCron ||
-			(ci != nil && ci.Actions.Apps.Lidarr.Backup(idx+1) != mnd.Disabled) {
-			c.sendBackups(ctx, &genericInstance{
-				event: input.Type,
-				name:  starr.Lidarr,
-				int:   idx + 1,
-				app:   app.Lidarr,
-				cName: app.Name,
-				skip:  !app.Enabled(),
-			})
-		}
-	}
-}
-
-func (c *cmd) sendProwlarrBackups(ctx context.Context, input *common.ActionInput) {
-	for idx, app := range c.Apps.Prowlarr {
-		if ci := clientinfo.Get(); input.Type != website.EventCron ||
-			(ci != nil && ci.Actions.Apps.Prowlarr.Backup(idx+1) != mnd.Disabled) {
-			c.sendBackups(ctx, &genericInstance{
-				event: input.Type,
-				name:  starr.Prowlarr,
-				int:   idx + 1,
-				app:   app.Prowlarr,
-				cName: app.Name,
-				skip:  !app.Enabled(),
-			})
-		}
-	}
-}
-
-func (c *cmd) sendRadarrBackups(ctx context.Context, input *common.ActionInput) {
-	for idx, app := range c.Apps.Radarr {
-		if ci := clientinfo.Get(); input.Type != website.EventCron ||
-			(ci != nil && ci.Actions.Apps.Radarr.Backup(idx+1) != mnd.Disabled) {
-			c.sendBackups(ctx, &genericInstance{
-				event: input.Type,
-				name:  starr.Radarr,
-				int:   idx + 1,
-				app:   app.Radarr,
-				cName: app.Name,
-				skip:  !app.Enabled(),
-			})
-		}
-	}
-}
-
-func (c *cmd) sendReadarrBackups(ctx context.Context, input *common.ActionInput) {
-	for idx, app := range c.Apps.Readarr {
-		if ci := clientinfo.Get(); input.Type != website.EventCron ||
-			(ci != nil && ci.Actions.Apps.Readarr.Backup(idx+1) != mnd.Disabled) {
-			c.sendBackups(ctx, &genericInstance{
-				event: input.Type,
-				name:  starr.Readarr,
-				int:   idx + 1,
-				app:   app.Readarr,
-				cName: app.Name,
-				skip:  !app.Enabled(),
-			})
-		}
-	}
-}
-
-func (c *cmd) sendSonarrBackups(ctx context.Context, input *common.ActionInput) {
-	for idx, app := range c.Apps.Sonarr {
+func (c *cmd) sendAppBackups(ctx context.Context, input *common.ActionInput, app starr.App) {
+	for _, instance := range c.Apps.StarrInstances(app) {
 		if ci := clientinfo.Get(); input.Type != website.EventCron ||
-			(ci != nil && ci.Actions.Apps.Sonarr.Backup(idx+1) != mnd.Disabled) {
-			c.sendBackups(ctx, &genericInstance{
-				event: input.Type,
-				name:  starr.Sonarr,
-				cName: app.Name,
-				int:   idx + 1,
-				app:   app.Sonarr,
-				skip:  !app.Enabled(),
-			})
+			(ci != nil && ci.Actions.Apps.Get(app).Backup(instance.Instance()) != mnd.Disabled) {
+			c.sendBackups(ctx, newInstance(input.Type, instance, ""))
 		}
 	}
 }
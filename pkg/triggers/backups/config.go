@@ -1,10 +1,11 @@
 package backups
 
 import (
-	"context"
 	"errors"
+	"sync"
 	"time"
 
+	"github.com/Notifiarr/notifiarr/pkg/apps"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
 	"github.com/Notifiarr/notifiarr/pkg/website"
 	"github.com/Notifiarr/notifiarr/pkg/website/clientinfo"
@@ -18,11 +19,8 @@ type Action struct {
 
 type cmd struct {
 	*common.Config
-	lidarr   map[int]string
-	prowlarr map[int]string
-	radarr   map[int]string
-	readarr  map[int]string
-	sonarr   map[int]string
+	mu   sync.Mutex
+	last map[starr.App]map[int]string // the last checked corruption file, per instance.
 }
 
 // Errors returned by this package.
@@ -50,6 +48,23 @@ const (
 	TrigSonarrBackup    common.TriggerName = "Sending Sonarr Backup File List to Notifiarr."
 )
 
+// appTriggers holds the trigger names for a single starr app.
+type appTriggers struct {
+	backup  common.TriggerName
+	corrupt common.TriggerName
+}
+
+// triggers maps each starr app to its trigger names.
+//
+//nolint:gochecknoglobals
+var triggers = map[starr.App]appTriggers{
+	starr.Lidarr:   {backup: TrigLidarrBackup, corrupt: TrigLidarrCorrupt},
+	starr.Prowlarr: {backup: TrigProwlarrBackup, corrupt: TrigProwlarrCorrupt},
+	starr.Radarr:   {backup: TrigRadarrBackup, corrupt: TrigRadarrCorrupt},
+	starr.Readarr:  {backup: TrigReadarrBackup, corrupt: TrigReadarrCorrupt},
+	starr.Sonarr:   {backup: TrigSonarrBackup, corrupt: TrigSonarrCorrupt},
+}
+
 // Info contains a pile of information about a Starr database (backup).
 // This is the data sent to notifiarr.com.
 type Info struct {
@@ -71,14 +86,23 @@ type Info struct {
 type genericInstance struct {
 	skip  bool
 	event website.EventType
-	last  string      // app.Corrupt
-	name  starr.App   // Lidarr, Radarr, ..
-	cName string      // configured app name
-	int   int         // instance ID: 1, 2, 3...
-	app   interface { // all starr apps satisfy this interface. yay!
-		GetBackupFiles() ([]*starr.BackupFile, error)
-		GetBackupFilesContext(ctx context.Context) ([]*starr.BackupFile, error)
-		starr.APIer
+	last  string           // app.Corrupt
+	name  starr.App        // Lidarr, Radarr, ..
+	cName string           // configured app name
+	int   int              // instance ID: 1, 2, 3...
+	app   apps.StarrClient // all starr apps satisfy this interface. yay!
+}
+
+// newInstance converts a starr instance into our generic instance.
+func newInstance(event website.EventType, instance apps.StarrInstance, last string) *genericInstance {
+	return &genericInstance{
+		event: event,
+		last:  last,
+		name:  instance.App(),
+		int:   instance.Instance(),
+		app:   instance.StarrClient(),
+		cName: instance.Starr().Name,
+		skip:  !instance.Enabled(),
 	}
 }
 
@@ -92,27 +116,36 @@ type Payload struct {
 
 // New configures the library.
 func New(config *common.Config) *Action {
-	return &Action{cmd: &cmd{
-		Config:   config,
-		lidarr:   make(map[int]string),
-		prowlarr: make(map[int]string),
-		radarr:   make(map[int]string),
-		readarr:  make(map[int]string),
-		sonarr:   make(map[int]string),
-	}}
+	last := make(map[starr.App]map[int]string)
+	for _, app := range apps.StarrApps() {
+		last[app] = make(map[int]string)
+	}
+
+	return &Action{cmd: &cmd{Config: config, last: last}}
 }
 
 // Create sets up all the triggers.
 func (a *Action) Create() {
 	info := clientinfo.Get()
-	a.cmd.makeBackupTriggersLidarr(info)
-	a.cmd.makeBackupTriggersRadarr(info)
-	a.cmd.makeBackupTriggersReadarr(info)
-	a.cmd.makeBackupTriggersSonarr(info)
-	a.cmd.makeBackupTriggersProwlarr(info)
-	a.cmd.makeCorruptionTriggersLidarr(info)
-	a.cmd.makeCorruptionTriggersRadarr(info)
-	a.cmd.makeCorruptionTriggersReadarr(info)
-	a.cmd.makeCorruptionTriggersSonarr(info)
-	a.cmd.makeCorruptionTriggersProwlarr(info)
+
+	for _, app := range apps.StarrApps() {
+		a.cmd.makeBackupTrigger(info, app)
+		a.cmd.makeCorruptionTrigger(info, app)
+	}
+}
+
+// getLast returns the last backup file checked for corruption on an instance.
+func (c *cmd) getLast(app starr.App, idx int) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.last[app][idx]
+}
+
+// setLast saves the last backup file checked for corruption on an instance.
+func (c *cmd) setLast(app starr.App, idx int, last string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.last[app][idx] = last
 }
@@ -11,6 +11,7 @@ import (
 	"path"
 	"time"
 
+	"github.com/Notifiarr/notifiarr/pkg/apps"
 	"github.com/Notifiarr/notifiarr/pkg/logs"
 	"github.com/Notifiarr/notifiarr/pkg/mnd"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
@@ -26,112 +27,27 @@ func (a *Action) Corruption(input *common.ActionInput, app starr.App) error {
 	logs.Log.Trace(input.ReqID, "start: Corruption", input.Type, app)
 	defer logs.Log.Trace(input.ReqID, "end: Corruption", input.Type, app)
 
-	switch app { //nolint:exhaustive // We only check starr apps.
-	default:
-		return fmt.Errorf("%w: %s", common.ErrInvalidApp, app)
-	case "":
+	switch trigger, ok := triggers[app]; {
+	case app == "":
 		return fmt.Errorf("%w: <no app provided>", common.ErrInvalidApp)
-	case "All":
-		a.cmd.Exec(input, TrigLidarrCorrupt)
-		a.cmd.Exec(input, TrigProwlarrCorrupt)
-		a.cmd.Exec(input, TrigRadarrCorrupt)
-		a.cmd.Exec(input, TrigReadarrCorrupt)
-		a.cmd.Exec(input, TrigSonarrCorrupt)
-	case starr.Lidarr:
-		a.cmd.Exec(input, TrigLidarrCorrupt)
-	case starr.Prowlarr:
-		a.cmd.Exec(input, TrigProwlarrCorrupt)
-	case starr.Radarr:
-		a.cmd.Exec(input, TrigRadarrCorrupt)
-	case starr.Readarr:
-		a.cmd.Exec(input, TrigReadarrCorrupt)
-	case starr.Sonarr:
-		a.cmd.Exec(input, TrigSonarrCorrupt)
-	}
-
-	return nil
-}
-
-func (c *cmd) makeCorruptionTriggersLidarr(info *clientinfo.ClientInfo) {
-	action := &common.Action{
-		Name: TrigLidarrCorrupt,
-		Key:  "TrigLidarrCorrupt",
-		Fn:   c.sendLidarrCorruption,
-		C:    make(chan *common.ActionInput, 1),
-	}
-	defer c.Add(action)
-
-	if info == nil {
-		return
-	}
-
-	for idx, app := range c.Apps.Lidarr {
-		if app.Enabled() {
-			c.lidarr[idx] = info.Actions.Apps.Lidarr.Corrupt(idx + 1) // mandatory
-			if c.lidarr[idx] != mnd.Disabled {
-				randomTime := time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Second +
-					time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Minute
-				action.D = cnfg.Duration{Duration: checkInterval + randomTime}
-			}
-		}
-	}
-}
-
-func (c *cmd) makeCorruptionTriggersProwlarr(info *clientinfo.ClientInfo) {
-	action := &common.Action{
-		Name: TrigProwlarrCorrupt,
-		Key:  "TrigProwlarrCorrupt",
-		Fn:   c.sendProwlarrCorruption,
-		C:    make(chan *common.ActionInput, 1),
-	}
-	defer c.Add(action)
-
-	if info == nil {
-		return
-	}
-
-	for idx, app := range c.Apps.Prowlarr {
-		if app.Enabled() {
-			c.prowlarr[idx] = info.Actions.Apps.Prowlarr.Corrupt(idx + 1) // mandatory
-			if c.prowlarr[idx] != mnd.Disabled {
-				randomTime := time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Second +
-					time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Minute
-				action.D = cnfg.Duration{Duration: checkInterval + randomTime}
-			}
+	case app == "All":
+		for _, app := range apps.StarrApps() {
+			a.cmd.Exec(input, triggers[app].corrupt)
 		}
-	}
-}
-
-func (c *cmd) makeCorruptionTriggersRadarr(info *clientinfo.ClientInfo) {
-	action := &common.Action{
-		Name: TrigRadarrCorrupt,
-		Key:  "TrigRadarrCorrupt",
-		Fn:   c.sendRadarrCorruption,
-		C:    make(chan *common.ActionInput, 1),
-	}
-	defer c.Add(action)
-
-	if info == nil {
-		return
+	case !ok:
+		return fmt.Errorf("%w: %s", common.ErrInvalidApp, app)
+	default:
+		a.cmd.Exec(input, trigger.corrupt)
 	}
 
-	for idx, app := range c.Apps.Radarr {
-		if app.Enabled() {
-			c.radarr[idx] = info.Actions.Apps.Radarr.Corrupt(idx + 1) // mandatory
-			if c.radarr[idx] != mnd.Disabled {
-				randomTime := time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Second +
-					time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Minute
-				action.D = cnfg.Duration{Duration: checkInterval + randomTime}
-			}
-		}
-	}
+	return nil
 }
 
-func (c *cmd) makeCorruptionTriggersReadarr(info *clientinfo.ClientInfo) {
+func (c *cmd) makeCorruptionTrigger(info *clientinfo.ClientInfo, app starr.App) {
 	action := &common.Action{
-		Name: TrigReadarrCorrupt,
-		Key:  "TrigReadarrCorrupt",
-		Fn:   c.sendReadarrCorruption,
+		Name: triggers[app].corrupt,
+		Key:  "Trig" + app.String() + "Corrupt",
+		Fn:   func(ctx context.Context, input *common.ActionInput) { c.sendAppCorruption(ctx, input, app) },
 		C:    make(chan *common.ActionInput, 1),
 	}
 	defer c.Add(action)
@@ -140,35 +56,12 @@ func (c *cmd) makeCorruptionTriggersReadarr(info *clientinfo.ClientInfo) {
 		return
 	}
 
-	for idx, app := range c.Apps.Readarr {
-		if app.Enabled() {
-			c.readarr[idx] = info.Actions.Apps.Readarr.Corrupt(idx + 1) // mandatory
-			if c.readarr[idx] != mnd.Disabled {
-				randomTime := time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Second +
-					time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Minute
-				action.D = cnfg.Duration{Duration: checkInterval + randomTime}
-			}
-		}
-	}
-}
-
-func (c *cmd) makeCorruptionTriggersSonarr(info *clientinfo.ClientInfo) {
-	action := &common.Action{
-		Name: TrigSonarrCorrupt,
-		Key:  "TrigSonarrCorrupt",
-		Fn:   c.sendSonarrCorruption,
-		C:    make(chan *common.ActionInput, 1),
-	}
-	defer c.Add(action)
+	for _, instance := range c.Apps.StarrInstances(app) {
+		if instance.Enabled() {
+			last := info.Actions.Apps.Get(app).Corrupt(instance.Instance()) // mandatory
+			c.setLast(app, instance.Index(), last)
 
-	if info == nil {
-		return
-	}
-
-	for idx, app := range c.Apps.Sonarr {
-		if app.Enabled() {
-			c.sonarr[idx] = info.Actions.Apps.Sonarr.Corrupt(idx + 1)
-			if c.sonarr[idx] != mnd.Disabled {
+			if last != mnd.Disabled {
 				randomTime := time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Second +
 					time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Minute
 				action.D = cnfg.Duration{Duration: checkInterval + randomTime}
@@ -177,73 +70,10 @@ func (c *cmd) makeCorruptionTriggersSonarr(info *clientinfo.ClientInfo) {
 	}
 }
 
-func (c *cmd) sendLidarrCorruption(ctx context.Context, input *common.ActionInput) {
-	for idx, app := range c.Apps.Lidarr {
-		c.lidarr[idx] = c.sendAndLogAppCorruption(ctx, &genericInstance{
-			event: input.Type,
-			last:  c.lidarr[idx],
-			name:  starr.Lidarr,
-			int:   idx + 1,
-			app:   app.Lidarr,
-			cName: app.Name,
-			skip:  !app.Enabled(),
-		})
-	}
-}
-
-func (c *cmd) sendProwlarrCorruption(ctx context.Context, input *common.ActionInput) {
-	for idx, app := range c.Apps.Prowlarr {
-		c.prowlarr[idx] = c.sendAndLogAppCorruption(ctx, &genericInstance{
-			event: input.Type,
-			last:  c.prowlarr[idx],
-			name:  starr.Prowlarr,
-			int:   idx + 1,
-			app:   app.Prowlarr,
-			cName: app.Name,
-			skip:  !app.Enabled(),
-		})
-	}
-}
-
-func (c *cmd) sendRadarrCorruption(ctx context.Context, input *common.ActionInput) {
-	for idx, app := range c.Apps.Radarr {
-		c.radarr[idx] = c.sendAndLogAppCorruption(ctx, &genericInstance{
-			event: input.Type,
-			last:  c.radarr[idx],
-			name:  starr.Radarr,
-			int:   idx + 1,
-			app:   app.Radarr,
-			cName: app.Name,
-			skip:  !app.Enabled(),
-		})
-	}
-}
-
-func (c *cmd) sendReadarrCorruption(ctx context.Context, input *common.ActionInput) {
-	for idx, app := range c.Apps.Readarr {
-		c.readarr[idx] = c.sendAndLogAppCorruption(ctx, &genericInstance{
-			event: input.Type,
-			last:  c.readarr[idx],
-			name:  starr.Readarr,
-			int:   idx + 1,
-			app:   app.Readarr,
-			cName: app.Name,
-			skip:  !app.Enabled(),
-		})
-	}
-}
-
-func (c *cmd) sendSonarrCorruption(ctx context.Context, input *common.ActionInput) {
-	for idx, app := range c.Apps.Sonarr {
-		c.sonarr[idx] = c.sendAndLogAppCorruption(ctx, &genericInstance{
-			event: input.Type,
-			last:  c.sonarr[idx],
-			name:  starr.Sonarr,
-			int:   idx + 1,
-			app:   app.Sonarr,
-			cName: app.Name,
-			skip:  !app.Enabled(),
-		})
+func (c *cmd) sendAppCorruption(ctx context.Context, input *common.ActionInput, app starr.App) {
+	for _, instance := range c.Apps.StarrInstances(app) {
+		last := c.sendAndLogAppCorruption(ctx, newInstance(input.Type, instance, c.getLast(app, instance.Index())))
+		c.setLast(app, instance.Index(), last)
 	}
 }
 
@@ -1,6 +1,7 @@
 package cfsync
 
 import (
+	"context"
 	"strings"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
 	"github.com/Notifiarr/notifiarr/pkg/website/clientinfo"
 	"golift.io/cnfg"
+	"golift.io/starr"
 )
 
 /* CF Sync means Custom Format Sync. This is a premium feature that allows syncing
@@ -46,9 +48,7 @@ func (a *Action) Create() {
 
 func (c *cmd) create(reqID string) {
 	info := clientinfo.Get()
-	c.setupRadarr(info)
-	c.setupSonarr(info)
-	c.setupLidarr(info)
+	c.setupInstances(info)
 
 	// Check each instance and enable only if needed.
 	if info != nil && info.Actions.Sync.Interval.Duration > 0 {
@@ -93,84 +93,31 @@ type lidarrApp struct {
 	idx int
 }
 
-func (c *cmd) setupLidarr(info *clientinfo.ClientInfo) {
-	if info == nil {
-		return
-	}
-
-	for idx, app := range c.Apps.Lidarr {
-		instance := idx + 1
-		if !app.Enabled() || !info.Actions.Sync.LidarrInstances.Has(instance) {
-			continue
-		}
-
-		var dur cnfg.Duration
-
-		if info.Actions.Sync.Interval.Duration > 0 {
-			randomTime := time.Duration(c.Config.Rand().Intn(randomMilliseconds)) * time.Millisecond
-			dur = cnfg.Duration{Duration: info.Actions.Sync.Interval.Duration + randomTime}
-		}
-
-		c.Add(&common.Action{
-			Key:  "TrigCFSyncLidarrInt",
-			Hide: true,
-			D:    dur,
-			Name: TrigCFSyncLidarrInt.WithInstance(instance),
-			Fn:   (&lidarrApp{app: &app, cmd: c, idx: idx}).syncLidarr,
-			C:    make(chan *common.ActionInput, 1),
-		})
-	}
-}
-
 type radarrApp struct {
 	app *apps.Radarr
 	cmd *cmd
 	idx int
 }
 
-func (c *cmd) setupRadarr(info *clientinfo.ClientInfo) {
-	if info == nil {
-		return
-	}
-
-	for idx, app := range c.Apps.Radarr {
-		instance := idx + 1
-		if !app.Enabled() || !info.Actions.Sync.RadarrInstances.Has(instance) {
-			continue
-		}
-
-		var dur cnfg.Duration
-
-		if info.Actions.Sync.Interval.Duration > 0 {
-			randomTime := time.Duration(c.Config.Rand().Intn(randomMilliseconds)) * time.Millisecond
-			dur = cnfg.Duration{Duration: info.Actions.Sync.Interval.Duration + randomTime}
-		}
-
-		c.Add(&common.Action{
-			Key:  "TrigCFSyncRadarrInt",
-			Hide: true,
-			D:    dur,
-			Name: TrigCFSyncRadarrInt.WithInstance(instance),
-			Fn:   (&radarrApp{app: &app, cmd: c, idx: idx}).syncRadarr,
-			C:    make(chan *common.ActionInput, 1),
-		})
-	}
-}
-
 type sonarrApp struct {
 	app *apps.Sonarr
 	cmd *cmd
 	idx int
 }
 
-func (c *cmd) setupSonarr(info *clientinfo.ClientInfo) {
+// setupInstances adds a sync timer for each starr instance the website has sync enabled for.
+func (c *cmd) setupInstances(info *clientinfo.ClientInfo) {
 	if info == nil {
 		return
 	}
 
-	for idx, app := range c.Apps.Sonarr {
-		instance := idx + 1
-		if !app.Enabled() || !info.Actions.Sync.SonarrInstances.Has(instance) {
+	for _, instance := range c.Apps.StarrInstances(starr.Lidarr, starr.Radarr, starr.Sonarr) {
+		if !instance.Enabled() || !info.Actions.Sync.Instances(instance.App()).Has(instance.Instance()) {
+			continue
+		}
+
+		name, sync := c.syncer(instance)
+		if sync == nil {
 			continue
 		}
 
@@ -182,12 +129,26 @@ func (c *cmd) setupSonarr(info *clientinfo.ClientInfo) {
 		}
 
 		c.Add(&common.Action{
-			Key:  "TrigCFSyncSonarrInt",
+			Key:  "TrigCFSync" + instance.App().String() + "Int",
 			Hide: true,
 			D:    dur,
-			Name: TrigCFSyncSonarrInt.WithInstance(instance),
-			Fn:   (&sonarrApp{app: &app, cmd: c, idx: idx}).syncSonarr,
+			Name: name.WithInstance(instance.Instance()),
+			Fn:   sync,
 			C:    make(chan *common.ActionInput, 1),
 		})
 	}
 }
+
+// syncer returns the trigger name and sync procedure for a starr instance.
+func (c *cmd) syncer(instance apps.StarrInstance) (common.TriggerName, func(context.Context, *common.ActionInput)) {
+	switch app := instance.(type) {
+	case apps.Lidarr:
+		return TrigCFSyncLidarrInt, (&lidarrApp{app: &app, cmd: c, idx: app.Index()}).syncLidarr
+	case apps.Radarr:
+		return TrigCFSyncRadarrInt, (&radarrApp{app: &app, cmd: c, idx: app.Index()}).syncRadarr
+	case apps.Sonarr:
+		return TrigCFSyncSonarrInt, (&sonarrApp{app: &app, cmd: c, idx: app.Index()}).syncSonarr
+	default:
+		return "", nil
+	}
+}
@@ -2,7 +2,11 @@
 // This is in its own package to avoid an import cycle with the clientinfo package.
 package cmdconfig
 
-import "golift.io/cnfg"
+import (
+	"strings"
+
+	"golift.io/cnfg"
+)
 
 type Config struct {
 	Name    string        `json:"name"              toml:"name"    xml:"name"    yaml:"name"`
@@ -12,7 +16,46 @@ type Config struct {
 	Log     bool          `json:"log"               toml:"log"     xml:"log"     yaml:"log"`
 	Notify  bool          `json:"notify"            toml:"notify"  xml:"notify"  yaml:"notify"`
 	Timeout cnfg.Duration `json:"timeout"           toml:"timeout" xml:"timeout" yaml:"timeout"`
+	// Env is a list of NAME=value environment variables added to the command's environment.
+	Env []string `json:"env,omitempty" toml:"env" xml:"env" yaml:"env"`
+	// Secrets is a list of NAME=/path/to/file. The file's contents become the NAME environment variable.
+	// The files are read every time the command runs.
+	Secrets []string `json:"secrets,omitempty" toml:"secrets" xml:"secrets" yaml:"secrets"`
+	// Dir is the working directory. The default is this app's working directory.
+	Dir string `json:"dir,omitempty" toml:"dir" xml:"dir" yaml:"dir"`
+	// RunAs is a user name or uid to run the command as. This app must run as root to use it. Not on Windows.
+	RunAs string `json:"runAs,omitempty" toml:"run_as" xml:"run_as" yaml:"runAs"`
+	// Stdin is written to the command's standard input.
+	Stdin string `json:"stdin,omitempty" toml:"stdin" xml:"stdin" yaml:"stdin"`
+	// ExitCodes is a list of code=state, like 1=warning. A mapped exit code is not an error; its state is reported.
+	ExitCodes []string `json:"exitCodes,omitempty" toml:"exit_codes" xml:"exit_codes" yaml:"exitCodes"`
+	// JSON parses the command's standard output as JSON, and sends it to the website as structured data.
+	JSON bool `json:"json" toml:"json" xml:"json" yaml:"json"`
+	// Concurrency is what happens when the command is triggered while it's running: queue, parallel, reject or kill.
+	Concurrency string `json:"concurrency,omitempty" toml:"concurrency" xml:"concurrency" yaml:"concurrency"`
+	// History is how many runs to keep in the history. Default is 20, -1 disables the history.
+	History int `json:"history" toml:"history" xml:"history" yaml:"history"`
+	// HistoryAge is how long to keep runs in the history. Default is 30 days.
+	HistoryAge cnfg.Duration `json:"historyAge" toml:"history_age" xml:"history_age" yaml:"historyAge"`
 	// Args and ArgValues are not config items. They are calculated on startup.
 	Args      int      `json:"args"      toml:"-" xml:"-" yaml:"-"`
 	ArgValues []string `json:"argValues" toml:"-" xml:"-" yaml:"-"`
 }
+
+// Redacted returns a copy of the config for the website without values that may contain secrets:
+// Env values and Stdin. Env names are kept. Secrets are file paths, and are kept.
+func (c *Config) Redacted() *Config {
+	redacted := *c
+	redacted.Env = make([]string, len(c.Env))
+
+	for idx, env := range c.Env {
+		name, _, _ := strings.Cut(env, "=")
+		redacted.Env[idx] = name + "=<redacted>"
+	}
+
+	if c.Stdin != "" {
+		redacted.Stdin = "<redacted>"
+	}
+
+	return &redacted
+}
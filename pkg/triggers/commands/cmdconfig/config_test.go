@@ -0,0 +1,26 @@
+package cmdconfig //nolint:testpackage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedacted(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{
+		Name:    "backup",
+		Env:     []string{"TOKEN=s3cret", "EMPTY"},
+		Secrets: []string{"KEY=/run/secrets/key"},
+		Stdin:   "password",
+	}
+	redacted := config.Redacted()
+
+	assert.Equal(t, []string{"TOKEN=<redacted>", "EMPTY=<redacted>"}, redacted.Env)
+	assert.Equal(t, "<redacted>", redacted.Stdin)
+	assert.Equal(t, config.Secrets, redacted.Secrets, "secret file paths are not secret")
+	assert.Equal(t, "backup", redacted.Name)
+	assert.Equal(t, "s3cret", config.Env[0][len("TOKEN="):], "the config is not changed")
+	assert.Empty(t, (&Config{}).Redacted().Stdin)
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
@@ -16,6 +17,7 @@ import (
 	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
 	"github.com/Notifiarr/notifiarr/pkg/website"
 	"github.com/hugelgupf/go-shlex"
+	"golift.io/cnfg"
 )
 
 var ErrDisabled = errors.New("the command is disabled due to an error")
@@ -47,6 +49,16 @@ func (c *Command) Setup() {
 		c.disable = true //nolint:wsl
 	}
 
+	if err := c.setupExitCodes(); err != nil {
+		mnd.Log.Errorf(reqID, "Command Setup Failed: %v", err)
+		c.disable = true //nolint:wsl
+	}
+
+	if err := c.setupConcurrency(); err != nil {
+		mnd.Log.Errorf(reqID, "Command Setup Failed: %v", err)
+		c.disable = true //nolint:wsl
+	}
+
 	c.Args = len(c.args)
 
 	c.ArgValues = make([]string, c.Args)
@@ -92,8 +104,11 @@ func (c *Command) SetupRegexpArgs() error {
 }
 
 // run executes this command and logs the output. This is executed from the trigger channel.
+// The command runs in its own go routine, so the concurrency policy decides when it runs.
 func (c *Command) run(ctx context.Context, input *common.ActionInput) {
-	_, _ = c.RunNow(ctx, input)
+	go func() {
+		_, _ = c.RunNow(ctx, input)
+	}()
 }
 
 // RunNow runs the command immediately, waits for and returns the output.
@@ -106,36 +121,88 @@ func (c *Command) RunNow(ctx context.Context, input *common.ActionInput) (string
 		return "<command disabled>", ErrDisabled
 	}
 
-	output, elapsed, err := c.exec(ctx, input)
-	oStr := output.String()
-	eStr := ""
+	ctx, done, err := c.start(ctx)
+	if err != nil {
+		c.reject(input, err)
+		return "", err
+	}
+	defer done()
+
+	start := time.Now()
+	res, err := c.exec(ctx, input)
+	code, state, err := c.state(err)
+
+	if errors.Is(context.Cause(ctx), ErrKilled) {
+		state = StateKilled
+	}
+	oStr := res.output.String()
+	payload := map[string]any{"name": c.Name, "hash": c.Hash, "exitCode": code}
+
+	if c.JSON {
+		var data any
+		if jErr := json.Unmarshal(res.stdout.Bytes(), &data); jErr != nil && err == nil {
+			state, err = StateFailed, fmt.Errorf("parsing output as JSON: %w", jErr)
+		}
+
+		// Only stderr is sent as output; stdout is sent as data.
+		payload["output"], payload["data"] = oStr, data
+		oStr = res.stdout.String() + oStr
+	} else {
+		payload["output"] = oStr
+	}
 
+	eStr := ""
 	if err != nil {
 		eStr = err.Error()
 	}
 
+	payload["error"], payload["state"] = eStr, state
+
 	// Send the notification before the lock.
 	if c.Notify {
 		website.SendData(&website.Request{
-			ReqID: mnd.GetID(ctx),
-			Route: website.CommandRoute,
-			Event: input.Type,
-			Payload: map[string]string{
-				"name":   c.Name,
-				"hash":   c.Hash,
-				"output": oStr,
-				"error":  eStr,
-			},
-			LogMsg:     fmt.Sprintf("Custom Command '%s' Output (elapsed: %s)", c.Name, elapsed.Round(time.Millisecond)),
+			ReqID:      mnd.GetID(ctx),
+			Route:      website.CommandRoute,
+			Event:      input.Type,
+			Payload:    payload,
+			LogMsg:     fmt.Sprintf("Custom Command '%s' Output (elapsed: %s)", c.Name, res.elapsed.Round(time.Millisecond)),
 			LogPayload: c.Log,
 		})
 	}
 
-	c.logOutput(input, oStr, eStr, elapsed, err)
+	c.mu.Lock()
+	c.lastCode, c.lastState = code, state
+	c.mu.Unlock()
+	c.logOutput(input, oStr, eStr, res.elapsed, err)
+	c.history.add(c, &Run{
+		Event:    input.Type,
+		ReqID:    input.ReqID,
+		Args:     input.Args,
+		Start:    start,
+		Elapsed:  cnfg.Duration{Duration: res.elapsed},
+		ExitCode: code,
+		State:    state,
+		Error:    eStr,
+		Output:   oStr,
+	})
 
 	return oStr, err
 }
 
+// reject logs and saves a run that the concurrency policy did not allow.
+func (c *Command) reject(input *common.ActionInput, err error) {
+	mnd.Log.Errorf(input.ReqID, "[%s requested] Custom Command '%s' Rejected: %v", input.Type, c.Name, err)
+	c.history.add(c, &Run{
+		Event:    input.Type,
+		ReqID:    input.ReqID,
+		Args:     input.Args,
+		Start:    time.Now(),
+		ExitCode: -1,
+		State:    StateRejected,
+		Error:    err.Error(),
+	})
+}
+
 func (c *Command) logOutput(input *common.ActionInput, oStr, eStr string, elapsed time.Duration, err error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -170,35 +237,56 @@ func (c *Command) logOutput(input *common.ActionInput, oStr, eStr string, elapse
 	}
 }
 
-// run read locks and runs the command then returns the output.
-func (c *Command) exec(ctx context.Context, input *common.ActionInput) (*bytes.Buffer, time.Duration, error) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+// execResult is the output from running a command.
+type execResult struct {
+	output  bytes.Buffer // stdout and stderr, or only stderr when parsing JSON.
+	stdout  bytes.Buffer // only used when parsing JSON.
+	elapsed time.Duration
+}
 
+// exec read locks and runs the command then returns the output.
+func (c *Command) exec(ctx context.Context, input *common.ActionInput) (*execResult, error) {
 	ctx, cancel := context.WithTimeout(ctx, c.Timeout.Duration)
 	defer cancel()
 
+	c.mu.RLock()
 	builder := &cmdBuilder{
 		cmd:          c.cmd,
 		expectedArgs: c.args,
 		providedArgs: input.Args,
 		shell:        c.Shell,
 	}
+	c.mu.RUnlock()
+
+	res := &execResult{}
 
 	args, cmd, err := builder.getCmd(ctx)
 	if err != nil {
-		return nil, 0, err
+		return res, err
+	}
+
+	if err := c.prepare(cmd); err != nil {
+		return res, err
 	}
 
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	cmd.Stderr = &out
+	cmd.Stdout = &res.output
+	cmd.Stderr = &res.output
+
+	if c.JSON {
+		cmd.Stdout = &res.stdout
+	}
+
+	c.mu.Lock()
 	c.lastCmd = strings.Join(args, " ")
+	c.mu.Unlock()
+
 	start := time.Now()
+	err = cmd.Run()
+	res.elapsed = time.Since(start)
 
-	if err := cmd.Run(); err != nil {
-		return &out, time.Since(start), fmt.Errorf(`running cmd %s: %w`, cmd.Args, err)
+	if err != nil {
+		return res, fmt.Errorf(`running cmd %s: %w`, cmd.Args, err)
 	}
 
-	return &out, time.Since(start), nil
+	return res, nil
 }
@@ -0,0 +1,100 @@
+package commands
+
+/* This file contains the procedures that decide what happens when a command is triggered while it's running. */
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errors produced by this file.
+var (
+	ErrRunning     = errors.New("command is already running")
+	ErrKilled      = errors.New("command was killed by a new run")
+	ErrConcurrency = errors.New("invalid concurrency, use queue, parallel, reject or kill")
+)
+
+// Concurrency policies. Queue is the default.
+const (
+	ConcurrencyQueue    = "queue"    // Wait for the running command to finish.
+	ConcurrencyParallel = "parallel" // Run at the same time.
+	ConcurrencyReject   = "reject"   // Do not run.
+	ConcurrencyKill     = "kill"     // Kill the running command, then run.
+)
+
+// Command states for runs that were stopped by the concurrency policy.
+const (
+	StateRejected = "rejected"
+	StateKilled   = "killed"
+)
+
+// activeRun is a running command that may be killed.
+type activeRun struct {
+	cancel context.CancelCauseFunc
+	done   chan struct{}
+}
+
+// setupConcurrency checks the concurrency policy.
+func (c *Command) setupConcurrency() error {
+	c.Concurrency = strings.ToLower(strings.TrimSpace(c.Concurrency))
+	c.active = make(map[int]*activeRun)
+
+	switch c.Concurrency {
+	case "":
+		c.Concurrency = ConcurrencyQueue
+	case ConcurrencyQueue, ConcurrencyParallel, ConcurrencyReject, ConcurrencyKill:
+	default:
+		return fmt.Errorf("%w: command '%s': %s", ErrConcurrency, c.Name, c.Concurrency)
+	}
+
+	return nil
+}
+
+// start applies the concurrency policy. It returns a context for the run, and a function to call when it's done.
+func (c *Command) start(ctx context.Context) (context.Context, func(), error) {
+	if c.Concurrency == ConcurrencyQueue || c.Concurrency == "" {
+		c.queue.Lock()
+	}
+
+	c.runMu.Lock()
+
+	if c.Concurrency == ConcurrencyReject && len(c.active) > 0 {
+		c.runMu.Unlock()
+		return nil, nil, ErrRunning
+	}
+
+	var killed []*activeRun
+
+	if c.Concurrency == ConcurrencyKill {
+		for _, run := range c.active {
+			run.cancel(ErrKilled)
+			killed = append(killed, run)
+		}
+	}
+
+	c.seq++
+	seq := c.seq
+	ctx, cancel := context.WithCancelCause(ctx)
+	run := &activeRun{cancel: cancel, done: make(chan struct{})}
+	c.active[seq] = run
+	c.runMu.Unlock()
+
+	// Wait for killed commands to exit, so they do not step on this one.
+	for _, run := range killed {
+		<-run.done
+	}
+
+	return ctx, func() {
+		c.runMu.Lock()
+		delete(c.active, seq)
+		c.runMu.Unlock()
+		cancel(nil)
+		close(run.done)
+
+		if c.Concurrency == ConcurrencyQueue || c.Concurrency == "" {
+			c.queue.Unlock()
+		}
+	}, nil
+}
@@ -0,0 +1,122 @@
+package commands //nolint:testpackage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/triggers/commands/cmdconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupConcurrency(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"":          ConcurrencyQueue,
+		" Queue ":   ConcurrencyQueue,
+		"parallel":  ConcurrencyParallel,
+		"REJECT":    ConcurrencyReject,
+		"kill":      ConcurrencyKill,
+		"sometimes": "",
+	}
+
+	for input, want := range tests {
+		cmd := &Command{Config: cmdconfig.Config{Concurrency: input}}
+		err := cmd.setupConcurrency()
+
+		if want == "" {
+			require.ErrorIs(t, err, ErrConcurrency, input)
+			continue
+		}
+
+		require.NoError(t, err, input)
+		assert.Equal(t, want, cmd.Concurrency, input)
+	}
+}
+
+func newConcurrency(t *testing.T, policy string) *Command {
+	t.Helper()
+
+	cmd := &Command{Config: cmdconfig.Config{Concurrency: policy}}
+	require.NoError(t, cmd.setupConcurrency())
+
+	return cmd
+}
+
+func TestStartReject(t *testing.T) {
+	t.Parallel()
+
+	cmd := newConcurrency(t, ConcurrencyReject)
+	_, done, err := cmd.start(t.Context())
+	require.NoError(t, err)
+
+	_, _, err = cmd.start(t.Context())
+	require.ErrorIs(t, err, ErrRunning, "a second run is rejected")
+
+	done()
+
+	_, done, err = cmd.start(t.Context())
+	require.NoError(t, err, "the command may run again when the first run finished")
+	done()
+}
+
+func TestStartKill(t *testing.T) {
+	t.Parallel()
+
+	cmd := newConcurrency(t, ConcurrencyKill)
+	first, done, err := cmd.start(t.Context())
+	require.NoError(t, err)
+
+	go func() {
+		<-first.Done()
+		done() // The killed run exits.
+	}()
+
+	second, done2, err := cmd.start(t.Context())
+	require.NoError(t, err)
+	require.ErrorIs(t, context.Cause(first), ErrKilled, "the first run is killed")
+	require.NoError(t, second.Err(), "the new run is not")
+	done2()
+	assert.Empty(t, cmd.active)
+}
+
+func TestStartQueue(t *testing.T) {
+	t.Parallel()
+
+	cmd := newConcurrency(t, ConcurrencyQueue)
+	_, done, err := cmd.start(t.Context())
+	require.NoError(t, err)
+
+	started := make(chan func())
+
+	go func() {
+		_, done2, _ := cmd.start(t.Context())
+		started <- done2
+	}()
+
+	select {
+	case <-started:
+		t.Fatal("the queued run must wait for the first run")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	done()
+	(<-started)()
+}
+
+func TestStartParallel(t *testing.T) {
+	t.Parallel()
+
+	cmd := newConcurrency(t, ConcurrencyParallel)
+	_, done, err := cmd.start(t.Context())
+	require.NoError(t, err)
+
+	_, done2, err := cmd.start(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, cmd.active, 2, "both runs are active")
+	done()
+	done2()
+	assert.Empty(t, cmd.active)
+}
@@ -0,0 +1,112 @@
+package commands
+
+/* This file contains the procedures that set up a command's environment, and map its exit code to a state. */
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Errors produced by this file.
+var (
+	ErrRunAsWindows = errors.New("run_as is not supported on Windows")
+	ErrExitCode     = errors.New("invalid exit_codes entry, use code=state")
+	ErrSecret       = errors.New("invalid secrets entry, use NAME=/path/to/file")
+)
+
+// Command states. Mapped exit codes may report any other state.
+const (
+	StateOK     = "ok"
+	StateFailed = "failed"
+)
+
+// setupExitCodes parses the exit code to state mapping.
+func (c *Command) setupExitCodes() error {
+	c.exitCodes = make(map[int]string, len(c.ExitCodes))
+
+	for _, entry := range c.ExitCodes {
+		code, state, ok := strings.Cut(entry, "=")
+		num, err := strconv.Atoi(strings.TrimSpace(code))
+
+		if !ok || err != nil || strings.TrimSpace(state) == "" {
+			return fmt.Errorf("%w: command '%s': %s", ErrExitCode, c.Name, entry)
+		}
+
+		c.exitCodes[num] = strings.TrimSpace(state)
+	}
+
+	return nil
+}
+
+// prepare sets the working directory, environment, standard input and user on the command.
+func (c *Command) prepare(cmd *exec.Cmd) error {
+	cmd.Dir = c.Dir
+
+	if c.Stdin != "" {
+		cmd.Stdin = strings.NewReader(c.Stdin)
+	}
+
+	if len(c.Env) > 0 || len(c.Secrets) > 0 {
+		secrets, err := c.readSecrets()
+		if err != nil {
+			return err
+		}
+
+		cmd.Env = append(append(os.Environ(), c.Env...), secrets...)
+	}
+
+	if c.RunAs != "" {
+		return runAs(cmd, c.RunAs)
+	}
+
+	return nil
+}
+
+// readSecrets returns NAME=value environment variables from the secret files.
+func (c *Command) readSecrets() ([]string, error) {
+	env := make([]string, len(c.Secrets))
+
+	for idx, secret := range c.Secrets {
+		name, path, ok := strings.Cut(secret, "=")
+		if !ok || name == "" || path == "" {
+			return nil, fmt.Errorf("%w: %s", ErrSecret, secret)
+		}
+
+		value, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading secret %s: %w", name, err)
+		}
+
+		env[idx] = name + "=" + strings.TrimRight(string(value), "\r\n")
+	}
+
+	return env, nil
+}
+
+// state returns the exit code and state for a finished command.
+// The error is removed if the exit code is mapped to a state.
+func (c *Command) state(err error) (int, string, error) {
+	if err == nil {
+		if state, ok := c.exitCodes[0]; ok {
+			return 0, state, nil
+		}
+
+		return 0, StateOK, nil
+	}
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return -1, StateFailed, err
+	}
+
+	code := exitErr.ExitCode()
+	if state, ok := c.exitCodes[code]; ok {
+		return code, state, nil
+	}
+
+	return code, StateFailed, err
+}
@@ -0,0 +1,109 @@
+package commands //nolint:testpackage
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/Notifiarr/notifiarr/pkg/triggers/commands/cmdconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errNotFound = errors.New("executable file not found")
+
+func TestSetupExitCodes(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	cmd := &Command{Config: cmdconfig.Config{ExitCodes: []string{"1=warning", " 2 = skipped ", "0=done"}}}
+	require.NoError(t, cmd.setupExitCodes())
+	assert.Equal(map[int]string{0: "done", 1: "warning", 2: "skipped"}, cmd.exitCodes)
+
+	for _, entry := range []string{"1", "one=warning", "1=", "=warning"} {
+		cmd := &Command{Config: cmdconfig.Config{ExitCodes: []string{entry}}}
+		assert.ErrorIs(cmd.setupExitCodes(), ErrExitCode, entry)
+	}
+}
+
+func TestCommandState(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a posix shell")
+	}
+
+	exitErr := func(code string) error {
+		return exec.CommandContext(t.Context(), "sh", "-c", "exit "+code).Run()
+	}
+
+	cmd := &Command{Config: cmdconfig.Config{ExitCodes: []string{"1=warning"}}}
+	require.NoError(t, cmd.setupExitCodes())
+
+	tests := []struct {
+		name  string
+		err   error
+		code  int
+		state string
+		fails bool
+	}{
+		{name: "success", code: 0, state: StateOK},
+		{name: "mapped", err: exitErr("1"), code: 1, state: "warning"},
+		{name: "unmapped", err: exitErr("3"), code: 3, state: StateFailed, fails: true},
+		{name: "not an exit", err: errNotFound, code: -1, state: StateFailed, fails: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			code, state, err := cmd.state(test.err)
+			assert.Equal(t, test.code, code)
+			assert.Equal(t, test.state, state)
+			assert.Equal(t, test.fails, err != nil, "a mapped exit code is not an error")
+		})
+	}
+
+	zero := &Command{Config: cmdconfig.Config{ExitCodes: []string{"0=changed"}}}
+	require.NoError(t, zero.setupExitCodes())
+
+	_, state, _ := zero.state(nil)
+	assert.Equal(t, "changed", state, "exit code 0 may be mapped too")
+}
+
+func TestPrepare(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(secret, []byte("s3cret\n"), 0o600))
+
+	cmd := &Command{Config: cmdconfig.Config{
+		Dir:     dir,
+		Env:     []string{"MODE=test"},
+		Secrets: []string{"TOKEN=" + secret},
+		Stdin:   "input",
+	}}
+	run := exec.CommandContext(t.Context(), "true")
+	require.NoError(t, cmd.prepare(run))
+	assert.Equal(dir, run.Dir)
+	assert.NotNil(run.Stdin)
+	assert.Contains(run.Env, "MODE=test")
+	assert.Contains(run.Env, "TOKEN=s3cret", "trailing newlines are trimmed from secrets")
+
+	run = exec.CommandContext(t.Context(), "true")
+	require.NoError(t, (&Command{}).prepare(run))
+	assert.Nil(run.Env, "no env or secrets inherits the environment")
+
+	for _, entry := range []string{"TOKEN", "=" + secret, "TOKEN="} {
+		cmd := &Command{Config: cmdconfig.Config{Secrets: []string{entry}}}
+		assert.ErrorIs(cmd.prepare(exec.CommandContext(t.Context(), "true")), ErrSecret, entry)
+	}
+
+	cmd = &Command{Config: cmdconfig.Config{Secrets: []string{"TOKEN=" + filepath.Join(dir, "missing")}}}
+	require.ErrorIs(t, cmd.prepare(exec.CommandContext(t.Context(), "true")), os.ErrNotExist)
+}
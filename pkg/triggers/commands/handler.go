@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// HistoryHandler is passed into the webserver as an HTTP handler.
+//
+//	@Description	Returns the saved runs for a command, newest first.
+//	@Summary		Command run history
+//	@Tags			Triggers
+//	@Produce		json
+//	@Param			hash	path		string								true	"Unique hash for the command"
+//	@Success		200		{object}	apps.APIResponse{message=[]Run}		"command runs"
+//	@Failure		400		{object}	apps.APIResponse{message=string}	"bad or missing hash"
+//	@Failure		404		{object}	string								"bad token or api key"
+//	@Router			/command/{hash}/runs [get]
+//	@Security		ApiKeyAuth
+func (a *Action) HistoryHandler(req *http.Request) (int, any) {
+	cmd := a.GetByHash(mux.Vars(req)["hash"])
+	if cmd == nil {
+		return http.StatusBadRequest, "Invalid command hash provided."
+	}
+
+	return http.StatusOK, a.cmd.history.list(cmd.Hash)
+}
+
+// RunHandler is passed into the webserver as an HTTP handler.
+//
+//	@Description	Returns one saved run for a command.
+//	@Summary		Command run
+//	@Tags			Triggers
+//	@Produce		json
+//	@Param			hash	path		string								true	"Unique hash for the command"
+//	@Param			id		path		int									true	"Run ID from the run history"
+//	@Success		200		{object}	apps.APIResponse{message=Run}		"command run"
+//	@Failure		400		{object}	apps.APIResponse{message=string}	"bad or missing hash"
+//	@Failure		404		{object}	apps.APIResponse{message=string}	"run not found"
+//	@Router			/command/{hash}/runs/{id} [get]
+//	@Security		ApiKeyAuth
+func (a *Action) RunHandler(req *http.Request) (int, any) {
+	cmd := a.GetByHash(mux.Vars(req)["hash"])
+	if cmd == nil {
+		return http.StatusBadRequest, "Invalid command hash provided."
+	}
+
+	id, _ := strconv.Atoi(mux.Vars(req)["id"])
+	if run := a.cmd.history.get(cmd.Hash, id); run != nil {
+		return http.StatusOK, run
+	}
+
+	return http.StatusNotFound, "Run not found in history."
+}
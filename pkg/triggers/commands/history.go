@@ -0,0 +1,183 @@
+package commands
+
+/* This file saves every command run to a file, so the history survives restarts. */
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/website"
+	"golift.io/cnfg"
+)
+
+// HistoryFile is saved next to the config file.
+const HistoryFile = "command_history.json"
+
+const (
+	defaultHistory    = 20
+	defaultHistoryAge = 30 * 24 * time.Hour
+	maxHistoryOutput  = 4096
+)
+
+// Run is one execution of a command, saved in the history.
+type Run struct {
+	ID int `json:"id"`
+	// Event is what triggered the command: the website, a chat command, the GUI, a file watcher, etc.
+	Event     website.EventType `json:"event"`
+	ReqID     string            `json:"reqId"`
+	Args      []string          `json:"args"`
+	Start     time.Time         `json:"start"`
+	Elapsed   cnfg.Duration     `json:"elapsed"`
+	ExitCode  int               `json:"exitCode"`
+	State     string            `json:"state"`
+	Error     string            `json:"error,omitempty"`
+	Output    string            `json:"output"`
+	Truncated bool              `json:"truncated,omitempty"`
+}
+
+// history holds the runs for every command, by command hash. Oldest runs are first.
+type history struct {
+	file string // Empty keeps the history in memory only.
+	mu   sync.RWMutex
+	runs map[string][]*Run
+}
+
+func newHistory(configFile string) *history {
+	hist := &history{runs: make(map[string][]*Run)}
+	if configFile != "" {
+		hist.file = filepath.Join(filepath.Dir(configFile), HistoryFile)
+	}
+
+	return hist
+}
+
+// load reads the history file. History for commands that no longer exist is dropped.
+func (h *history) load(cmds []*Command) error {
+	if h.file == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(h.file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("reading command history: %w", err)
+	}
+
+	runs := make(map[string][]*Run)
+	if err := json.Unmarshal(data, &runs); err != nil {
+		return fmt.Errorf("decoding command history %s: %w", h.file, err)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, cmd := range cmds {
+		if runs[cmd.Hash] != nil {
+			h.runs[cmd.Hash] = cmd.prune(runs[cmd.Hash])
+		}
+	}
+
+	return nil
+}
+
+// add saves a run to a command's history, and writes the history file.
+func (h *history) add(cmd *Command, run *Run) {
+	if h == nil || cmd.History < 0 {
+		return
+	}
+
+	if len(run.Output) > maxHistoryOutput {
+		run.Output, run.Truncated = run.Output[:maxHistoryOutput], true
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if runs := h.runs[cmd.Hash]; len(runs) > 0 {
+		run.ID = runs[len(runs)-1].ID + 1
+	} else {
+		run.ID = 1
+	}
+
+	h.runs[cmd.Hash] = cmd.prune(append(h.runs[cmd.Hash], run))
+
+	if err := h.save(); err != nil {
+		mnd.Log.Errorf(run.ReqID, "Saving command history: %v", err)
+	}
+}
+
+// save writes the history file. Call with the lock held.
+func (h *history) save() error {
+	if h.file == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(h.runs)
+	if err != nil {
+		return fmt.Errorf("encoding command history: %w", err)
+	}
+
+	if err := os.WriteFile(h.file, data, mnd.Mode0600); err != nil {
+		return fmt.Errorf("writing command history: %w", err)
+	}
+
+	return nil
+}
+
+// list returns a command's runs, newest first.
+func (h *history) list(hash string) []*Run {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	runs := slices.Clone(h.runs[hash])
+	slices.Reverse(runs)
+
+	if runs == nil {
+		return []*Run{}
+	}
+
+	return runs
+}
+
+// get returns a command's run by ID, or nil.
+func (h *history) get(hash string, id int) *Run {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for _, run := range h.runs[hash] {
+		if run.ID == id {
+			return run
+		}
+	}
+
+	return nil
+}
+
+// prune removes runs older than the history age, and the oldest runs over the history count.
+func (c *Command) prune(runs []*Run) []*Run {
+	count, age := c.History, c.HistoryAge.Duration
+	if count == 0 {
+		count = defaultHistory
+	}
+
+	if age == 0 {
+		age = defaultHistoryAge
+	}
+
+	cutoff := time.Now().Add(-age)
+	runs = slices.DeleteFunc(runs, func(run *Run) bool { return run.Start.Before(cutoff) })
+
+	if len(runs) > count {
+		runs = runs[len(runs)-count:]
+	}
+
+	return runs
+}
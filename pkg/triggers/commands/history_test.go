@@ -0,0 +1,91 @@
+package commands //nolint:testpackage
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/triggers/commands/cmdconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golift.io/cnfg"
+)
+
+func TestHistory(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	configFile := filepath.Join(t.TempDir(), "notifiarr.conf")
+	cmd := &Command{Config: cmdconfig.Config{Hash: "abc", History: 2}}
+	hist := newHistory(configFile)
+	assert.Equal(filepath.Join(filepath.Dir(configFile), HistoryFile), hist.file)
+
+	for _, output := range []string{"one", "two", strings.Repeat("x", maxHistoryOutput+1)} {
+		hist.add(cmd, &Run{Start: time.Now(), Output: output})
+	}
+
+	runs := hist.list("abc")
+	require.Len(t, runs, 2, "only two runs are kept")
+	assert.Equal(3, runs[0].ID, "newest run is first")
+	assert.True(runs[0].Truncated)
+	assert.Len(runs[0].Output, maxHistoryOutput)
+	assert.Equal("two", runs[1].Output)
+	assert.Nil(hist.get("abc", 1), "the oldest run was pruned")
+	assert.Equal("two", hist.get("abc", 2).Output)
+	assert.Equal([]*Run{}, hist.list("missing"))
+
+	// Another history reads the file; commands that no longer exist are dropped.
+	loaded := newHistory(configFile)
+	require.NoError(t, loaded.load([]*Command{cmd}))
+	assert.Len(loaded.list("abc"), 2)
+
+	dropped := newHistory(configFile)
+	require.NoError(t, dropped.load(nil))
+	assert.Empty(dropped.list("abc"))
+
+	disabled := &Command{Config: cmdconfig.Config{Hash: "off", History: -1}}
+	hist.add(disabled, &Run{Start: time.Now()})
+	assert.Empty(hist.list("off"), "-1 disables the history")
+}
+
+func TestPrune(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	runs := func() []*Run {
+		return []*Run{
+			{ID: 1, Start: now.Add(-40 * 24 * time.Hour)},
+			{ID: 2, Start: now.Add(-2 * time.Hour)},
+			{ID: 3, Start: now.Add(-time.Hour)},
+			{ID: 4, Start: now},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		count   int
+		age     time.Duration
+		wantIDs []int
+	}{
+		{name: "defaults drop old runs", wantIDs: []int{2, 3, 4}},
+		{name: "count", count: 2, wantIDs: []int{3, 4}},
+		{name: "age", age: 90 * time.Minute, wantIDs: []int{3, 4}},
+		{name: "count and age", count: 1, age: 90 * time.Minute, wantIDs: []int{4}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := &Command{Config: cmdconfig.Config{History: test.count, HistoryAge: cnfg.Duration{Duration: test.age}}}
+			ids := []int{}
+
+			for _, run := range cmd.prune(runs()) {
+				ids = append(ids, run.ID)
+			}
+
+			assert.Equal(t, test.wantIDs, ids)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+//go:build !windows
+
+package commands
+
+import (
+	"fmt"
+	"os/exec"
+	"os/user"
+	"strconv"
+	"syscall"
+
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+)
+
+// runAs sets the user and group the command runs as. The user may be a name or a uid.
+func runAs(cmd *exec.Cmd, username string) error {
+	found, err := user.Lookup(username)
+	if err != nil {
+		if found, err = user.LookupId(username); err != nil {
+			return fmt.Errorf("finding run_as user %s: %w", username, err)
+		}
+	}
+
+	uid, err := strconv.ParseUint(found.Uid, mnd.Base10, mnd.Bits32)
+	if err != nil {
+		return fmt.Errorf("parsing run_as user %s uid: %w", username, err)
+	}
+
+	gid, err := strconv.ParseUint(found.Gid, mnd.Base10, mnd.Bits32)
+	if err != nil {
+		return fmt.Errorf("parsing run_as user %s gid: %w", username, err)
+	}
+
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Credential: &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid)},
+	}
+
+	return nil
+}
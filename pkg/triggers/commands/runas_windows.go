@@ -0,0 +1,11 @@
+package commands
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// runAs is not supported on Windows.
+func runAs(_ *exec.Cmd, username string) error {
+	return fmt.Errorf("%w: %s", ErrRunAsWindows, username)
+}
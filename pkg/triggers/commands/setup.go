@@ -26,32 +26,44 @@ type Action struct {
 type cmd struct {
 	*common.Config
 	cmdlist []*Command
+	history *history
 }
 
 // Command contains the input data for a defined command.
 // It also contains some saved data about the command being run.
 type Command struct {
 	cmdconfig.Config
-	cmd     string
-	args    []*regexp.Regexp
-	disable bool
-	fails   int
-	runs    int
-	output  string // last output logged
-	lastRun time.Time
-	lastArg []string
-	lastCmd string
-	mu      sync.RWMutex
-	ch      chan *common.ActionInput
+	cmd       string
+	args      []*regexp.Regexp
+	disable   bool
+	fails     int
+	runs      int
+	output    string // last output logged
+	lastRun   time.Time
+	lastArg   []string
+	lastCmd   string
+	lastCode  int
+	lastState string
+	exitCodes map[int]string // parsed from ExitCodes.
+	mu        sync.RWMutex
+	ch        chan *common.ActionInput
+	history   *history
+	queue     sync.Mutex // held while running, with the queue concurrency policy.
+	runMu     sync.Mutex // protects seq and active.
+	seq       int
+	active    map[int]*activeRun
 }
 
-// New configures the library.
-func New(config *common.Config, commands []*Command) *Action {
+// New configures the library. The command history is saved next to the config file.
+func New(config *common.Config, commands []*Command, configFile string) *Action {
+	history := newHistory(configFile)
+
 	for _, cmd := range commands {
 		cmd.Setup()
+		cmd.history = history
 	}
 
-	return &Action{cmd: &cmd{Config: config, cmdlist: commands}}
+	return &Action{cmd: &cmd{Config: config, cmdlist: commands, history: history}}
 }
 
 // Run fires a custom command.
@@ -69,6 +81,7 @@ func (a *Action) List() []*cmdconfig.Config {
 
 	for _, c := range a.cmd.cmdlist {
 		config := c.Config
+		config.Env = nil // Env values may be secret.
 		output = append(output, &config)
 	}
 
@@ -86,6 +99,17 @@ func (a *Action) GetByHash(hash string) *Command {
 	return nil
 }
 
+// GetByName returns a command by name, or by hash ID.
+func (a *Action) GetByName(name string) *Command {
+	for _, cmd := range a.cmd.cmdlist {
+		if cmd.Name == name {
+			return cmd
+		}
+	}
+
+	return a.GetByHash(name)
+}
+
 // Create initializes the library.
 func (a *Action) Create() {
 	reqID := mnd.ReqID()
@@ -103,6 +127,8 @@ type Stats struct {
 	LastCmd    string           `json:"lastCmd"`
 	LastTime   time.Time        `json:"lastTime"`
 	LastArgs   []string         `json:"lastArgs"`
+	LastCode   int              `json:"lastCode"`
+	LastState  string           `json:"lastState"`
 }
 
 // Stats returns statistics about a command.
@@ -125,10 +151,16 @@ func (c *Command) Stats() Stats {
 		LastTime:   c.lastRun,
 		LastArgs:   c.lastArg,
 		LastCmd:    c.lastCmd,
+		LastCode:   c.lastCode,
+		LastState:  c.lastState,
 	}
 }
 
 func (c *cmd) create(reqID string) {
+	if err := c.history.load(c.cmdlist); err != nil {
+		mnd.Log.Errorf(reqID, "Loading Custom Command History: %v", err)
+	}
+
 	for _, cmd := range c.cmdlist {
 		cmd.ch = make(chan *common.ActionInput, 1)
 
@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -10,6 +11,9 @@ import (
 	"github.com/go-co-op/gocron/v2"
 )
 
+// ErrInvalidCron is returned when a cron expression or timezone cannot be parsed.
+var ErrInvalidCron = errors.New("invalid cron expression")
+
 // Frequency sets the base "how-often" a CronJob is executed.
 // See the Frequency constants.
 type Frequency uint
@@ -49,6 +53,7 @@ const (
 // 3 `Daily` uses Hours, Minutes and Seconds.
 // 4 `Weekly` uses DaysOfWeek, Hours, Minutes and Seconds.
 // 5 `Monthly` uses DaysOfMonth, Hours, Minutes and Seconds.
+// A Cron expression replaces all of these when it is set.
 type CronJob struct {
 	// Frequency to configure the job. Pass 0 disable the cron.
 	Frequency Frequency `json:"frequency" toml:"frequency" xml:"frequency" yaml:"frequency"`
@@ -63,10 +68,64 @@ type CronJob struct {
 	DaysOfMonth []int `json:"daysOfMonth" toml:"days_of_month" xml:"days_of_month" yaml:"daysOfMonth"`
 	// Months to schedule. 1 to 12. 1 = January.
 	Months []uint `json:"months" toml:"months" xml:"months" yaml:"months"`
+	// CronExpr replaces Frequency and the other fields when its Cron expression is set.
+	CronExpr
+}
+
+// CronExpr is a cron expression and its timezone. Schedules without frequency settings use it alone.
+type CronExpr struct {
+	// Cron is a standard cron expression with 5 fields, or 6 with seconds first, or a descriptor
+	// like @daily or @every 1h30m. Frequency and the other fields are ignored when this is set.
+	Cron string `json:"cron,omitempty" toml:"cron" xml:"cron" yaml:"cron"`
+	// Timezone for the Cron expression, like America/New_York. Defaults to the local timezone.
+	Timezone string `json:"timezone,omitempty" toml:"timezone" xml:"timezone" yaml:"timezone"`
+}
+
+// Enabled returns true if the job has a cron expression or a frequency.
+func (c *CronJob) Enabled() bool {
+	return c.Cron != "" || c.Frequency != DeadCron
+}
+
+// Validate returns an error if the cron expression or its timezone is invalid.
+func (c *CronExpr) Validate() error {
+	if c.Cron == "" {
+		return nil
+	}
+
+	if c.Timezone != "" {
+		if _, err := time.LoadLocation(c.Timezone); err != nil {
+			return fmt.Errorf("%w: timezone: %w", ErrInvalidCron, err)
+		}
+	}
+
+	if err := gocron.NewDefaultCron(true).IsValid(c.crontab(), time.Local, time.Now()); err != nil {
+		return fmt.Errorf("%w '%s': %w", ErrInvalidCron, c.Cron, err)
+	}
+
+	return nil
+}
+
+// Job returns a schedule for the cron expression.
+func (c CronExpr) Job() *CronJob {
+	return &CronJob{CronExpr: c}
+}
+
+// crontab returns the cron expression with the timezone prefix, unless it already has one.
+func (c *CronExpr) crontab() string {
+	expr := strings.TrimSpace(c.Cron)
+	if c.Timezone == "" || strings.HasPrefix(expr, "TZ=") || strings.HasPrefix(expr, "CRON_TZ=") {
+		return expr
+	}
+
+	return "CRON_TZ=" + c.Timezone + " " + expr
 }
 
 // String attempts to turn a CronJob into a string.
 func (c *CronJob) String() string {
+	if c.Cron != "" {
+		return "Cron " + c.crontab()
+	}
+
 	switch c.Frequency {
 	default:
 		fallthrough
@@ -204,31 +263,43 @@ func (c *CronJob) daysOfTheWeek() func() []time.Weekday {
 	}
 }
 
+// New schedules the job. Call Validate first; an invalid cron expression is a bug here.
 func (c *CronJob) New(cron gocron.Scheduler, cmd func()) gocron.Job { //nolint:ireturn,nolintlint // it's what we have.
-	var def gocron.JobDefinition
+	def := c.definition()
+	if def == nil {
+		return nil
+	}
+
+	job, err := cron.NewJob(def, gocron.NewTask(cmd))
+	if err != nil {
+		panic(fmt.Sprint("[scheduler] THIS IS A BUG, please report it: ", err))
+	}
+
+	return job
+}
+
+//nolint:ireturn // gocron only returns interfaces.
+func (c *CronJob) definition() gocron.JobDefinition {
+	if c.Cron != "" {
+		return gocron.CronJob(c.crontab(), true) // seconds are optional.
+	}
+
 	switch c.fix(); c.Frequency {
 	default:
 		fallthrough
 	case DeadCron:
 		return nil
 	case Minutely:
-		def = gocron.CronJob(c.AtTimes.seconds()+" * * * * *", true)
+		return gocron.CronJob(c.AtTimes.seconds()+" * * * * *", true)
 	case Hourly:
-		def = gocron.CronJob(c.AtTimes.minutes()+" * * * *", false)
+		return gocron.CronJob(c.AtTimes.minutes()+" * * * *", false)
 	case Daily:
-		def = gocron.DailyJob(c.Interval, c.AtTimes.AtTimes())
+		return gocron.DailyJob(c.Interval, c.AtTimes.AtTimes())
 	case Weekly:
-		def = gocron.WeeklyJob(c.Interval, c.daysOfTheWeek(), c.AtTimes.AtTimes())
+		return gocron.WeeklyJob(c.Interval, c.daysOfTheWeek(), c.AtTimes.AtTimes())
 	case Monthly:
-		def = gocron.MonthlyJob(c.Interval, c.daysOfTheMonths(), c.AtTimes.AtTimes())
-	}
-
-	job, err := cron.NewJob(def, gocron.NewTask(cmd))
-	if err != nil {
-		panic(fmt.Sprint("[scheduler] THIS IS A BUG, please report it: ", err))
+		return gocron.MonthlyJob(c.Interval, c.daysOfTheMonths(), c.AtTimes.AtTimes())
 	}
-
-	return job
 }
 
 // Stop stops all jobs in the scheduler and pauses it.
@@ -0,0 +1,81 @@
+package scheduler //nolint:testpackage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronExprValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		expr    CronExpr
+		crontab string
+		invalid bool
+	}{
+		{name: "empty", expr: CronExpr{}, crontab: ""},
+		{name: "five fields", expr: CronExpr{Cron: "*/15 * * * *"}, crontab: "*/15 * * * *"},
+		{name: "six fields", expr: CronExpr{Cron: " 30 0 4 * * 1-5 "}, crontab: "30 0 4 * * 1-5"},
+		{name: "every", expr: CronExpr{Cron: "@every 5m"}, crontab: "@every 5m"},
+		{name: "daily", expr: CronExpr{Cron: "@daily"}, crontab: "@daily"},
+		{
+			name:    "timezone field",
+			expr:    CronExpr{Cron: "0 3 * * *", Timezone: "America/New_York"},
+			crontab: "CRON_TZ=America/New_York 0 3 * * *",
+		},
+		{name: "tz prefix", expr: CronExpr{Cron: "TZ=UTC 0 3 * * *"}, crontab: "TZ=UTC 0 3 * * *"},
+		{
+			name:    "prefix wins over timezone field",
+			expr:    CronExpr{Cron: "CRON_TZ=Europe/Berlin 0 3 * * *", Timezone: "UTC"},
+			crontab: "CRON_TZ=Europe/Berlin 0 3 * * *",
+		},
+		{name: "invalid timezone", expr: CronExpr{Cron: "0 3 * * *", Timezone: "Mars/Olympus"}, invalid: true},
+		{name: "invalid expression", expr: CronExpr{Cron: "61 * * * *"}, invalid: true},
+		{name: "too many fields", expr: CronExpr{Cron: "* * * * * * *"}, invalid: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			if test.invalid {
+				require.ErrorIs(t, test.expr.Validate(), ErrInvalidCron)
+				return
+			}
+
+			require.NoError(t, test.expr.Validate())
+			assert.Equal(t, test.crontab, test.expr.crontab())
+
+			job := test.expr.Job()
+			assert.Equal(t, test.expr.Cron != "", job.Enabled())
+
+			if test.expr.Cron != "" {
+				assert.Equal(t, "Cron "+test.crontab, job.String())
+				assert.NotNil(t, job.definition(), "a cron expression must produce a job definition")
+			}
+		})
+	}
+}
+
+func TestCronJobNew(t *testing.T) {
+	t.Parallel()
+
+	cron, err := gocron.NewScheduler()
+	require.NoError(t, err)
+	defer func() { _ = cron.Shutdown() }()
+
+	cron.Start()
+
+	job := CronExpr{Cron: "@every 5m", Timezone: "UTC"}.Job().New(cron, func() {})
+	require.NotNil(t, job)
+
+	next, err := job.NextRun()
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), next, time.Minute)
+	assert.Nil(t, (&CronJob{}).New(cron, func() {}), "a disabled schedule has no job")
+}
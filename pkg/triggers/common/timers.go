@@ -58,6 +58,22 @@ type TriggerInfo struct {
 	Cron *scheduler.CronJob `json:"cron,omitempty"`
 	Runs int                `json:"runs"`
 	Kind string             `json:"kind"`
+	// Next contains the next few run times of a schedule.
+	Next []time.Time `json:"next,omitempty"`
+}
+
+// nextRuns is how many run times are included for schedules.
+const nextRuns = 5
+
+// NextRuns returns the next few run times of a scheduled action, or nil if the action has no schedule.
+func (a *Action) NextRuns() []time.Time {
+	if a == nil || a.job == nil {
+		return nil
+	}
+
+	next, _ := a.job.NextRuns(nextRuns) // errors if the scheduler is not running.
+
+	return next
 }
 
 //nolint:nonamedreturns,cyclop
@@ -113,6 +129,7 @@ func (c *Config) GatherTriggerInfo() (triggers, timers, schedules []TriggerInfo)
 				Cron: action.J,
 				Runs: count,
 				Kind: "Schedule",
+				Next: action.NextRuns(),
 			})
 		}
 	}
@@ -139,10 +139,15 @@ func (c *Config) Get(name TriggerName) *Action {
 }
 
 // Add adds a new action to our list of "Actions to run."
-// actions are timers or triggers, or both.
+// actions are timers or triggers, or both. An action with an invalid schedule is only a trigger.
 func (c *Config) Add(action ...*Action) {
 	for _, a := range action {
 		if a.J != nil {
+			if err := a.J.Validate(); err != nil {
+				mnd.Log.Errorf(mnd.ReqID(), "Schedule for %s disabled: %v", a.Name, err)
+				continue
+			}
+
 			a.job = a.J.New(c.Scheduler, func() { a.C <- &ActionInput{Type: website.EventCron, ReqID: mnd.ReqID()} })
 		} else if a.D.Duration != 0 {
 			a.t = time.NewTicker(a.D.Duration)
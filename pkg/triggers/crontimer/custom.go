@@ -54,7 +54,8 @@ type cmd struct {
 // Timer is used to trigger actions.
 type Timer struct {
 	*clientinfo.CronConfig
-	ch chan *common.ActionInput
+	ch     chan *common.ActionInput
+	action *common.Action
 }
 
 // New configures the library.
@@ -71,6 +72,11 @@ func (t *Timer) Run(input *common.ActionInput) {
 	t.ch <- input
 }
 
+// NextRuns returns the next few run times of a timer with a cron expression.
+func (t *Timer) NextRuns() []time.Time {
+	return t.action.NextRuns()
+}
+
 // run responds to the channel that the timer fired into.
 func (t *Timer) run(ctx context.Context, input *common.ActionInput) {
 	website.SendData(&website.Request{
@@ -150,23 +156,29 @@ func (c *cmd) create(reqID string) {
 			ch:         make(chan *common.ActionInput, 1),
 		}
 		custom.URI = "/" + strings.TrimPrefix(custom.URI, "/")
+		action := &common.Action{
+			Key:  "TrigCustomCronTimer",
+			Name: common.TriggerName(fmt.Sprintf("Running Custom Cron Timer '%s'", custom.Name)),
+			Fn:   timer.run,
+			C:    timer.ch,
+		}
 
-		if custom.Interval.Duration < time.Minute {
-			mnd.Log.ErrorfNoShare(reqID, "Website provided custom cron interval under 1 minute. Interval: %s Name: %s, URI: %s",
-				custom.Interval, custom.Name, custom.URI)
+		if custom.Cron != "" {
+			action.J = custom.CronExpr.Job()
+		} else {
+			if custom.Interval.Duration < time.Minute {
+				mnd.Log.ErrorfNoShare(reqID, "Website provided custom cron interval under 1 minute. Interval: %s Name: %s, URI: %s",
+					custom.Interval, custom.Name, custom.URI)
 
-			custom.Interval.Duration = time.Minute
+				custom.Interval.Duration = time.Minute
+			}
+
+			action.D = cnfg.Duration{Duration: custom.Interval.Duration}
 		}
 
+		timer.action = action
 		c.list = append(c.list, timer)
-
-		c.Add(&common.Action{
-			Key:  "TrigCustomCronTimer",
-			Name: common.TriggerName(fmt.Sprintf("Running Custom Cron Timer '%s'", custom.Name)),
-			Fn:   timer.run,
-			C:    timer.ch,
-			D:    cnfg.Duration{Duration: custom.Interval.Duration},
-		})
+		c.Add(action)
 	}
 
 	mnd.Log.Printf(reqID, "==> Custom Timers Enabled: %d timers provided", len(info.Actions.Custom))
@@ -5,13 +5,16 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Notifiarr/notifiarr/pkg/apps"
 	"github.com/Notifiarr/notifiarr/pkg/mnd"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/data"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/plexcron"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/usenet"
 	"github.com/Notifiarr/notifiarr/pkg/website"
 	"github.com/Notifiarr/notifiarr/pkg/website/clientinfo"
 	"golift.io/cnfg"
+	"golift.io/starr"
 )
 
 /* This file sends state of affairs to notifiarr.com */
@@ -24,6 +27,7 @@ const randomMilliseconds = 2500
 type Cmd struct {
 	*common.Config
 	PlexCron *plexcron.Action
+	Usenet   *usenet.Config
 	Enabled  clientinfo.DashConfig
 }
 
@@ -78,6 +82,10 @@ type State struct {
 	Artists int   `json:"artists,omitempty"`
 	Albums  int64 `json:"albums,omitempty"`
 	Tracks  int64 `json:"tracks,omitempty"`
+	// Prowlarr
+	Indexers int64        `json:"indexers,omitempty"`
+	Disabled int64        `json:"disabled,omitempty"`
+	Failing  SortableList `json:"failing,omitempty"`
 	// Downloader
 	Downloads   int   `json:"downloads,omitempty"`
 	Uploaded    int64 `json:"uploaded,omitempty"`
@@ -91,11 +99,14 @@ type State struct {
 	Month       int64 `json:"month,omitempty"`
 	Week        int64 `json:"week,omitempty"`
 	Day         int64 `json:"day,omitempty"`
+	// Usenet failure reasons and their counts in the usenet failure analysis window.
+	Failures map[string]int64 `json:"failures,omitempty"`
 }
 
 // States is our compiled states for the dashboard.
 type States struct {
 	Lidarr   []*State `json:"lidarr"`
+	Prowlarr []*State `json:"prowlarr"`
 	Radarr   []*State `json:"radarr"`
 	Readarr  []*State `json:"readarr"`
 	Sonarr   []*State `json:"sonarr"`
@@ -109,11 +120,12 @@ type States struct {
 }
 
 // New configures the library.
-func New(config *common.Config, plex *plexcron.Action) *Action {
+func New(config *common.Config, plex *plexcron.Action, usenetConfig *usenet.Config) *Action {
 	return &Action{
 		cmd: &Cmd{
 			Config:   config,
 			PlexCron: plex,
+			Usenet:   usenetConfig,
 		},
 	}
 }
@@ -173,19 +185,67 @@ func (c *Cmd) getStates(ctx context.Context) *States {
 
 	return &States{
 		Deluge:   c.getDelugeStates(ctx),
-		Lidarr:   c.getLidarrStates(ctx),
+		Lidarr:   c.getStarrStates(ctx, starr.Lidarr),
+		Prowlarr: c.getStarrStates(ctx, starr.Prowlarr),
 		Qbit:     c.getQbitStates(ctx),
 		NZBGet:   c.getNZBGetStates(ctx),
 		RTorrent: c.getRtorrentStates(ctx),
-		Radarr:   c.getRadarrStates(ctx),
-		Readarr:  c.getReadarrStates(ctx),
-		Sonarr:   c.getSonarrStates(ctx),
+		Radarr:   c.getStarrStates(ctx, starr.Radarr),
+		Readarr:  c.getStarrStates(ctx, starr.Readarr),
+		Sonarr:   c.getStarrStates(ctx, starr.Sonarr),
 		SabNZB:   c.getSabNZBStates(ctx),
 		Xmission: c.getTransmissionStates(ctx),
 		Plex:     sessions,
 	}
 }
 
+// getStarrStates grabs data for each enabled instance of a starr app.
+func (c *Cmd) getStarrStates(ctx context.Context, app starr.App) []*State {
+	states := []*State{}
+
+	if !c.Enabled.Starr(app) {
+		return states
+	}
+
+	for _, instance := range c.Apps.StarrInstances(app) {
+		if !instance.Enabled() {
+			continue
+		}
+
+		mnd.Log.Debugf(mnd.GetID(ctx), "Getting %s State: %d:%s", app, instance.Instance(), instance.Starr().URL)
+
+		state, err := c.getStarrState(ctx, instance)
+		if err != nil {
+			state.Error = err.Error()
+			mnd.Log.Errorf(mnd.GetID(ctx), "Getting %s Data from %d:%s: %v",
+				app, instance.Instance(), instance.Starr().URL, err)
+		}
+
+		states = append(states, state)
+	}
+
+	return states
+}
+
+// getStarrState passes a starr instance to the state collector for its app type.
+func (c *Cmd) getStarrState(ctx context.Context, instance apps.StarrInstance) (*State, error) {
+	switch app := instance.(type) {
+	case apps.Lidarr:
+		return c.getLidarrState(ctx, app.Instance(), &app)
+	case apps.Prowlarr:
+		return c.getProwlarrState(ctx, app.Instance(), &app)
+	case apps.Radarr:
+		return c.getRadarrState(ctx, app.Instance(), &app)
+	case apps.Readarr:
+		return c.getReadarrState(ctx, app.Instance(), &app)
+	case apps.Sonarr:
+		return c.getSonarrState(ctx, app.Instance(), &app)
+	default:
+		return &State{Instance: instance.Instance(), Name: instance.Starr().Name},
+			fmt.Errorf("%w: %s", common.ErrInvalidApp, instance.App())
+	}
+}
+
 type dateSorter []*Sortable
 
 func (s dateSorter) Len() int {
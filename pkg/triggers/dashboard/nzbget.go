@@ -9,6 +9,7 @@ import (
 
 	"github.com/Notifiarr/notifiarr/pkg/apps"
 	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/usenet"
 	"golift.io/nzbget"
 )
 
@@ -48,6 +49,8 @@ func (c *Cmd) getNZBGetState(ctx context.Context, instance int, n *apps.NZBGet)
 	state.Downloads = len(queue) + len(hist)
 	state.Next = []*Sortable{}
 	state.Latest = []*Sortable{}
+	state.Failures = map[string]int64{}
+	cutoff := c.Usenet.Cutoff(start)
 
 	for idx, xfer := range queue {
 		switch xfer.Status { //nolint:exhaustive // We only check nzbget groups.
@@ -68,6 +71,10 @@ func (c *Cmd) getNZBGetState(ctx context.Context, instance int, n *apps.NZBGet)
 	}
 
 	for _, xfer := range hist {
+		if reason, ok := usenet.ClassifyNZBGet(xfer); ok && reason != "" && xfer.HistoryTime.After(cutoff) {
+			state.Failures[reason]++
+		}
+
 		state.Latest = append(state.Latest, &Sortable{
 			Name: xfer.Name,
 			Date: xfer.HistoryTime.Time,
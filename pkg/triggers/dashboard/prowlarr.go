@@ -0,0 +1,49 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+)
+
+func (c *Cmd) getProwlarrState(ctx context.Context, instance int, p *apps.Prowlarr) (*State, error) {
+	state := &State{Instance: instance, Failing: []*Sortable{}, Name: p.Name}
+	start := time.Now()
+
+	indexers, err := p.GetIndexersWithStatusContext(ctx)
+	state.Elapsed.Duration = time.Since(start)
+
+	if err != nil {
+		return state, fmt.Errorf("getting indexers from instance %d: %w", instance, err)
+	}
+
+	processProwlarrState(state, indexers)
+	sort.Sort(sort.Reverse(dateSorter(state.Failing)))
+
+	return state, nil
+}
+
+func processProwlarrState(state *State, indexers []*apps.ProwlarrIndexer) {
+	for _, indexer := range indexers {
+		state.Indexers++
+
+		if !indexer.Enable {
+			state.Disabled++
+			continue
+		}
+
+		if indexer.Status == nil || indexer.Status.DisabledTill.Before(time.Now()) {
+			continue
+		}
+
+		state.Failing = append(state.Failing, &Sortable{
+			id:   indexer.ID,
+			Name: indexer.Name,
+			Sub:  "disabled until " + indexer.Status.DisabledTill.Format(time.RFC3339),
+			Date: indexer.Status.MostRecentFailure,
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package dashboard //nolint:testpackage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/stretchr/testify/assert"
+	"golift.io/starr/prowlarr"
+)
+
+func TestProcessProwlarrState(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	indexer := func(id int64, enable bool, status *apps.ProwlarrIndexerStatus) *apps.ProwlarrIndexer {
+		return &apps.ProwlarrIndexer{
+			IndexerOutput: &prowlarr.IndexerOutput{ID: id, Name: "indexer", Enable: enable},
+			Status:        status,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		indexers []*apps.ProwlarrIndexer
+		total    int64
+		disabled int64
+		failing  []int64
+	}{
+		{name: "none"},
+		{name: "healthy", indexers: []*apps.ProwlarrIndexer{indexer(1, true, nil)}, total: 1},
+		{name: "disabled", indexers: []*apps.ProwlarrIndexer{indexer(1, false, nil)}, total: 1, disabled: 1},
+		{
+			name:     "failure expired",
+			indexers: []*apps.ProwlarrIndexer{indexer(1, true, &apps.ProwlarrIndexerStatus{DisabledTill: now.Add(-time.Minute)})},
+			total:    1,
+		},
+		{
+			name:     "failing",
+			indexers: []*apps.ProwlarrIndexer{indexer(2, true, &apps.ProwlarrIndexerStatus{DisabledTill: now.Add(time.Hour)})},
+			total:    1,
+			failing:  []int64{2},
+		},
+		{
+			name: "disabled and failing is disabled",
+			indexers: []*apps.ProwlarrIndexer{
+				indexer(1, true, nil),
+				indexer(2, false, &apps.ProwlarrIndexerStatus{DisabledTill: now.Add(time.Hour)}),
+				indexer(3, true, &apps.ProwlarrIndexerStatus{DisabledTill: now.Add(time.Hour)}),
+			},
+			total:    3,
+			disabled: 1,
+			failing:  []int64{3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			state := &State{Failing: []*Sortable{}}
+			processProwlarrState(state, test.indexers)
+			assert.Equal(t, test.total, state.Indexers)
+			assert.Equal(t, test.disabled, state.Disabled)
+
+			failing := []int64{}
+			for _, item := range state.Failing {
+				failing = append(failing, item.id)
+				assert.Contains(t, item.Sub, "disabled until ")
+			}
+
+			assert.ElementsMatch(t, test.failing, failing)
+		})
+	}
+}
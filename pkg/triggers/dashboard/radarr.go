@@ -7,32 +7,9 @@ import (
 	"time"
 
 	"github.com/Notifiarr/notifiarr/pkg/apps"
-	"github.com/Notifiarr/notifiarr/pkg/mnd"
 	"golift.io/starr/radarr"
 )
 
-func (c *Cmd) getRadarrStates(ctx context.Context) []*State {
-	states := []*State{}
-
-	for instance, app := range c.Apps.Radarr {
-		if !app.Enabled() || !c.Enabled.Radarr {
-			continue
-		}
-
-		mnd.Log.Debugf(mnd.GetID(ctx), "Getting Radarr State: %d:%s", instance+1, app.URL)
-
-		state, err := c.getRadarrState(ctx, instance+1, &app)
-		if err != nil {
-			state.Error = err.Error()
-			mnd.Log.Errorf(mnd.GetID(ctx), "Getting Radarr Queue from %d:%s: %v", instance+1, app.URL, err)
-		}
-
-		states = append(states, state)
-	}
-
-	return states
-}
-
 func (c *Cmd) getRadarrState(ctx context.Context, instance int, r *apps.Radarr) (*State, error) {
 	state := &State{Instance: instance, Next: []*Sortable{}, Latest: []*Sortable{}, Name: r.Name}
 	start := time.Now()
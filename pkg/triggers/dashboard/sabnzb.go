@@ -9,6 +9,7 @@ import (
 
 	"github.com/Notifiarr/notifiarr/pkg/apps"
 	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/usenet"
 )
 
 func (c *Cmd) getSabNZBStates(ctx context.Context) []*State {
@@ -54,6 +55,8 @@ func (c *Cmd) getSabNZBState(ctx context.Context, instance int, s *apps.SabNZB)
 	state.Downloads = len(queue.Slots) + hist.Noofslots
 	state.Next = []*Sortable{}
 	state.Latest = []*Sortable{}
+	state.Failures = map[string]int64{}
+	cutoff := c.Usenet.Cutoff(start).Unix()
 
 	for _, xfer := range queue.Slots {
 		if strings.EqualFold(xfer.Status, "Downloading") {
@@ -72,7 +75,11 @@ func (c *Cmd) getSabNZBState(ctx context.Context, instance int, s *apps.SabNZB)
 		})
 	}
 
-	for _, xfer := range hist.Slots {
+	for idx, xfer := range hist.Slots {
+		if reason, ok := usenet.ClassifySAB(&hist.Slots[idx]); ok && reason != "" && xfer.Completed > cutoff {
+			state.Failures[reason]++
+		}
+
 		state.Latest = append(state.Latest, &Sortable{
 			Name: xfer.Name,
 			Date: time.Unix(xfer.Completed, 0).Round(time.Second).UTC(),
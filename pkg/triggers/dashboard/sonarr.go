@@ -7,33 +7,10 @@ import (
 	"time"
 
 	"github.com/Notifiarr/notifiarr/pkg/apps"
-	"github.com/Notifiarr/notifiarr/pkg/mnd"
 	"golift.io/starr"
 	"golift.io/starr/sonarr"
 )
 
-func (c *Cmd) getSonarrStates(ctx context.Context) []*State {
-	states := []*State{}
-
-	for instance, app := range c.Apps.Sonarr {
-		if !app.Enabled() || !c.Enabled.Sonarr {
-			continue
-		}
-
-		mnd.Log.Debugf(mnd.GetID(ctx), "Getting Sonarr State: %d:%s", instance+1, app.URL)
-
-		state, err := c.getSonarrState(ctx, instance+1, &app)
-		if err != nil {
-			state.Error = err.Error()
-			mnd.Log.Errorf(mnd.GetID(ctx), "Getting Sonarr Queue from %d:%s: %v", instance+1, app.URL, err)
-		}
-
-		states = append(states, state)
-	}
-
-	return states
-}
-
 func (c *Cmd) getSonarrState(ctx context.Context, instance int, app *apps.Sonarr) (*State, error) {
 	state := &State{Instance: instance, Next: []*Sortable{}, Name: app.Name}
 	start := time.Now()
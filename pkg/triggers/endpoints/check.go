@@ -0,0 +1,218 @@
+package endpoints
+
+/* This file checks endpoint responses with assertions, and extracts values from them. */
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidCheck = errors.New("invalid endpoint check")
+
+// check contains the compiled assertions and extractors for an endpoint.
+type check struct {
+	status  []int
+	body    *regexp.Regexp
+	json    []*assertion
+	extract []*extractor
+	failing bool // true if the last run failed, so changes can be reported.
+}
+
+// assertion is a JSON path assertion, like: status == ok.
+type assertion struct {
+	input string
+	path  string
+	op    string // empty checks that the path exists.
+	value string
+	re    *regexp.Regexp
+}
+
+// extractor saves a value from the response into a named variable.
+type extractor struct {
+	name string
+	path string
+	re   *regexp.Regexp
+}
+
+// newCheck compiles the assertions and extractors for an endpoint or a step. Returns nil if there are none.
+func newCheck(status []int, body string, expectJSON, extract []string) (*check, error) {
+	if len(status) == 0 && body == "" && len(expectJSON) == 0 && len(extract) == 0 {
+		return nil, nil //nolint:nilnil // no checks is not an error.
+	}
+
+	check := &check{status: status}
+
+	var err error
+
+	if body != "" {
+		if check.body, err = regexp.Compile(body); err != nil {
+			return nil, fmt.Errorf("%w: expect_body: %w", ErrInvalidCheck, err)
+		}
+	}
+
+	for _, input := range expectJSON {
+		assert, err := parseAssertion(input)
+		if err != nil {
+			return nil, err
+		}
+
+		check.json = append(check.json, assert)
+	}
+
+	for _, input := range extract {
+		extract, err := parseExtractor(input)
+		if err != nil {
+			return nil, err
+		}
+
+		check.extract = append(check.extract, extract)
+	}
+
+	return check, nil
+}
+
+// parseAssertion parses path==value, path!=value, path=~regexp or path.
+func parseAssertion(input string) (*assertion, error) {
+	assert := &assertion{input: input, path: strings.TrimSpace(input)}
+
+	for _, op := range []string{"==", "!=", "=~"} {
+		if path, value, ok := strings.Cut(input, op); ok {
+			assert.path, assert.op, assert.value = strings.TrimSpace(path), op, strings.TrimSpace(value)
+			break
+		}
+	}
+
+	if assert.path == "" {
+		return nil, fmt.Errorf("%w: expect_json '%s' has no path", ErrInvalidCheck, input)
+	}
+
+	if assert.op == "=~" {
+		var err error
+		if assert.re, err = regexp.Compile(assert.value); err != nil {
+			return nil, fmt.Errorf("%w: expect_json '%s': %w", ErrInvalidCheck, input, err)
+		}
+	}
+
+	return assert, nil
+}
+
+// parseExtractor parses name=json.path or name=~regexp. A regexp extracts its first capture group, if any.
+func parseExtractor(input string) (*extractor, error) {
+	name, path, ok := strings.Cut(input, "=")
+	if name = strings.TrimSpace(name); !ok || name == "" || strings.TrimSpace(path) == "" {
+		return nil, fmt.Errorf("%w: extract '%s' must look like name=json.path or name=~regexp", ErrInvalidCheck, input)
+	}
+
+	extract := &extractor{name: name, path: strings.TrimSpace(path)}
+
+	if pattern, ok := strings.CutPrefix(path, "~"); ok {
+		var err error
+		if extract.re, err = regexp.Compile(strings.TrimSpace(pattern)); err != nil {
+			return nil, fmt.Errorf("%w: extract '%s': %w", ErrInvalidCheck, input, err)
+		}
+	}
+
+	return extract, nil
+}
+
+// run checks a response, and returns the failed assertions and the extracted variables.
+func (c *check) run(code int, body []byte) ([]string, map[string]any) {
+	failures := []string{}
+
+	if len(c.status) > 0 && !slices.Contains(c.status, code) {
+		failures = append(failures, fmt.Sprintf("status %d is not one of %v", code, c.status))
+	}
+
+	if c.body != nil && !c.body.Match(body) {
+		failures = append(failures, "body does not match: "+c.body.String())
+	}
+
+	var data any
+	if len(c.json) > 0 || len(c.extract) > 0 {
+		_ = json.Unmarshal(body, &data) // data is nil if the body is not JSON, and JSON checks fail.
+	}
+
+	for _, assert := range c.json {
+		if !assert.check(data) {
+			failures = append(failures, "json assertion failed: "+assert.input)
+		}
+	}
+
+	if len(c.extract) == 0 {
+		return failures, nil
+	}
+
+	variables := make(map[string]any, len(c.extract))
+
+	for _, extract := range c.extract {
+		if extract.re == nil {
+			variables[extract.name], _ = jsonPath(data, extract.path)
+		} else if match := extract.re.FindSubmatch(body); len(match) > 1 {
+			variables[extract.name] = string(match[1])
+		} else if match != nil {
+			variables[extract.name] = string(match[0])
+		} else {
+			variables[extract.name] = nil
+		}
+	}
+
+	return failures, variables
+}
+
+func (a *assertion) check(data any) bool {
+	value, ok := jsonPath(data, a.path)
+
+	switch a.op {
+	case "==":
+		return ok && toString(value) == a.value
+	case "!=":
+		return !ok || toString(value) != a.value
+	case "=~":
+		return ok && a.re.MatchString(toString(value))
+	default:
+		return ok && value != nil
+	}
+}
+
+// jsonPath finds a value in decoded JSON by a dotted path. Array items use their index, like items.0.name.
+func jsonPath(data any, path string) (any, bool) {
+	for key := range strings.SplitSeq(path, ".") {
+		switch item := data.(type) {
+		case map[string]any:
+			var ok bool
+			if data, ok = item[key]; !ok {
+				return nil, false
+			}
+		case []any:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(item) {
+				return nil, false
+			}
+
+			data = item[idx]
+		default:
+			return nil, false
+		}
+	}
+
+	return data, true
+}
+
+func toString(value any) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case nil:
+		return "null"
+	default:
+		data, _ := json.Marshal(value)
+		return string(data)
+	}
+}
@@ -0,0 +1,179 @@
+package endpoints //nolint:testpackage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Notifiarr/notifiarr/pkg/triggers/endpoints/epconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAssertion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		path    string
+		op      string
+		value   string
+		invalid bool
+	}{
+		{input: "status", path: "status"},
+		{input: " status == ok ", path: "status", op: "==", value: "ok"},
+		{input: "data.count!=0", path: "data.count", op: "!=", value: "0"},
+		{input: "version =~ ^4\\.", path: "version", op: "=~", value: "^4\\."},
+		{input: "== ok", invalid: true},
+		{input: "  ", invalid: true},
+		{input: "name =~ (", invalid: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+
+			assertion, err := parseAssertion(test.input)
+			if test.invalid {
+				require.ErrorIs(t, err, ErrInvalidCheck)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.path, assertion.path)
+			assert.Equal(t, test.op, assertion.op)
+			assert.Equal(t, test.value, assertion.value)
+			assert.Equal(t, test.op == "=~", assertion.re != nil)
+		})
+	}
+}
+
+func TestJSONPath(t *testing.T) {
+	t.Parallel()
+
+	data := map[string]any{
+		"status": "ok",
+		"count":  float64(2),
+		"empty":  nil,
+		"items":  []any{map[string]any{"name": "first"}, map[string]any{"name": "second"}},
+	}
+
+	tests := []struct {
+		path  string
+		value any
+		found bool
+	}{
+		{path: "status", value: "ok", found: true},
+		{path: "count", value: float64(2), found: true},
+		{path: "empty", value: nil, found: true},
+		{path: "items.1.name", value: "second", found: true},
+		{path: "items.0", value: map[string]any{"name": "first"}, found: true},
+		{path: "missing"},
+		{path: "items.2.name"},
+		{path: "items.-1"},
+		{path: "items.name"},
+		{path: "status.length"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			t.Parallel()
+
+			value, found := jsonPath(data, test.path)
+			assert.Equal(t, test.found, found)
+			assert.Equal(t, test.value, value)
+		})
+	}
+}
+
+func TestCheckRun(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"status":"ok","version":"4.1.0","count":3,"items":[{"id":7}],"token":"abc"}`)
+
+	tests := []struct {
+		name      string
+		status    []int
+		body      string
+		json      []string
+		extract   []string
+		code      int
+		failures  int
+		variables map[string]any
+	}{
+		{name: "status ok", status: []int{200, 204}, code: 204},
+		{name: "status failed", status: []int{200}, code: 500, failures: 1},
+		{name: "body", body: `"status":"ok"`, code: 200},
+		{name: "body failed", body: `"status":"down"`, code: 200, failures: 1},
+		{
+			name: "json",
+			json: []string{"status == ok", "count == 3", "version =~ ^4\\.", "items.0.id", "status != down", "missing != x"},
+			code: 200,
+		},
+		{name: "json failed", json: []string{"status == down", "missing", "count != 3"}, code: 200, failures: 3},
+		{
+			name:      "extract",
+			extract:   []string{"token=token", "id=items.0.id", "major=~\"version\":\"(\\d+)", "whole=~\"ok\"", "none=missing"},
+			code:      200,
+			variables: map[string]any{"token": "abc", "id": float64(7), "major": "4", "whole": `"ok"`, "none": nil},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			check, err := newCheck(test.status, test.body, test.json, test.extract)
+			require.NoError(t, err)
+			require.NotNil(t, check)
+
+			failures, variables := check.run(test.code, body)
+			assert.Len(t, failures, test.failures, failures)
+			assert.Equal(t, test.variables, variables)
+		})
+	}
+
+	check, err := newCheck(nil, "", nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, check, "no checks")
+
+	check, err = newCheck(nil, "", []string{"status == ok"}, nil)
+	require.NoError(t, err)
+
+	failures, _ := check.run(200, []byte("not json"))
+	assert.Len(t, failures, 1, "json assertions fail if the body is not json")
+
+	for _, extract := range []string{"token", "=token", "token=", "bad=~("} {
+		_, err := newCheck(nil, "", nil, []string{extract})
+		require.ErrorIs(t, err, ErrInvalidCheck, extract)
+	}
+}
+
+func TestNewScheduleChecks(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"degraded","version":"1.2"}`))
+	}))
+	defer server.Close()
+
+	schedule := NewSchedule(&epconfig.Endpoint{
+		URL:        server.URL,
+		Method:     http.MethodGet,
+		ExpectJSON: []string{"status==ok"},
+		Extract:    []string{"version=version"},
+	}, nil)
+	require.NoError(t, schedule.err)
+	require.NotNil(t, schedule.check, "checks are built without Create, for the GUI test")
+
+	resp, err := schedule.Fetch(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"json assertion failed: status==ok"}, resp.Failures)
+	assert.Equal(t, map[string]any{"version": "1.2"}, resp.Extracted)
+
+	schedule = NewSchedule(&epconfig.Endpoint{URL: server.URL, ExpectJSON: []string{"== x"}}, nil)
+	require.ErrorIs(t, schedule.err, ErrInvalidCheck)
+
+	_, err = schedule.Fetch(t.Context())
+	require.ErrorIs(t, err, ErrInvalidCheck, "an invalid check is returned when it runs")
+}
@@ -11,8 +11,11 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"net/http/cookiejar"
 	"net/url"
+	"strings"
 
 	"github.com/Notifiarr/notifiarr/pkg/mnd"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
@@ -33,6 +36,12 @@ type Schedule struct {
 	ch     chan *common.ActionInput
 	client *http.Client
 	conf   *common.Config
+	check  *check
+	tmpls  *templates // parsed when Render is true.
+	steps  []*step
+	// err is returned on every run if the templates or steps failed to parse.
+	err       error
+	variables map[string]any // extracted from the last response, for templates.
 }
 
 // Schedules is a slice of Schedule.
@@ -74,6 +83,10 @@ func (a *Action) Create() {
 		schedule := NewSchedule(endpoint, a.conf)
 		a.list = append(a.list, schedule)
 
+		if schedule.err != nil {
+			mnd.Log.Errorf(reqID, "Endpoint URL '%s' will not run: %v", endpoint.Name, schedule.err)
+		}
+
 		// Schedule this cron job.
 		a.conf.Add(&common.Action{
 			Key:  "TrigEndpointURL",
@@ -89,13 +102,29 @@ func (a *Action) Create() {
 	}
 }
 
+// NewSchedule parses an endpoint's templates, checks and steps. Parse errors are returned when it runs.
 func NewSchedule(endpoint *epconfig.Endpoint, conf *common.Config) *Schedule {
-	return &Schedule{
+	schedule := &Schedule{
 		conf:     conf,
 		Endpoint: endpoint,
 		ch:       make(chan *common.ActionInput, 1),
 		client:   endpoint.GetClient(),
 	}
+
+	if endpoint.Render {
+		schedule.tmpls, schedule.err = parseTemplates(endpoint.URL, endpoint.Body, endpoint.Header, endpoint.Query)
+	}
+
+	if schedule.err == nil {
+		schedule.check, schedule.err = newCheck(endpoint.ExpectStatus, endpoint.ExpectBody,
+			endpoint.ExpectJSON, endpoint.Extract)
+	}
+
+	if schedule.err == nil {
+		schedule.steps, schedule.err = parseSteps(endpoint.Steps)
+	}
+
+	return schedule
 }
 
 // Get a schedule by name or URL.
@@ -120,10 +149,30 @@ func (s *Schedule) Run(input *common.ActionInput) {
 
 // run responds to the channel that Run() fired into.
 func (s *Schedule) run(ctx context.Context, input *common.ActionInput) {
-	header, code, body, err := s.getURLBody(ctx)
+	resp, err := s.Fetch(ctx)
 	if err != nil {
 		mnd.Log.Errorf(input.ReqID, "Endpoint URL '%s' failed: %v", s.Name, err)
-		return
+
+		if s.check == nil {
+			return
+		}
+	}
+
+	payload := map[string]any{
+		"name":     s.Name,
+		"url":      s.URL,
+		"template": s.Template,
+		"gzb64":    encode(resp.Body),
+		"header":   resp.Header,
+		"status":   resp.Status,
+	}
+
+	if len(resp.Steps) > 0 {
+		payload["steps"] = resp.Steps
+	}
+
+	if s.check != nil {
+		s.checkResponse(input, payload, resp, err)
 	}
 
 	if s.Template == mnd.False {
@@ -135,41 +184,137 @@ func (s *Schedule) run(ctx context.Context, input *common.ActionInput) {
 		Route:      website.EndpointRoute,
 		Event:      input.Type,
 		LogPayload: true,
-		Payload: map[string]any{
-			"name":     s.Name,
-			"url":      s.URL,
-			"template": s.Template,
-			"gzb64":    body,
-			"header":   header,
-			"status":   code,
-		},
+		Payload:    payload,
 	})
 }
 
-func (s *Schedule) getURLBody(ctx context.Context) (http.Header, int, string, error) {
-	req, err := http.NewRequestWithContext(ctx, s.Method, s.GetURL(), bytes.NewBufferString(s.Body))
+// checkResponse runs the endpoint's assertions and extractors, and adds the results to the payload.
+// A request error is a failure.
+func (s *Schedule) checkResponse(input *common.ActionInput, payload map[string]any, resp *Response, err error) {
+	var (
+		failures  = []string{}
+		variables map[string]any
+	)
+
 	if err != nil {
-		return nil, 0, "", fmt.Errorf("creating request: %w", err)
+		failures = append(failures, err.Error())
+	} else {
+		failures, variables = resp.Failures, resp.Extracted
+		s.variables = variables
 	}
 
-	s.SetHeaders(req)
+	success := len(failures) == 0
+	changed := success == s.check.failing
+	s.check.failing = !success
+
+	payload["success"] = success
+	payload["failures"] = failures
+	payload["changed"] = changed
+
+	if variables != nil {
+		payload["variables"] = variables
+	}
 
-	resp, err := s.client.Do(req)
+	switch {
+	case !success:
+		mnd.Log.Errorf(input.ReqID, "Endpoint URL '%s' check failed: %s", s.Name, strings.Join(failures, "; "))
+	case changed:
+		mnd.Log.Printf(input.ReqID, "Endpoint URL '%s' check recovered.", s.Name)
+	}
+}
+
+// encode gzips and base64 encodes a response body for the website.
+func encode(body []byte) string {
+	var buf bytes.Buffer
+
+	gzwriter := gzip.NewWriter(&buf)
+	_, _ = gzwriter.Write(body)
+	_ = gzwriter.Close()
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+// Response is an endpoint's response, and the results of its steps.
+type Response struct {
+	Header    http.Header
+	Status    int
+	Body      []byte
+	Steps     []*StepResult
+	Variables map[string]any // extracted by the steps.
+	Failures  []string       // failed assertions, if the endpoint has checks.
+	Extracted map[string]any // extracted from the response, if the endpoint has checks.
+}
+
+// Fetch runs the endpoint's steps, then requests the endpoint, and runs its checks on the response.
+// Cookies are kept between the requests. The response is never nil, and contains the step results if a step failed.
+func (s *Schedule) Fetch(ctx context.Context) (*Response, error) {
+	resp := &Response{Variables: maps.Clone(s.variables)}
+	if s.err != nil {
+		return resp, s.err
+	}
+
+	if resp.Variables == nil {
+		resp.Variables = make(map[string]any)
+	}
+
+	client := s.client
+	if len(s.steps) > 0 {
+		withJar := *s.client
+		withJar.Jar, _ = cookiejar.New(nil) // never returns an error.
+		client = &withJar
+	}
+
+	var err error
+	if resp.Steps, err = s.runSteps(ctx, client, resp.Variables); err != nil {
+		return resp, err
+	}
+
+	req, err := s.request(ctx, resp.Variables)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.Header, resp.Status, resp.Body, err = do(client, req); err != nil {
+		return resp, err
+	}
+
+	if s.check != nil {
+		resp.Failures, resp.Extracted = s.check.run(resp.Status, resp.Body)
+	}
+
+	return resp, nil
+}
+
+// do makes a request and reads the response.
+func do(client *http.Client, req *http.Request) (http.Header, int, []byte, error) {
+	resp, err := client.Do(req)
 	if err != nil {
-		return nil, 0, "", fmt.Errorf("making request: %w", err)
+		return nil, 0, nil, fmt.Errorf("making request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	var body bytes.Buffer
-	gzwriter := gzip.NewWriter(&body)
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("reading response body: %w", err)
+	}
 
-	if _, err = io.Copy(gzwriter, resp.Body); err != nil {
-		return nil, 0, "", fmt.Errorf("reading response body: %w", err)
+	return resp.Header, resp.StatusCode, body, nil
+}
+
+// request creates the request for the endpoint, from templates if Render is enabled.
+func (s *Schedule) request(ctx context.Context, variables map[string]any) (*http.Request, error) {
+	if s.tmpls != nil {
+		return s.tmpls.request(ctx, s.Method, s.renderData(variables))
 	}
 
-	_ = gzwriter.Close()
+	req, err := http.NewRequestWithContext(ctx, s.Method, s.GetURL(), bytes.NewBufferString(s.Body))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	s.SetHeaders(req)
 
-	return resp.Header, resp.StatusCode, base64.StdEncoding.EncodeToString(body.Bytes()), nil
+	return req, nil
 }
 
 // Verify the interface is satisfied.
@@ -13,6 +13,8 @@ import (
 
 // Endpoint contains the cronjob definition and url query parameters.
 // This is the input data to poll a url on a frequency.
+// The Expect fields make the endpoint a check: each run succeeds or fails, and changes are reported.
+// Render makes the request dynamic, with access to time, environment, secrets, app data and step variables.
 type Endpoint struct {
 	Query    url.Values    `json:"query"    toml:"query"     xml:"query"     yaml:"query"`
 	Header   http.Header   `json:"header"   toml:"header"    xml:"header"    yaml:"header"`
@@ -24,10 +26,43 @@ type Endpoint struct {
 	Follow   bool          `json:"follow"   toml:"follow"    xml:"follow"    yaml:"follow"`   // redirects
 	ValidSSL bool          `json:"validSsl" toml:"valid_ssl" xml:"valid_ssl" yaml:"validSsl"` // https only
 	Timeout  cnfg.Duration `json:"timeout"  toml:"timeout"   xml:"timeout"   yaml:"timeout"`
-	url      string        // url + query
+	// ExpectStatus is a list of allowed response status codes.
+	ExpectStatus []int `json:"expectStatus,omitempty" toml:"expect_status" xml:"expect_status" yaml:"expectStatus"`
+	// ExpectBody is a regular expression the response body must match.
+	ExpectBody string `json:"expectBody,omitempty" toml:"expect_body" xml:"expect_body" yaml:"expectBody"`
+	// ExpectJSON is a list of assertions on the JSON response: path==value, path!=value, path=~regexp,
+	// or a path that must exist. Paths use dots, and array indexes, like: data.items.0.status.
+	ExpectJSON []string `json:"expectJson,omitempty" toml:"expect_json" xml:"expect_json" yaml:"expectJson"`
+	// Extract is a list of name=json.path or name=~regexp. The values are sent to the website as variables.
+	Extract []string `json:"extract,omitempty" toml:"extract" xml:"extract" yaml:"extract"`
+	// Render parses the url, body, header values and query values as Go templates, and renders them on every run.
+	Render bool `json:"render,omitempty" toml:"render" xml:"render" yaml:"render"`
+	// Steps are requests that run in order before this endpoint's request, like a login that returns a token.
+	Steps []*Step `json:"steps,omitempty" toml:"step" xml:"step" yaml:"steps"`
+	url   string  // url + query
 	scheduler.CronJob
 }
 
+// Step is one request in an endpoint workflow. The url, body, header values and query values are
+// always templates, and may use .Variables extracted by earlier steps. Cookies are kept between steps.
+type Step struct {
+	Name   string      `json:"name"   toml:"name"   xml:"name"   yaml:"name"`
+	URL    string      `json:"url"    toml:"url"    xml:"url"    yaml:"url"`
+	Method string      `json:"method" toml:"method" xml:"method" yaml:"method"`
+	Body   string      `json:"body"   toml:"body"   xml:"body"   yaml:"body"`
+	Header http.Header `json:"header" toml:"header" xml:"header" yaml:"header"`
+	Query  url.Values  `json:"query"  toml:"query"  xml:"query"  yaml:"query"`
+	// When is a template condition. The step is skipped if it renders empty, false or 0.
+	When string `json:"when,omitempty" toml:"when" xml:"when" yaml:"when"`
+	// OnError is stop (default) or continue. A step fails if the request fails, an expectation fails,
+	// or the response status is 400 or more and ExpectStatus is empty. Stopping skips the endpoint request.
+	OnError      string   `json:"onError,omitempty"      toml:"on_error"      xml:"on_error"      yaml:"onError"`
+	ExpectStatus []int    `json:"expectStatus,omitempty" toml:"expect_status" xml:"expect_status" yaml:"expectStatus"`
+	ExpectBody   string   `json:"expectBody,omitempty"   toml:"expect_body"   xml:"expect_body"   yaml:"expectBody"`
+	ExpectJSON   []string `json:"expectJson,omitempty"   toml:"expect_json"   xml:"expect_json"   yaml:"expectJson"`
+	Extract      []string `json:"extract,omitempty"      toml:"extract"       xml:"extract"       yaml:"extract"`
+}
+
 func (e *Endpoint) GetClient() *http.Client {
 	return &http.Client{
 		Timeout: e.Timeout.Duration,
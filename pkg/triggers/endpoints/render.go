@@ -0,0 +1,198 @@
+package endpoints
+
+/* This file renders endpoint urls, bodies, headers and query values as Go templates. */
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/services"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/data"
+)
+
+// templates contains the parsed templates for an endpoint or a step.
+type templates struct {
+	url    *template.Template
+	body   *template.Template
+	header map[string][]*template.Template
+	query  map[string][]*template.Template
+}
+
+// RenderData is the data available to endpoint templates.
+type RenderData struct {
+	// Name is the endpoint name.
+	Name string
+	// Snapshot is the last system snapshot, if one was taken.
+	Snapshot any
+	// Dashboard is the last dashboard state, if it was collected.
+	Dashboard any
+	// Services are the current service check results.
+	Services []*services.CheckResult
+	// Variables are the values extracted by earlier steps, and from this endpoint's last response.
+	Variables map[string]any
+}
+
+// resultser is satisfied by the services package.
+type resultser interface {
+	GetResults() []*services.CheckResult
+}
+
+// templateFuncs are available in endpoint templates, along with the built-in functions.
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"now":   time.Now,
+		"since": time.Since,
+		"env":   os.Getenv,
+		"trim":  strings.TrimSpace,
+		// secret reads a file, like a docker secret, and returns its contents without surrounding whitespace.
+		"secret": func(path string) (string, error) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return "", fmt.Errorf("reading secret: %w", err)
+			}
+
+			return strings.TrimSpace(string(data)), nil
+		},
+		// json encodes a value, so it may be placed in a JSON body. Strings are quoted.
+		"json": func(v any) (string, error) {
+			data, err := json.Marshal(v)
+			if err != nil {
+				return "", fmt.Errorf("encoding json: %w", err)
+			}
+
+			return string(data), nil
+		},
+	}
+}
+
+// parseTemplates parses the url, body, header values and query values of an endpoint or a step.
+func parseTemplates(uri, body string, header http.Header, query url.Values) (*templates, error) {
+	var (
+		tmpls = &templates{
+			header: make(map[string][]*template.Template),
+			query:  make(map[string][]*template.Template),
+		}
+		err error
+	)
+
+	if tmpls.url, err = template.New("url").Funcs(templateFuncs()).Parse(uri); err != nil {
+		return nil, fmt.Errorf("parsing url template: %w", err)
+	}
+
+	if tmpls.body, err = template.New("body").Funcs(templateFuncs()).Parse(body); err != nil {
+		return nil, fmt.Errorf("parsing body template: %w", err)
+	}
+
+	for name, values := range header {
+		if tmpls.header[name], err = parseValues("header "+name, values); err != nil {
+			return nil, err
+		}
+	}
+
+	for name, values := range query {
+		if tmpls.query[name], err = parseValues("query "+name, values); err != nil {
+			return nil, err
+		}
+	}
+
+	return tmpls, nil
+}
+
+func parseValues(name string, values []string) ([]*template.Template, error) {
+	tmpls := make([]*template.Template, len(values))
+
+	for idx, value := range values {
+		var err error
+		if tmpls[idx], err = template.New(name).Funcs(templateFuncs()).Parse(value); err != nil {
+			return nil, fmt.Errorf("parsing %s template: %w", name, err)
+		}
+	}
+
+	return tmpls, nil
+}
+
+// renderData collects the data for the endpoint's templates.
+func (s *Schedule) renderData(variables map[string]any) *RenderData {
+	render := &RenderData{Name: s.Name, Variables: variables}
+
+	if item := data.Get("snapshot"); item != nil {
+		render.Snapshot = item.Data
+	}
+
+	if item := data.Get("dashboard"); item != nil {
+		render.Dashboard = item.Data
+	}
+
+	if s.conf != nil {
+		if svcs, ok := s.conf.Services.(resultser); ok {
+			render.Services = svcs.GetResults()
+		}
+	}
+
+	return render
+}
+
+// request renders the templates and creates the request.
+func (t *templates) request(ctx context.Context, method string, render *RenderData) (*http.Request, error) {
+	uri, err := execute(t.url, render)
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	if err := t.body.Execute(&body, render); err != nil {
+		return nil, fmt.Errorf("rendering body: %w", err)
+	}
+
+	query := make(url.Values)
+
+	for name, tmpls := range t.query {
+		for _, tmpl := range tmpls {
+			value, err := execute(tmpl, render)
+			if err != nil {
+				return nil, err
+			}
+
+			query.Add(name, value)
+		}
+	}
+
+	if len(query) > 0 {
+		uri += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, uri, &body)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	for name, tmpls := range t.header {
+		for _, tmpl := range tmpls {
+			value, err := execute(tmpl, render)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set(name, value)
+		}
+	}
+
+	return req, nil
+}
+
+func execute(tmpl *template.Template, render *RenderData) (string, error) {
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, render); err != nil {
+		return "", fmt.Errorf("rendering %s: %w", tmpl.Name(), err)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,136 @@
+package endpoints //nolint:testpackage
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Notifiarr/notifiarr/pkg/triggers/endpoints/epconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	t.Parallel()
+
+	secret := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(secret, []byte("  s3cret\n"), 0o600))
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+		err      bool
+	}{
+		{name: "secret", template: `{{ secret "` + secret + `" }}`, expected: "s3cret"},
+		{name: "missing secret", template: `{{ secret "` + secret + `.missing" }}`, err: true},
+		{name: "env", template: `{{ env "PATH" }}`, expected: os.Getenv("PATH")},
+		{name: "missing env", template: `{{ env "NOTIFIARR_TEST_UNSET_VARIABLE" }}`, expected: ""},
+		{name: "trim", template: `{{ trim "  x  " }}`, expected: "x"},
+		{name: "json string", template: `{{ json .Name }}`, expected: `"say \"hi\""`},
+		{name: "json map", template: `{{ json .Variables }}`, expected: `{"count":2,"token":"abc"}`},
+		{name: "json unsupported", template: `{{ json .Snapshot }}`, err: true},
+	}
+
+	render := &RenderData{
+		Name:      `say "hi"`,
+		Variables: map[string]any{"token": "abc", "count": 2},
+		Snapshot:  func() {},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpls, err := parseTemplates(test.template, "", nil, nil)
+			require.NoError(t, err)
+
+			output, err := execute(tmpls.url, render)
+			if test.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, output)
+		})
+	}
+}
+
+func TestParseTemplates(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		uri    string
+		body   string
+		header http.Header
+		query  url.Values
+	}{
+		"url":    {uri: "{{ .Name "},
+		"body":   {body: "{{ end }}"},
+		"header": {header: http.Header{"X-Token": {"ok", "{{ nope }}"}}},
+		"query":  {query: url.Values{"q": {"{{"}}},
+	}
+
+	for name, test := range tests {
+		_, err := parseTemplates(test.uri, test.body, test.header, test.query)
+		require.ErrorContains(t, err, "parsing "+name, "the error names the template that failed")
+	}
+}
+
+func TestTemplatesRequest(t *testing.T) {
+	t.Parallel()
+
+	tmpls, err := parseTemplates(
+		"http://localhost/{{ .Name }}",
+		`{"token":{{ json .Variables.token }}}`,
+		http.Header{"Authorization": {"Bearer {{ .Variables.token }}"}},
+		url.Values{"user": {"{{ .Variables.user }}"}, "static": {"1", "2"}},
+	)
+	require.NoError(t, err)
+
+	render := &RenderData{Name: "api", Variables: map[string]any{"token": "abc", "user": "me & you"}}
+	req, err := tmpls.request(t.Context(), http.MethodPost, render)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, "/api", req.URL.Path)
+	assert.Equal(t, url.Values{"user": {"me & you"}, "static": {"1", "2"}}, req.URL.Query(), "query values are encoded")
+	assert.Equal(t, "Bearer abc", req.Header.Get("Authorization"))
+
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"token":"abc"}`, string(body))
+
+	render.Variables = nil
+	req, err = tmpls.request(t.Context(), http.MethodGet, render)
+	require.NoError(t, err, "a missing variable is not an error")
+	assert.Equal(t, "Bearer <no value>", req.Header.Get("Authorization"))
+}
+
+func TestScheduleRequest(t *testing.T) {
+	t.Parallel()
+
+	endpoint := &epconfig.Endpoint{
+		Name:   "render",
+		URL:    "http://localhost/{{ .Variables.path }}",
+		Method: http.MethodGet,
+		Header: http.Header{"X-Name": {"{{ .Name }}"}},
+		Render: true,
+	}
+
+	req, err := NewSchedule(endpoint, nil).request(t.Context(), map[string]any{"path": "status"})
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost/status", req.URL.String())
+	assert.Equal(t, "render", req.Header.Get("X-Name"))
+
+	endpoint.Render = false
+	req, err = NewSchedule(endpoint, nil).request(t.Context(), map[string]any{"path": "status"})
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost/%7B%7B%20.Variables.path%20%7D%7D", req.URL.String(),
+		"templates are not rendered unless render is enabled")
+	assert.Equal(t, "{{ .Name }}", req.Header.Get("X-Name"))
+}
@@ -0,0 +1,155 @@
+package endpoints
+
+/* This file runs workflow steps: requests that run before an endpoint's request, like a login. */
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/Notifiarr/notifiarr/pkg/triggers/endpoints/epconfig"
+)
+
+// Step error handling, the OnError setting.
+const (
+	OnErrorStop     = "stop"
+	OnErrorContinue = "continue"
+)
+
+var (
+	ErrInvalidStep = errors.New("invalid endpoint step")
+	ErrStepFailed  = errors.New("endpoint step failed")
+)
+
+// step is a parsed workflow step.
+type step struct {
+	*epconfig.Step
+	tmpls *templates
+	when  *template.Template
+	check *check
+}
+
+// StepResult is the result of one step, sent to the website with the endpoint's response.
+type StepResult struct {
+	Name     string   `json:"name"`
+	Status   int      `json:"status"`
+	Skipped  bool     `json:"skipped,omitempty"`
+	Failures []string `json:"failures,omitempty"`
+}
+
+// parseSteps parses the templates, conditions and checks for an endpoint's steps.
+func parseSteps(configs []*epconfig.Step) ([]*step, error) {
+	steps := make([]*step, 0, len(configs))
+
+	for idx, config := range configs {
+		if config.Name == "" {
+			config.Name = "step " + strconv.Itoa(idx+1)
+		}
+
+		if config.URL == "" {
+			return nil, fmt.Errorf("%w: %s has no url", ErrInvalidStep, config.Name)
+		}
+
+		switch strings.ToLower(config.OnError) {
+		case "", OnErrorStop, OnErrorContinue:
+		default:
+			return nil, fmt.Errorf("%w: %s: on_error must be %s or %s", ErrInvalidStep, config.Name, OnErrorStop, OnErrorContinue)
+		}
+
+		var (
+			step = &step{Step: config}
+			err  error
+		)
+
+		if step.tmpls, err = parseTemplates(config.URL, config.Body, config.Header, config.Query); err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidStep, config.Name, err)
+		}
+
+		if config.When != "" {
+			if step.when, err = template.New("when").Funcs(templateFuncs()).Parse(config.When); err != nil {
+				return nil, fmt.Errorf("%w: %s: parsing when template: %w", ErrInvalidStep, config.Name, err)
+			}
+		}
+
+		if step.check, err = newCheck(config.ExpectStatus, config.ExpectBody, config.ExpectJSON, config.Extract); err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidStep, config.Name, err)
+		}
+
+		steps = append(steps, step)
+	}
+
+	return steps, nil
+}
+
+// runSteps runs the steps in order, and adds their extracted values to variables.
+// Returns an error if a step fails, and its on_error setting is not continue.
+func (s *Schedule) runSteps(ctx context.Context, client *http.Client, variables map[string]any) ([]*StepResult, error) {
+	results := make([]*StepResult, 0, len(s.steps))
+
+	for _, step := range s.steps {
+		result := step.run(ctx, client, s.renderData(variables), variables)
+		results = append(results, result)
+
+		if len(result.Failures) > 0 && !strings.EqualFold(step.OnError, OnErrorContinue) {
+			return results, fmt.Errorf("%w: %s: %s", ErrStepFailed, step.Name, strings.Join(result.Failures, "; "))
+		}
+	}
+
+	return results, nil
+}
+
+func (s *step) run(ctx context.Context, client *http.Client, render *RenderData, variables map[string]any) *StepResult {
+	result := &StepResult{Name: s.Name}
+
+	if s.when != nil {
+		value, err := execute(s.when, render)
+		if err != nil {
+			result.Failures = append(result.Failures, err.Error())
+			return result
+		}
+
+		if !truthy(value) {
+			result.Skipped = true
+			return result
+		}
+	}
+
+	req, err := s.tmpls.request(ctx, s.Method, render)
+	if err != nil {
+		result.Failures = append(result.Failures, err.Error())
+		return result
+	}
+
+	_, code, body, err := do(client, req)
+	if result.Status = code; err != nil {
+		result.Failures = append(result.Failures, err.Error())
+		return result
+	}
+
+	if len(s.ExpectStatus) == 0 && code >= http.StatusBadRequest {
+		result.Failures = append(result.Failures, "status "+strconv.Itoa(code))
+	}
+
+	if s.check != nil {
+		failures, extracted := s.check.run(code, body)
+		result.Failures = append(result.Failures, failures...)
+		maps.Copy(variables, extracted)
+	}
+
+	return result
+}
+
+// truthy returns false for a rendered condition that is empty, false, 0 or a missing value.
+func truthy(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "false", "0", "<no value>":
+		return false
+	default:
+		return true
+	}
+}
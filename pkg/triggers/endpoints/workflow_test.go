@@ -0,0 +1,111 @@
+package endpoints //nolint:testpackage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Notifiarr/notifiarr/pkg/triggers/endpoints/epconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTruthy(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]bool{
+		"":           false,
+		"  ":         false,
+		"false":      false,
+		"FALSE":      false,
+		"0":          false,
+		"<no value>": false,
+		"true":       true,
+		"1":          true,
+		"yes":        true,
+		"00":         true,
+	}
+
+	for value, want := range tests {
+		assert.Equal(t, want, truthy(value), value)
+	}
+}
+
+func TestParseSteps(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		step    *epconfig.Step
+		invalid bool
+	}{
+		{name: "step 1", step: &epconfig.Step{URL: "http://localhost"}},
+		{name: "login", step: &epconfig.Step{Name: "login", URL: "http://localhost", OnError: "Continue"}},
+		{name: "step 1", step: &epconfig.Step{URL: "http://localhost", When: "{{ .Variables.token }}"}},
+		{name: "no url", step: &epconfig.Step{}, invalid: true},
+		{name: "on error", step: &epconfig.Step{URL: "http://localhost", OnError: "retry"}, invalid: true},
+		{name: "url template", step: &epconfig.Step{URL: "http://{{ .Name"}, invalid: true},
+		{name: "when template", step: &epconfig.Step{URL: "http://localhost", When: "{{ if }}"}, invalid: true},
+		{name: "check", step: &epconfig.Step{URL: "http://localhost", ExpectJSON: []string{"== x"}}, invalid: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			steps, err := parseSteps([]*epconfig.Step{test.step})
+			if test.invalid {
+				require.ErrorIs(t, err, ErrInvalidStep)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, steps, 1)
+			assert.Equal(t, test.name, steps[0].Name, "unnamed steps are numbered")
+			assert.Equal(t, test.step.When != "", steps[0].when != nil)
+		})
+	}
+}
+
+func TestStepRun(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			_, _ = w.Write([]byte(`{"token":"abc"}`))
+		case "/data":
+			if r.Header.Get("Authorization") != "Bearer abc" {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		}
+	}))
+	defer server.Close()
+
+	steps, err := parseSteps([]*epconfig.Step{
+		{Name: "login", URL: server.URL + "/login", Extract: []string{"token=token"}},
+		{
+			Name:   "data",
+			URL:    server.URL + "/data",
+			When:   "{{ .Variables.token }}",
+			Header: http.Header{"Authorization": {"Bearer {{ .Variables.token }}"}},
+		},
+		{Name: "skipped", URL: server.URL + "/data", When: "{{ .Variables.missing }}"},
+		{Name: "unauthorized", URL: server.URL + "/data"},
+	})
+	require.NoError(t, err)
+
+	variables := map[string]any{}
+	results := make([]*StepResult, len(steps))
+
+	for idx, step := range steps {
+		results[idx] = step.run(t.Context(), server.Client(), &RenderData{Variables: variables}, variables)
+	}
+
+	assert.Equal(t, map[string]any{"token": "abc"}, variables, "the login step extracts the token")
+	assert.Equal(t, &StepResult{Name: "login", Status: http.StatusOK}, results[0])
+	assert.Equal(t, &StepResult{Name: "data", Status: http.StatusOK}, results[1], "the token is used by the next step")
+	assert.Equal(t, &StepResult{Name: "skipped", Skipped: true}, results[2])
+	assert.Equal(t, &StepResult{Name: "unauthorized", Status: http.StatusUnauthorized, Failures: []string{"status 401"}},
+		results[3])
+}
@@ -0,0 +1,106 @@
+package filewatch
+
+/* This file runs actions when a line matches: a command, an endpoint or a trigger. */
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"github.com/Notifiarr/notifiarr/pkg/website"
+)
+
+// Action types.
+const (
+	ActionCommand  = "command"
+	ActionEndpoint = "endpoint"
+	ActionTrigger  = "trigger"
+)
+
+var (
+	ErrInvalidAction = errors.New("invalid action")
+	ErrNoRunner      = errors.New("actions are not available")
+)
+
+// Runner runs an action for a match. The kind is command, endpoint or trigger, and
+// the name is the command name or hash, the endpoint name, or the trigger name with optional /content.
+// The triggers package provides this, because this package cannot import it.
+type Runner func(input *common.ActionInput, kind, name string) error
+
+// action is a parsed action from the watcher's config.
+type action struct {
+	kind string
+	name string
+}
+
+func (a *action) String() string {
+	return a.kind + ":" + a.name
+}
+
+// setupActions parses the actions. Each one looks like command:restart-plex.
+func (w *WatchFile) setupActions() error {
+	w.actions = make([]*action, 0, len(w.Actions))
+
+	for _, input := range w.Actions {
+		kind, name, _ := strings.Cut(strings.TrimSpace(input), ":")
+		kind, name = strings.ToLower(strings.TrimSpace(kind)), strings.TrimSpace(name)
+
+		switch {
+		case name == "":
+			return fmt.Errorf("%w: '%s' has no name, ignored: %s", ErrInvalidAction, input, w.Path)
+		case kind != ActionCommand && kind != ActionEndpoint && kind != ActionTrigger:
+			return fmt.Errorf("%w: '%s' must begin with command:, endpoint: or trigger:, ignored: %s",
+				ErrInvalidAction, input, w.Path)
+		}
+
+		w.actions = append(w.actions, &action{kind: kind, name: name})
+	}
+
+	return nil
+}
+
+// runActions runs the watcher's actions in the background, so the watcher is not blocked.
+// Capture groups from the regexp are passed as command arguments. Only used in the tailFiles go routine.
+func (c *cmd) runActions(reqID string, tail *WatchFile, text string) {
+	if len(tail.actions) == 0 {
+		return
+	}
+
+	if !tail.throttle.allowActions() {
+		mnd.FileWatcher.Add(tail.Path+" Actions Dropped", 1)
+		return // rate limited.
+	}
+
+	var args []string
+	if tail.re != nil {
+		if groups := tail.re.FindStringSubmatch(text); len(groups) > 1 {
+			args = groups[1:]
+		}
+	}
+
+	for _, action := range tail.actions {
+		go c.runAction(&common.ActionInput{Type: website.EventFile, ReqID: reqID, Args: args}, tail, action)
+	}
+}
+
+func (c *cmd) runAction(input *common.ActionInput, tail *WatchFile, action *action) {
+	defer mnd.Log.CapturePanic()
+
+	mnd.FileWatcher.Add(tail.Path+" Actions", 1)
+
+	err := ErrNoRunner
+	if c.runner != nil {
+		err = c.runner(input, action.kind, action.name)
+	}
+
+	if err != nil {
+		mnd.FileWatcher.Add(tail.Path+" Action Errors", 1)
+		mnd.Log.Errorf(input.ReqID, "File Watcher %s: action %s failed: %v", tail.Path, action, err)
+
+		return
+	}
+
+	mnd.Log.Printf(input.ReqID, "File Watcher %s: ran action %s, args: %q", tail.Path, action, input.Args)
+}
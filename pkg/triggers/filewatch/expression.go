@@ -0,0 +1,432 @@
+package filewatch
+
+/* This file parses and evaluates field expressions, like: level == "error" && logger =~ 'Import'. */
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// node is one part of a parsed expression.
+type node interface {
+	eval(fields map[string]any) bool
+}
+
+type (
+	orNode    []node
+	andNode   []node
+	notNode   struct{ node }
+	fieldNode string // a bare field is true if it exists and is not empty, zero or false.
+	cmpNode   struct {
+		field string
+		op    string
+		value any // string, float64, bool or nil.
+		re    *regexp.Regexp
+	}
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// parseExpression compiles an expression. Supported operators are
+// == != =~ !~ < <= > >= && || ! and parentheses.
+func parseExpression(input string) (node, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &parser{tokens: tokens}
+
+	expr, err := parser.or()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := parser.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("%w: unexpected '%s' at position %d", ErrInvalidExpression, tok.text, tok.pos)
+	}
+
+	return expr, nil
+}
+
+func lex(input string) ([]token, error) { //nolint:cyclop
+	tokens := []token{}
+
+	for pos := 0; pos < len(input); {
+		char := rune(input[pos])
+
+		switch {
+		case unicode.IsSpace(char):
+			pos++
+		case char == '"' || char == '\'':
+			value, size, ok := quoted(input[pos:])
+			if !ok {
+				return nil, fmt.Errorf("%w: unterminated string at position %d", ErrInvalidExpression, pos)
+			}
+
+			tokens = append(tokens, token{kind: tokString, text: value, pos: pos})
+			pos += size
+		case unicode.IsDigit(char) || (char == '-' && pos+1 < len(input) && unicode.IsDigit(rune(input[pos+1]))):
+			end := pos + 1
+			for end < len(input) && strings.ContainsRune("0123456789.eE", rune(input[end])) {
+				end++
+			}
+
+			tokens = append(tokens, token{kind: tokNumber, text: input[pos:end], pos: pos})
+			pos = end
+		case isIdent(char):
+			end := pos + 1
+			for end < len(input) && (isIdent(rune(input[end])) || unicode.IsDigit(rune(input[end])) ||
+				strings.ContainsRune(".-/", rune(input[end]))) {
+				end++
+			}
+
+			tokens = append(tokens, token{kind: tokIdent, text: input[pos:end], pos: pos})
+			pos = end
+		default:
+			op := operator(input[pos:])
+			if op == "" {
+				return nil, fmt.Errorf("%w: unexpected '%c' at position %d", ErrInvalidExpression, char, pos)
+			}
+
+			tokens = append(tokens, token{kind: tokOp, text: op, pos: pos})
+			pos += len(op)
+		}
+	}
+
+	return append(tokens, token{kind: tokEOF, text: "end of expression", pos: len(input)}), nil
+}
+
+// quoted returns the quoted string at the start of the input, and its length with the quotes.
+// Double-quoted strings may contain escapes. Single-quoted strings are literal, which suits regexps.
+func quoted(input string) (string, int, bool) {
+	quote := input[0]
+
+	for idx := 1; idx < len(input); idx++ {
+		switch {
+		case input[idx] == '\\' && quote == '"':
+			idx++
+		case input[idx] != quote:
+		case quote == '\'':
+			return input[1:idx], idx + 1, true
+		default:
+			value, err := strconv.Unquote(input[:idx+1])
+			return value, idx + 1, err == nil
+		}
+	}
+
+	return "", 0, false
+}
+
+func isIdent(char rune) bool {
+	return unicode.IsLetter(char) || char == '_' || char == '@'
+}
+
+// operator returns the operator at the start of the input, or an empty string.
+func operator(input string) string {
+	for _, op := range []string{"==", "!=", "=~", "!~", "<=", ">=", "&&", "||", "<", ">", "!", "(", ")"} {
+		if strings.HasPrefix(input, op) {
+			return op
+		}
+	}
+
+	return ""
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+
+	return tok
+}
+
+// or parses: and [|| and]...
+func (p *parser) or() (node, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := orNode{left}
+
+	for p.peek().text == "||" && p.peek().kind == tokOp {
+		p.next()
+
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, right)
+	}
+
+	if len(nodes) == 1 {
+		return left, nil
+	}
+
+	return nodes, nil
+}
+
+// and parses: unary [&& unary]...
+func (p *parser) and() (node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := andNode{left}
+
+	for p.peek().text == "&&" && p.peek().kind == tokOp {
+		p.next()
+
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, right)
+	}
+
+	if len(nodes) == 1 {
+		return left, nil
+	}
+
+	return nodes, nil
+}
+
+// unary parses: !unary, (or) or a comparison.
+func (p *parser) unary() (node, error) {
+	tok := p.next()
+
+	switch {
+	case tok.kind == tokOp && tok.text == "!":
+		expr, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		return notNode{expr}, nil
+	case tok.kind == tokOp && tok.text == "(":
+		expr, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+
+		if end := p.next(); end.kind != tokOp || end.text != ")" {
+			return nil, fmt.Errorf("%w: expected ')' at position %d", ErrInvalidExpression, end.pos)
+		}
+
+		return expr, nil
+	case tok.kind == tokIdent:
+		return p.comparison(tok.text)
+	default:
+		return nil, fmt.Errorf("%w: expected a field name at position %d, got '%s'", ErrInvalidExpression, tok.pos, tok.text)
+	}
+}
+
+// comparison parses: field [op value].
+func (p *parser) comparison(field string) (node, error) {
+	op := p.peek()
+	if op.kind != tokOp || !slices.Contains([]string{"==", "!=", "=~", "!~", "<", "<=", ">", ">="}, op.text) {
+		return fieldNode(field), nil
+	}
+
+	p.next()
+
+	tok := p.next()
+	cmp := &cmpNode{field: field, op: op.text}
+
+	switch tok.kind {
+	case tokString:
+		cmp.value = tok.text
+	case tokNumber:
+		num, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: bad number '%s' at position %d", ErrInvalidExpression, tok.text, tok.pos)
+		}
+
+		cmp.value = num
+	case tokIdent:
+		switch tok.text {
+		case "true", "false":
+			cmp.value = tok.text == "true"
+		case "null":
+			cmp.value = nil
+		default:
+			return nil, fmt.Errorf("%w: expected a value at position %d, got '%s'", ErrInvalidExpression, tok.pos, tok.text)
+		}
+	default:
+		return nil, fmt.Errorf("%w: expected a value at position %d, got '%s'", ErrInvalidExpression, tok.pos, tok.text)
+	}
+
+	switch cmp.op {
+	case "=~", "!~":
+		pattern, ok := cmp.value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s needs a string at position %d", ErrInvalidExpression, cmp.op, tok.pos)
+		}
+
+		var err error
+		if cmp.re, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("%w: regexp at position %d: %w", ErrInvalidExpression, tok.pos, err)
+		}
+	case "<", "<=", ">", ">=":
+		if _, ok := cmp.value.(float64); !ok {
+			return nil, fmt.Errorf("%w: %s needs a number at position %d", ErrInvalidExpression, cmp.op, tok.pos)
+		}
+	}
+
+	return cmp, nil
+}
+
+func (n orNode) eval(fields map[string]any) bool {
+	for _, expr := range n {
+		if expr.eval(fields) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (n andNode) eval(fields map[string]any) bool {
+	for _, expr := range n {
+		if !expr.eval(fields) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (n notNode) eval(fields map[string]any) bool {
+	return !n.node.eval(fields)
+}
+
+func (n fieldNode) eval(fields map[string]any) bool {
+	value, ok := lookup(fields, string(n))
+	if !ok {
+		return false
+	}
+
+	switch value := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return value
+	case float64:
+		return value != 0
+	case string:
+		return value != "" && value != "false" && value != "0"
+	default:
+		return true
+	}
+}
+
+// eval compares a field to the value. A missing field is null.
+func (n *cmpNode) eval(fields map[string]any) bool {
+	value, _ := lookup(fields, n.field)
+
+	switch n.op {
+	case "==":
+		return equal(value, n.value)
+	case "!=":
+		return !equal(value, n.value)
+	case "=~":
+		return value != nil && n.re.MatchString(toString(value))
+	case "!~":
+		return value == nil || !n.re.MatchString(toString(value))
+	}
+
+	num, ok := toNumber(value)
+	if !ok {
+		return false
+	}
+
+	want, _ := n.value.(float64)
+
+	switch n.op {
+	case "<":
+		return num < want
+	case "<=":
+		return num <= want
+	case ">":
+		return num > want
+	case ">=":
+		return num >= want
+	default:
+		return false
+	}
+}
+
+// equal compares a field value to an expression value. Numbers compare as numbers,
+// so a logfmt field of "200" equals 200.
+func equal(value, want any) bool {
+	switch want := want.(type) {
+	case nil:
+		return value == nil
+	case float64:
+		num, ok := toNumber(value)
+		return ok && num == want
+	default:
+		return value != nil && toString(value) == toString(want)
+	}
+}
+
+func toNumber(value any) (float64, bool) {
+	switch value := value.(type) {
+	case float64:
+		return value, true
+	case string:
+		num, err := strconv.ParseFloat(value, 64)
+		return num, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func toString(value any) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	case nil:
+		return ""
+	default:
+		data, _ := json.Marshal(value)
+		return string(data)
+	}
+}
@@ -0,0 +1,151 @@
+package filewatch
+
+/* This file parses structured log lines, in JSON or logfmt, into fields. */
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Structured log formats.
+const (
+	FormatJSON   = "json"
+	FormatLogfmt = "logfmt"
+)
+
+// setupFields validates the format and compiles the field expression.
+func (w *WatchFile) setupFields() error {
+	var err error
+
+	w.expr = nil
+
+	switch w.Format = strings.ToLower(strings.TrimSpace(w.Format)); w.Format {
+	case "", FormatJSON, FormatLogfmt:
+	default:
+		return fmt.Errorf("%w: unknown format '%s', ignored: %s", ErrInvalidFormat, w.Format, w.Path)
+	}
+
+	if w.Expression == "" {
+		return nil
+	}
+
+	if w.Format == "" {
+		return fmt.Errorf("%w: an expression requires a format, ignored: %s", ErrInvalidFormat, w.Path)
+	}
+
+	if w.expr, err = parseExpression(w.Expression); err != nil {
+		return fmt.Errorf("%w, ignored: %s", err, w.Path)
+	}
+
+	return nil
+}
+
+// fields parses a line in the watcher's format. Returns nil if there is no format, or the line does not parse.
+func (w *WatchFile) fields(line string) map[string]any {
+	switch w.Format {
+	case FormatJSON:
+		fields := map[string]any{}
+		if json.Unmarshal([]byte(line), &fields) != nil {
+			return nil
+		}
+
+		return fields
+	case FormatLogfmt:
+		return parseLogfmt(line)
+	default:
+		return nil
+	}
+}
+
+// parseLogfmt parses key=value pairs. Values may be double-quoted. A key without a value is true.
+// Returns nil if the line contains no keys.
+func parseLogfmt(line string) map[string]any {
+	fields := map[string]any{}
+
+	for line = strings.TrimSpace(line); line != ""; line = strings.TrimLeftFunc(line, unicode.IsSpace) {
+		end := strings.IndexFunc(line, func(r rune) bool { return r == '=' || unicode.IsSpace(r) })
+		if end == -1 {
+			fields[line] = true
+			break
+		}
+
+		key := line[:end]
+		if line = line[end:]; line[0] != '=' {
+			fields[key] = true
+			continue
+		}
+
+		line = line[1:]
+
+		var value string
+
+		if strings.HasPrefix(line, `"`) {
+			value, line = unquotePrefix(line)
+		} else if end = strings.IndexFunc(line, unicode.IsSpace); end == -1 {
+			value, line = line, ""
+		} else {
+			value, line = line[:end], line[end:]
+		}
+
+		if key != "" {
+			fields[key] = value
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return fields
+}
+
+// unquotePrefix returns the double-quoted string at the start of the input, and the rest of the input.
+// An unterminated string is returned as-is.
+func unquotePrefix(input string) (string, string) {
+	for idx := 1; idx < len(input); idx++ {
+		switch input[idx] {
+		case '\\':
+			idx++
+		case '"':
+			if value, err := strconv.Unquote(input[:idx+1]); err == nil {
+				return value, input[idx+1:]
+			}
+
+			return input[1:idx], input[idx+1:]
+		}
+	}
+
+	return input[1:], ""
+}
+
+// lookup returns a field by name. Names with dots find nested JSON objects, like request.host.
+func lookup(fields map[string]any, name string) (any, bool) {
+	if value, ok := fields[name]; ok {
+		return value, true
+	}
+
+	for idx := strings.IndexByte(name, '.'); idx != -1; idx = nextDot(name, idx) {
+		nested, ok := fields[name[:idx]].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if value, ok := lookup(nested, name[idx+1:]); ok {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
+// nextDot returns the index of the next dot in name after idx, or -1.
+func nextDot(name string, idx int) int {
+	if next := strings.IndexByte(name[idx+1:], '.'); next != -1 {
+		return idx + 1 + next
+	}
+
+	return -1
+}
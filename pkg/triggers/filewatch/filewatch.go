@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 	"reflect"
 	"regexp"
@@ -17,13 +18,15 @@ import (
 	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
 	"github.com/Notifiarr/notifiarr/pkg/website"
 	"github.com/nxadm/tail"
-	"github.com/nxadm/tail/ratelimiter"
+	"golift.io/cnfg"
 )
 
 var (
-	ErrInvalidRegexp = errors.New("invalid regexp")
-	ErrIgnoredLog    = errors.New("the requested path is internally ignored")
-	ErrDisabled      = errors.New("the requested watch path is administratively disabled")
+	ErrInvalidRegexp     = errors.New("invalid regexp")
+	ErrIgnoredLog        = errors.New("the requested path is internally ignored")
+	ErrDisabled          = errors.New("the requested watch path is administratively disabled")
+	ErrInvalidFormat     = errors.New("invalid format")
+	ErrInvalidExpression = errors.New("invalid expression")
 )
 
 const (
@@ -31,9 +34,9 @@ const (
 	Matched       = " Matched"
 	maxRetries    = 12                                 // how many times to retry watching a file.
 	retryInterval = 10 * time.Second                   // how often channels are checked for being closed.
-	specialCase   = 2                                  // We have two special channels in our select cases.
-	burstRate     = 6                                  // burst to this many 'matches' before throttling.
-	requestPer    = time.Second + 500*time.Millisecond // 1 request per this time period allowed + burst rate.
+	specialCase   = 3                                  // We have three special channels in our select cases.
+	burstRate     = 6                                  // default burst to this many 'matches' before throttling.
+	requestPer    = time.Second + 500*time.Millisecond // default 1 request per this time period allowed + burst rate.
 )
 
 type cmd struct {
@@ -42,8 +45,8 @@ type cmd struct {
 	stopWatcher chan struct{}
 	awMutex     sync.RWMutex
 	files       []*WatchFile
-	limiter     *ratelimiter.LeakyBucket
 	ignored     []string
+	runner      Runner
 }
 
 // Action contains the exported methods for this package.
@@ -52,6 +55,12 @@ type Action struct {
 }
 
 // WatchFile is the input data needed to watch files.
+// Path may be a glob pattern or a directory; every matching file is watched, including new files.
+// Setting StartRegexp or ContinueRegexp groups multi-line events, like stack traces, into one match.
+// Setting Format parses each line as JSON or logfmt, and Expression matches on the parsed fields.
+// Setting Source reads the systemd journal or a docker container's log instead of a file.
+// Every watcher has its own rate limit, and may de-duplicate repeated matches or send them in digests.
+// Actions run a command, an endpoint or a trigger when a line matches.
 type WatchFile struct {
 	Path      string `json:"path"      toml:"path"       xml:"path"       yaml:"path"`
 	Regexp    string `json:"regex"     toml:"regex"      xml:"regex"      yaml:"regex"`
@@ -61,11 +70,54 @@ type WatchFile struct {
 	MustExist bool   `json:"mustExist" toml:"must_exist" xml:"must_exist" yaml:"mustExist"`
 	LogMatch  bool   `json:"logMatch"  toml:"log_match"  xml:"log_match"  yaml:"logMatch"`
 	Disabled  bool   `json:"disabled"  toml:"disabled"   xml:"disabled"   yaml:"disabled"`
-	re        *regexp.Regexp
-	skip      *regexp.Regexp
-	tail      *tail.Tail
-	mu        sync.RWMutex
-	retries   uint
+	// StartRegexp matches the first line of a multi-line event.
+	StartRegexp string `json:"startRegex" toml:"start_regex" xml:"start_regex" yaml:"startRegex"`
+	// ContinueRegexp matches the following lines of a multi-line event.
+	ContinueRegexp string `json:"continueRegex" toml:"continue_regex" xml:"continue_regex" yaml:"continueRegex"`
+	// MaxLines ends a multi-line event at this many lines. Default is 50.
+	MaxLines int `json:"maxLines" toml:"max_lines" xml:"max_lines" yaml:"maxLines"`
+	// Flush ends a multi-line event when no new line is written for this long. Default is 2 seconds.
+	Flush cnfg.Duration `json:"flush" toml:"flush" xml:"flush" yaml:"flush"`
+	// Format parses each line as structured data: json or logfmt. The parsed fields are sent with a match.
+	Format string `json:"format" toml:"format" xml:"format" yaml:"format"`
+	// Expression matches on parsed fields, like: level == "error" && logger =~ 'Import'.
+	// Regexp is optional when this is set. If both are set, both must match.
+	Expression string `json:"expression" toml:"expression" xml:"expression" yaml:"expression"`
+	// Source is where lines are read from: file (default), journald or docker.
+	// For journald, Path is a unit name or a journal match like SYSLOG_IDENTIFIER=sonarr.
+	// For docker, Path is a container name or ID.
+	Source string `json:"source" toml:"source" xml:"source" yaml:"source"`
+	// Burst is how many matches may be sent at once before the rate limit applies. Default is 6.
+	Burst int `json:"burst" toml:"burst" xml:"burst" yaml:"burst"`
+	// Rate allows one match per this duration after the burst. Default is 1.5 seconds.
+	Rate cnfg.Duration `json:"rate" toml:"rate" xml:"rate" yaml:"rate"`
+	// Dedup sends identical matches once within this window, then sends how many times they repeated.
+	Dedup cnfg.Duration `json:"dedup" toml:"dedup" xml:"dedup" yaml:"dedup"`
+	// Digest collects matches, and sends them together once per this duration.
+	Digest cnfg.Duration `json:"digest" toml:"digest" xml:"digest" yaml:"digest"`
+	// Actions run when a line matches, like command:restart-plex, endpoint:my-endpoint or trigger:TrigDashboard.
+	// Commands are found by name or hash. Capture groups from the regexp are passed as command arguments.
+	// Actions do not run for repeated matches in the dedup window. They have their own rate limit using Burst and Rate.
+	Actions  []string `json:"actions,omitempty" toml:"actions" xml:"actions" yaml:"actions"`
+	actions  []*action
+	re       *regexp.Regexp
+	expr     node
+	skip     *regexp.Regexp
+	start    *regexp.Regexp
+	cont     *regexp.Regexp
+	event    *event // only used in the tailFiles go routine.
+	tail     *tail.Tail
+	stream   *stream // used instead of tail for journald and docker sources.
+	throttle *throttle
+	mu       sync.RWMutex
+	retries  uint
+	// These are used when Path is a glob or a directory.
+	pattern   string
+	globbing  bool
+	children  map[string]*WatchFile // by path.
+	seen      []os.FileInfo         // every file being watched, to find rotated files.
+	parent    *WatchFile
+	fromStart bool // read a new file from the beginning.
 }
 
 // Match is what we send to the website.
@@ -73,16 +125,27 @@ type Match struct {
 	File    string   `json:"file"`
 	Matches []string `json:"matches"`
 	Line    string   `json:"line"`
+	// Lines contains every line in a multi-line event. Line is the first line.
+	Lines []string `json:"lines,omitempty"`
+	// Fields are the parsed fields from the first line, when a format is set.
+	Fields map[string]any `json:"fields,omitempty"`
+	// Source is journald or docker when the line did not come from a file. File is the unit or container.
+	Source string `json:"source,omitempty"`
+	// Repeated is how many more times this match was seen in the dedup window.
+	Repeated int `json:"repeated,omitempty"`
+	// Count is how many matches are in a digest. Digest contains up to 100 of them.
+	Count  int      `json:"count,omitempty"`
+	Digest []*Match `json:"digest,omitempty"`
 }
 
-// New configures the library.
-func New(config *common.Config, files []*WatchFile, ignored []string) *Action {
+// New configures the library. The runner runs actions for matches.
+func New(config *common.Config, files []*WatchFile, ignored []string, runner Runner) *Action {
 	return &Action{
 		cmd: &cmd{
 			Config:  config,
 			files:   files,
-			limiter: ratelimiter.NewLeakyBucket(burstRate, requestPer),
 			ignored: checkIgnored(ignored),
+			runner:  runner,
 		},
 	}
 }
@@ -136,8 +199,8 @@ func (a *Action) Stop() {
 }
 
 func (c *cmd) run(ctx context.Context) {
-	// two fake tails for internal channels.
-	validTails := []*WatchFile{{Path: "/add watcher channel/"}, {Path: "/retry ticker/"}}
+	// three fake tails for internal channels.
+	validTails := []*WatchFile{{Path: "/add watcher channel/"}, {Path: "/retry ticker/"}, {Path: "/flush ticker/"}}
 
 	for _, item := range c.files {
 		if err := item.setup(c.ignored); err != nil {
@@ -145,15 +208,22 @@ func (c *cmd) run(ctx context.Context) {
 			continue
 		}
 
-		validTails = append(validTails, item)
+		if item.pattern == "" {
+			validTails = append(validTails, item)
+			continue
+		}
+
+		mnd.Log.Printf(mnd.GetID(ctx), "==> Watching Files Matching: %s, regexp: '%s' skip: '%s'",
+			item.pattern, item.Regexp, item.Skip)
+		validTails = append(validTails, c.scanGlob(ctx, item, true)...)
 	}
 
 	if len(validTails) == 0 {
 		return
 	}
 
-	cases, ticker := c.collectFileTails(ctx, validTails)
-	c.tailFiles(ctx, cases, validTails, ticker)
+	cases, tickers := c.collectFileTails(ctx, validTails)
+	c.tailFiles(ctx, cases, validTails, tickers)
 }
 
 func (w *WatchFile) setup(ignored ignored) error {
@@ -161,26 +231,66 @@ func (w *WatchFile) setup(ignored ignored) error {
 
 	w.retries = maxRetries // so it will not get "restarted" unless it passes validation.
 
-	if w.Regexp == "" {
+	w.re = nil
+
+	if w.Regexp == "" && w.Expression == "" {
 		return fmt.Errorf("%w: no regexp match provided, ignored: %s", ErrInvalidRegexp, w.Path)
-	} else if w.re, err = regexp.Compile(w.Regexp); err != nil {
-		return fmt.Errorf("%w: regexp match compile failed, ignored: %s", ErrInvalidRegexp, w.Path)
-	} else if w.skip, err = regexp.Compile(w.Skip); err != nil {
+	} else if w.Regexp != "" {
+		if w.re, err = regexp.Compile(w.Regexp); err != nil {
+			return fmt.Errorf("%w: regexp match compile failed, ignored: %s", ErrInvalidRegexp, w.Path)
+		}
+	}
+
+	if w.skip, err = regexp.Compile(w.Skip); err != nil {
 		return fmt.Errorf("%w: regexp skip compile failed, ignored: %s", ErrInvalidRegexp, w.Path)
+	} else if err = w.setupFields(); err != nil {
+		return err
+	} else if err = w.setupMultiline(); err != nil {
+		return err
+	} else if err = w.setupSource(); err != nil {
+		return err
+	} else if err = w.setupActions(); err != nil {
+		return err
 	} else if ignored.isIgnored(w.Path) {
 		return fmt.Errorf("%w: %s", ErrIgnoredLog, w.Path)
 	} else if w.Disabled {
 		return fmt.Errorf("%w: %s", ErrDisabled, w.Path)
 	}
 
+	w.setupThrottle()
+
+	if w.Source != "" {
+		return w.setupStream()
+	}
+
+	if w.parent == nil {
+		w.pattern = globPattern(w.Path)
+	}
+
+	if w.pattern != "" {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		w.globbing = true
+		w.children = make(map[string]*WatchFile)
+		w.retries = 0
+
+		return nil
+	}
+
+	location := &tail.SeekInfo{Whence: io.SeekEnd}
+	if w.fromStart {
+		location.Whence = io.SeekStart
+	}
+
 	w.tail, err = tail.TailFile(w.Path, tail.Config{
 		Follow:        true,
-		ReOpen:        true,
+		ReOpen:        w.parent == nil,
 		MustExist:     w.MustExist,
 		Poll:          w.Poll,
 		Pipe:          w.Pipe,
 		CompleteLines: true,
-		Location:      &tail.SeekInfo{Whence: io.SeekEnd},
+		Location:      location,
 		Logger:        &logger{Logger: mnd.Log},
 	})
 	if err != nil {
@@ -193,6 +303,32 @@ func (w *WatchFile) setup(ignored ignored) error {
 	return nil
 }
 
+// setupStream starts reading a journald or docker source.
+func (w *WatchFile) setupStream() error {
+	stream, err := w.newStream()
+	if err != nil {
+		mnd.FileWatcher.Add(w.Path+Errors, 1)
+		return fmt.Errorf("watching %s %s: %w", w.Source, w.Path, err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.stream = stream
+	w.retries = 0
+
+	return nil
+}
+
+// lines returns the channel that new lines are sent to.
+func (w *WatchFile) lines() chan *tail.Line {
+	if w.stream != nil {
+		return w.stream.lines
+	}
+
+	return w.tail.Lines
+}
+
 // isQuietSetupErr is true for expected setup outcomes that must not be shared to Discord.
 func isQuietSetupErr(err error) bool {
 	return errors.Is(err, ErrIgnoredLog) || errors.Is(err, ErrDisabled)
@@ -212,13 +348,15 @@ func logWatchSetupError(reqID string, err error) {
 }
 
 // collectFileTails uses reflection to watch a dynamic list of files in one go routine.
-func (c *cmd) collectFileTails(ctx context.Context, tails []*WatchFile) ([]reflect.SelectCase, *time.Ticker) {
+// Returns the retry and flush tickers, so they can be stopped.
+func (c *cmd) collectFileTails(ctx context.Context, tails []*WatchFile) ([]reflect.SelectCase, []*time.Ticker) {
 	c.awMutex.Lock()
 	defer c.awMutex.Unlock()
 
 	c.addWatcher = make(chan *WatchFile, len(tails)+1) // DATA RACE 101
 	c.stopWatcher = make(chan struct{})
 	ticker := time.NewTicker(retryInterval)
+	flusher := time.NewTicker(flushInterval)
 	cases := make([]reflect.SelectCase, len(tails))
 
 	for idx, item := range tails {
@@ -229,9 +367,12 @@ func (c *cmd) collectFileTails(ctx context.Context, tails []*WatchFile) ([]refle
 		} else if idx == 1 {
 			cases[idx] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ticker.C)}
 			continue
+		} else if idx == 2 { //nolint:mnd
+			cases[idx] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(flusher.C)}
+			continue
 		}
 
-		cases[idx] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(item.tail.Lines)}
+		cases[idx] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(item.lines())}
 
 		mnd.Log.Printf(mnd.GetID(ctx), "==> Watching: %s, regexp: '%s' skip: '%s' poll:%v pipe:%v must:%v log:%v",
 			item.Path, item.Regexp, item.Skip, item.Poll, item.Pipe, item.MustExist, item.LogMatch)
@@ -242,13 +383,17 @@ func (c *cmd) collectFileTails(ctx context.Context, tails []*WatchFile) ([]refle
 		}
 	}
 
-	return cases, ticker
+	return cases, []*time.Ticker{ticker, flusher}
 }
 
-func (c *cmd) tailFiles(ctx context.Context, cases []reflect.SelectCase, tails []*WatchFile, ticker *time.Ticker) {
+func (c *cmd) tailFiles(ctx context.Context, cases []reflect.SelectCase, tails []*WatchFile, tickers []*time.Ticker) {
 	defer func() {
 		defer mnd.Log.CapturePanic()
-		ticker.Stop()
+
+		for _, ticker := range tickers {
+			ticker.Stop()
+		}
+
 		mnd.Log.Printf(mnd.GetID(ctx), "==> All file watchers stopped.")
 		close(c.stopWatcher) // signal we're done.
 	}()
@@ -269,15 +414,28 @@ func (c *cmd) tailFiles(ctx context.Context, cases []reflect.SelectCase, tails [
 			tails = append(tails[:idx], tails[idx+1:]...) // The channel was closed? okay, remove it.
 			cases = append(cases[:idx], cases[idx+1:]...)
 			died = c.killWatcher(ctx, item)
+
+			if lines := item.flush(time.Now(), true); lines != nil {
+				c.checkMatch(reqID, item, lines)
+			}
+
+			c.send(reqID, item, item.throttle.tick(time.Now(), true))
 		case idx == 1:
 			died = c.fileWatcherTicker(ctx, died)
+
+			for _, item := range c.scanGlobs(ctx) {
+				tails = append(tails, item)
+				cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(item.lines())})
+			}
+		case idx == 2: //nolint:mnd
+			c.flushEvents(tails[specialCase:])
 		case data.IsNil(), data.IsZero(), !data.Elem().CanInterface():
 			mnd.Log.Errorf(reqID, "Got non-addressable file watcher data from %s", item.Path)
 			mnd.FileWatcher.Add(item.Path+Errors, 1)
 		case idx == 0:
 			item, _ = reflect.TypeAssert[*WatchFile](data.Elem().Addr())
 			tails = append(tails, item)
-			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(item.tail.Lines)})
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(item.lines())})
 		default:
 			mnd.FileWatcher.Add(item.Path+" Lines", 1)
 
@@ -353,15 +511,46 @@ func (c *cmd) fileWatcherTicker(ctx context.Context, died bool) bool {
 }
 
 // checkLineMatch runs when a watched file has a new line written.
-// If a match is found a notification is sent.
+// Lines are grouped into multi-line events first, if configured.
 func (c *cmd) checkLineMatch(reqID string, line *tail.Line, tail *WatchFile) {
 	tail.retries = 0 // reset retries once we get a line from the file.
 
-	if tail.re == nil || line.Text == "" || !tail.re.MatchString(line.Text) {
+	if !tail.grouping() {
+		c.checkMatch(reqID, tail, []string{line.Text})
+	} else if lines := tail.group(line.Text, time.Now()); lines != nil {
+		c.checkMatch(reqID, tail, lines)
+	}
+}
+
+// flushEvents checks multi-line events for the flush timeout, and sends repeat counts and digests that are due.
+func (c *cmd) flushEvents(tails []*WatchFile) {
+	now := time.Now()
+
+	for _, tail := range tails {
+		if lines := tail.flush(now, false); lines != nil {
+			c.checkMatch(mnd.ReqID(), tail, lines)
+		}
+
+		if send := tail.throttle.tick(now, false); len(send) > 0 {
+			c.send(mnd.ReqID(), tail, send)
+		}
+	}
+}
+
+// checkMatch runs when a line, or a multi-line event, is complete.
+// If a match is found a notification is sent.
+func (c *cmd) checkMatch(reqID string, tail *WatchFile, lines []string) {
+	text := strings.Join(lines, "\n")
+	if text == "" || (tail.re == nil && tail.expr == nil) || (tail.re != nil && !tail.re.MatchString(text)) {
 		return // no match
 	}
 
-	if tail.skip != nil && tail.Skip != "" && tail.skip.MatchString(line.Text) {
+	fields := tail.fields(lines[0])
+	if tail.expr != nil && (fields == nil || !tail.expr.eval(fields)) {
+		return // no match
+	}
+
+	if tail.skip != nil && tail.Skip != "" && tail.skip.MatchString(text) {
 		mnd.FileWatcher.Add(tail.Path+" Skipped", 1)
 		return // skip matches
 	}
@@ -370,23 +559,46 @@ func (c *cmd) checkLineMatch(reqID string, line *tail.Line, tail *WatchFile) {
 
 	match := &Match{
 		File:    tail.Path,
-		Line:    strings.TrimSpace(line.Text),
-		Matches: tail.re.FindAllString(line.Text, -1),
+		Line:    strings.TrimSpace(lines[0]),
+		Matches: []string{},
+		Fields:  fields,
+		Source:  tail.Source,
 	}
 
-	if !c.limiter.Pour(1) {
-		mnd.FileWatcher.Add(tail.Path+" Dropped", 1)
-		return // rate limited.
+	if tail.re != nil {
+		match.Matches = tail.re.FindAllString(text, -1)
 	}
 
-	website.SendData(&website.Request{
-		ReqID:      reqID,
-		Route:      website.LogLineRoute,
-		Event:      website.EventFile,
-		LogPayload: tail.LogMatch,
-		LogMsg:     fmt.Sprintf("Watched-File Line Match: %s: %s", tail.Path, match.Line),
-		Payload:    match,
-	})
+	if len(lines) > 1 {
+		match.Lines = lines
+	}
+
+	now := time.Now()
+	if tail.throttle.repeated(match, now) {
+		return
+	}
+
+	c.runActions(reqID, tail, text)
+	c.send(reqID, tail, tail.throttle.collect(match, now))
+}
+
+// send sends matches to the website, if the watcher's rate limit allows it.
+func (c *cmd) send(reqID string, tail *WatchFile, matches []*Match) {
+	for _, match := range matches {
+		if !tail.throttle.allow() {
+			mnd.FileWatcher.Add(tail.Path+" Dropped", 1)
+			continue // rate limited.
+		}
+
+		website.SendData(&website.Request{
+			ReqID:      reqID,
+			Route:      website.LogLineRoute,
+			Event:      website.EventFile,
+			LogPayload: tail.LogMatch,
+			LogMsg:     fmt.Sprintf("Watched-File Line Match: %s: %s", match.File, match.summary()),
+			Payload:    match,
+		})
+	}
 }
 
 func (a *Action) AddFileWatcher(file *WatchFile) error {
@@ -407,6 +619,13 @@ func (c *cmd) addFileWatcher(file *WatchFile) error {
 	}
 
 	reqID := mnd.ReqID()
+
+	if file.pattern != "" {
+		// The retry ticker scans for matching files.
+		mnd.Log.Printf(reqID, "Watching Files Matching: %s, regexp: '%s' skip: '%s'", file.pattern, file.Regexp, file.Skip)
+		return nil
+	}
+
 	mnd.Log.Printf(reqID, "Watching File: %s, regexp: '%s' skip: '%s' poll:%v pipe:%v must:%v log:%v",
 		file.Path, file.Regexp, file.Skip, file.Poll, file.Pipe, file.MustExist, file.LogMatch)
 
@@ -422,6 +641,10 @@ func (w *WatchFile) Stop() error {
 
 	w.retries = maxRetries // so it will not get "restarted" after manually being stopped.
 
+	if w.pattern != "" {
+		return w.stopGlob()
+	}
+
 	return w.stop()
 }
 
@@ -456,7 +679,7 @@ func (w *WatchFile) deactivate() error {
 func (w *WatchFile) Active() bool {
 	w.mu.RLock()
 	defer w.mu.RUnlock()
-	return w.tail != nil
+	return w.tail != nil || w.stream != nil || w.globbing
 }
 
 // stop stops a file watcher.
@@ -464,6 +687,17 @@ func (w *WatchFile) stop() error {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
+	if w.stream != nil {
+		err := w.stream.Stop()
+		w.stream = nil
+
+		if err != nil {
+			return fmt.Errorf("stop failed: %w", err)
+		}
+
+		return nil
+	}
+
 	if err := w.tail.Stop(); err != nil {
 		return fmt.Errorf("stop failed: %w", err)
 	}
@@ -1,8 +1,18 @@
 package filewatch //nolint:testpackage
 
 import (
+	"bytes"
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
+
+	"golift.io/cnfg"
 )
 
 func TestIsQuietSetupErr(t *testing.T) {
@@ -34,3 +44,274 @@ func TestIsQuietSetupErr(t *testing.T) {
 		})
 	}
 }
+
+func TestGroup(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		watch *WatchFile
+		lines []string
+		want  [][]string // completed events, then the flushed event.
+	}{
+		{
+			name:  "start regexp",
+			watch: &WatchFile{StartRegexp: `^\d{4}-`},
+			lines: []string{"2024-01 one", "  at a", "  at b", "2024-02 two", "2024-03 three", "  at c"},
+			want:  [][]string{{"2024-01 one", "  at a", "  at b"}, {"2024-02 two"}, {"2024-03 three", "  at c"}},
+		},
+		{
+			name:  "continue regexp",
+			watch: &WatchFile{ContinueRegexp: `^\s+at `},
+			lines: []string{"Exception", "   at a", "   at b", "next"},
+			want:  [][]string{{"Exception", "   at a", "   at b"}, {"next"}},
+		},
+		{
+			name:  "max lines",
+			watch: &WatchFile{StartRegexp: `^start`, MaxLines: 2},
+			lines: []string{"start", "a", "b", "c"},
+			want:  [][]string{{"start", "a"}, {"b", "c"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := test.watch.setupMultiline(); err != nil {
+				t.Fatalf("setupMultiline: %v", err)
+			}
+
+			now := time.Now()
+			got := [][]string{}
+
+			for _, line := range test.lines {
+				if lines := test.watch.group(line, now); lines != nil {
+					got = append(got, lines)
+				}
+			}
+
+			if test.watch.flush(now, false) != nil {
+				t.Fatal("flush returned an event before the flush timeout")
+			}
+
+			if lines := test.watch.flush(now.Add(test.watch.Flush.Duration), false); lines != nil {
+				got = append(got, lines)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("got events %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGlobPattern(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "app.log")
+
+	if err := os.WriteFile(file, []byte("line\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		file:                              "",
+		dir:                               filepath.Join(dir, "*"),
+		filepath.Join(dir, "*.log"):       filepath.Join(dir, "*.log"),
+		filepath.Join(dir, "app.[0-9]"):   filepath.Join(dir, "app.[0-9]"),
+		filepath.Join(dir, "missing.log"): "",
+	}
+
+	for path, want := range tests {
+		if got := globPattern(path); got != want {
+			t.Errorf("globPattern(%s) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestExpression(t *testing.T) {
+	t.Parallel()
+
+	fields := map[string]any{
+		"level":   "error",
+		"logger":  "ImportListSync",
+		"status":  float64(502),
+		"request": map[string]any{"host": "sonarr.example.com", "remote_ip": "10.1.1.1"},
+		"tls":     false,
+		"msg":     "upstream failed",
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{expr: `level == "error" && logger =~ "Import"`, want: true},
+		{expr: `level == "error" && logger =~ 'Export'`, want: false},
+		{expr: `level == "warn" || status >= 500`, want: true},
+		{expr: `status < 500 || !(request.host =~ '^sonarr\.')`, want: false},
+		{expr: `request.host == "sonarr.example.com"`, want: true},
+		{expr: `status == 502 && status != 200`, want: true},
+		{expr: `missing != "x" && missing == null && !missing`, want: true},
+		{expr: `missing =~ '.*'`, want: false},
+		{expr: `tls == false && !tls && msg`, want: true},
+	}
+
+	for _, test := range tests {
+		expr, err := parseExpression(test.expr)
+		if err != nil {
+			t.Fatalf("parseExpression(%s): %v", test.expr, err)
+		}
+
+		if got := expr.eval(fields); got != test.want {
+			t.Errorf("%s = %v, want %v", test.expr, got, test.want)
+		}
+	}
+
+	for _, bad := range []string{``, `level ==`, `level == "error" &&`, `(level`, `status > "x"`, `msg =~ '('`, `level = 1`} {
+		if _, err := parseExpression(bad); !errors.Is(err, ErrInvalidExpression) {
+			t.Errorf("parseExpression(%s) = %v, want ErrInvalidExpression", bad, err)
+		}
+	}
+}
+
+func TestParseLogfmt(t *testing.T) {
+	t.Parallel()
+
+	got := parseLogfmt(`time=2024-01-02T03:04:05Z level=error msg="import \"failed\"" path=/tv debug`)
+	want := map[string]any{
+		"time":  "2024-01-02T03:04:05Z",
+		"level": "error",
+		"msg":   `import "failed"`,
+		"path":  "/tv",
+		"debug": true,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseLogfmt() = %v, want %v", got, want)
+	}
+
+	if got := parseLogfmt("   "); got != nil {
+		t.Errorf("parseLogfmt(empty) = %v, want nil", got)
+	}
+}
+
+func TestDemuxer(t *testing.T) {
+	t.Parallel()
+
+	var stream bytes.Buffer
+
+	for _, frame := range []string{"line one\nline ", "two\n", "three\n"} {
+		header := make([]byte, dockerHeaderSize)
+		header[0] = 1 // stdout
+		binary.BigEndian.PutUint32(header[4:], uint32(len(frame)))
+		stream.Write(append(header, frame...))
+	}
+
+	got, err := io.ReadAll(&demuxer{ReadCloser: io.NopCloser(&stream)})
+	if err != nil {
+		t.Fatalf("reading demuxer: %v", err)
+	}
+
+	if want := "line one\nline two\nthree\n"; string(got) != want {
+		t.Errorf("demuxer = %q, want %q", got, want)
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	t.Parallel()
+
+	watch := &WatchFile{Path: "/app.log", Dedup: cnfg.Duration{Duration: time.Minute}}
+	watch.setupThrottle()
+
+	now := time.Now()
+	errA := &Match{File: "/app.log", Line: "error A", Matches: []string{"error A"}}
+	errB := &Match{File: "/app.log", Line: "error B", Matches: []string{"error B"}}
+
+	if watch.throttle.repeated(errA, now) {
+		t.Fatal("first match was a repeat")
+	}
+
+	for range 3 {
+		if !watch.throttle.repeated(&Match{Line: "error A", Matches: []string{"error A"}}, now) {
+			t.Fatal("repeated match was not a repeat")
+		}
+	}
+
+	if watch.throttle.repeated(errB, now) {
+		t.Fatal("different match was a repeat")
+	}
+
+	if got := watch.throttle.tick(now.Add(time.Second), false); got != nil {
+		t.Fatalf("tick in dedup window returned %v", got)
+	}
+
+	got := watch.throttle.tick(now.Add(time.Minute), false)
+	if len(got) != 1 || got[0].Line != "error A" || got[0].Repeated != 3 {
+		t.Fatalf("tick after dedup window = %v, want error A repeated 3 times", got)
+	}
+
+	// Digest mode collects everything, including repeat counts, into one match.
+	watch = &WatchFile{Path: "/app.log", Dedup: cnfg.Duration{Duration: time.Minute}, Digest: cnfg.Duration{Duration: time.Hour}}
+	watch.setupThrottle()
+
+	for _, match := range []*Match{errA, errB, errA, errA} {
+		if !watch.throttle.repeated(match, now) {
+			if got := watch.throttle.collect(match, now); got != nil {
+				t.Fatalf("digest mode returned a match: %v", got)
+			}
+		}
+	}
+
+	if got := watch.throttle.tick(now.Add(time.Minute), false); got != nil {
+		t.Fatalf("digest sent early: %v", got)
+	}
+
+	got = watch.throttle.tick(now.Add(time.Hour), false)
+	if len(got) != 1 || got[0].Count != 4 || len(got[0].Digest) != 3 || got[0].File != "/app.log" {
+		t.Fatalf("digest = %v, want 1 match with count 4 and 3 entries", got)
+	}
+}
+
+func TestSetupActions(t *testing.T) {
+	t.Parallel()
+
+	watch := &WatchFile{Path: "/plex.log", Actions: []string{"command:restart-plex", " Trigger : backup/sonarr", "endpoint:ping"}}
+	if err := watch.setupActions(); err != nil {
+		t.Fatalf("setupActions: %v", err)
+	}
+
+	want := []*action{{ActionCommand, "restart-plex"}, {ActionTrigger, "backup/sonarr"}, {ActionEndpoint, "ping"}}
+	if !reflect.DeepEqual(watch.actions, want) {
+		t.Errorf("actions = %v, want %v", watch.actions, want)
+	}
+
+	for _, bad := range []string{"restart-plex", "command:", "script:foo"} {
+		watch.Actions = []string{bad}
+		if err := watch.setupActions(); !errors.Is(err, ErrInvalidAction) {
+			t.Errorf("setupActions(%s) = %v, want ErrInvalidAction", bad, err)
+		}
+	}
+}
+
+func TestActionRateLimit(t *testing.T) {
+	t.Parallel()
+
+	watch := &WatchFile{Path: "/plex.log", Burst: 2, Rate: cnfg.Duration{Duration: time.Hour}}
+	watch.setupThrottle()
+
+	for range 2 {
+		if !watch.throttle.allowActions() {
+			t.Fatal("actions in the burst were not allowed")
+		}
+	}
+
+	if watch.throttle.allowActions() {
+		t.Error("actions after the burst were allowed")
+	}
+
+	if !watch.throttle.allow() {
+		t.Error("actions used the limit for sent matches")
+	}
+}
@@ -0,0 +1,164 @@
+package filewatch
+
+/* This file watches glob patterns and directories. Every matching file gets its own tail. */
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+)
+
+// globPattern returns the glob pattern for a path with wildcards, or a directory.
+// Returns an empty string for a single file.
+func globPattern(path string) string {
+	if strings.ContainsAny(path, "*?[") {
+		return path
+	}
+
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return filepath.Join(path, "*")
+	}
+
+	return ""
+}
+
+// newChild returns a watcher for a file matched by this glob watcher.
+// Children do not reopen moved files; a rotated file is picked up by its new name.
+func (w *WatchFile) newChild(path string) *WatchFile {
+	return &WatchFile{
+		Path:           path,
+		Regexp:         w.Regexp,
+		Skip:           w.Skip,
+		Poll:           w.Poll,
+		Pipe:           w.Pipe,
+		MustExist:      true,
+		LogMatch:       w.LogMatch,
+		StartRegexp:    w.StartRegexp,
+		ContinueRegexp: w.ContinueRegexp,
+		MaxLines:       w.MaxLines,
+		Flush:          w.Flush,
+		Format:         w.Format,
+		Expression:     w.Expression,
+		Burst:          w.Burst,
+		Rate:           w.Rate,
+		Dedup:          w.Dedup,
+		Digest:         w.Digest,
+		Actions:        w.Actions,
+		parent:         w,
+	}
+}
+
+// scanGlobs checks every glob watcher for new and removed files.
+// Returns new children that must be added to the tail loop.
+func (c *cmd) scanGlobs(ctx context.Context) []*WatchFile {
+	added := []*WatchFile{}
+
+	for _, item := range c.files {
+		if item.pattern != "" && item.Active() {
+			added = append(added, c.scanGlob(ctx, item, false)...)
+		}
+	}
+
+	return added
+}
+
+// scanGlob finds files matching a glob watcher, and returns new children for them.
+// New files are read from the beginning, unless this is the first scan.
+// Files that were already read under another name (rotated by rename) are not watched again.
+func (c *cmd) scanGlob(ctx context.Context, parent *WatchFile, first bool) []*WatchFile {
+	paths, err := filepath.Glob(parent.pattern)
+	if err != nil {
+		mnd.Log.Errorf(mnd.GetID(ctx), "Globbing file watcher path %s: %v", parent.Path, err)
+		return nil
+	}
+
+	parent.mu.Lock()
+	defer parent.mu.Unlock()
+
+	var (
+		added   = []*WatchFile{}
+		seen    = []os.FileInfo{}
+		current = make(map[string]bool, len(paths))
+	)
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			continue
+		}
+
+		current[path] = true
+
+		if parent.known(info) {
+			seen = append(seen, info)
+			continue
+		}
+
+		if child := parent.children[path]; child != nil && child.Active() {
+			continue // The old file at this path was moved. Its tail stops on its own; check again next scan.
+		}
+
+		seen = append(seen, info)
+
+		child := parent.newChild(path)
+		child.fromStart = !first
+
+		if err := child.setup(c.ignored); err != nil {
+			logWatchSetupError(mnd.GetID(ctx), err)
+			continue
+		}
+
+		parent.children[path] = child
+		added = append(added, child)
+	}
+
+	// Stop watching files that no longer match.
+	for path, child := range parent.children {
+		if current[path] {
+			continue
+		}
+
+		if err := child.Stop(); err != nil {
+			mnd.Log.Errorf(mnd.GetID(ctx), "Stopping File Watcher: %s: %v", path, err)
+		}
+
+		delete(parent.children, path)
+	}
+
+	parent.seen = seen
+
+	return added
+}
+
+// known returns true if the file was already seen by this glob watcher, by any name.
+func (w *WatchFile) known(info os.FileInfo) bool {
+	for _, seen := range w.seen {
+		if os.SameFile(seen, info) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// stopGlob stops a glob watcher and all of its children.
+func (w *WatchFile) stopGlob() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.globbing = false
+	w.seen = nil
+
+	for path, child := range w.children {
+		if err := child.Stop(); err != nil {
+			mnd.Log.Errorf(mnd.ReqID(), "Stopping File Watcher: %s: %v", path, err)
+		}
+	}
+
+	w.children = make(map[string]*WatchFile)
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package filewatch
+
+/* This file groups multi-line log events, like stack traces, so they are matched and sent together. */
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+const (
+	defaultMaxLines = 50
+	defaultFlush    = 2 * time.Second
+	flushInterval   = 250 * time.Millisecond // how often grouped events are checked for the flush timeout.
+)
+
+// event is a multi-line log event being collected.
+type event struct {
+	lines []string
+	last  time.Time
+}
+
+// setupMultiline compiles the start and continue regexps, and sets defaults if either is provided.
+func (w *WatchFile) setupMultiline() error {
+	var err error
+
+	w.start, w.cont = nil, nil
+
+	if w.StartRegexp != "" {
+		if w.start, err = regexp.Compile(w.StartRegexp); err != nil {
+			return fmt.Errorf("%w: regexp start compile failed, ignored: %s", ErrInvalidRegexp, w.Path)
+		}
+	}
+
+	if w.ContinueRegexp != "" {
+		if w.cont, err = regexp.Compile(w.ContinueRegexp); err != nil {
+			return fmt.Errorf("%w: regexp continue compile failed, ignored: %s", ErrInvalidRegexp, w.Path)
+		}
+	}
+
+	if !w.grouping() {
+		return nil
+	}
+
+	if w.MaxLines <= 0 {
+		w.MaxLines = defaultMaxLines
+	}
+
+	if w.Flush.Duration <= 0 {
+		w.Flush.Duration = defaultFlush
+	}
+
+	return nil
+}
+
+// grouping returns true if this watcher groups lines into multi-line events.
+func (w *WatchFile) grouping() bool {
+	return w.start != nil || w.cont != nil
+}
+
+// group adds a line to the current event. A line that matches the start regexp, or does not
+// match the continue regexp, begins a new event. Any other line continues the current event.
+// Returns the lines of an event that is complete, or nil.
+func (w *WatchFile) group(text string, now time.Time) []string {
+	isStart := w.start != nil && w.start.MatchString(text)
+	isCont := !isStart && (w.cont == nil || w.cont.MatchString(text))
+
+	if w.event != nil && isCont {
+		w.event.lines = append(w.event.lines, text)
+		w.event.last = now
+
+		if len(w.event.lines) < w.MaxLines {
+			return nil
+		}
+
+		lines := w.event.lines
+		w.event = nil
+
+		return lines
+	}
+
+	var lines []string
+	if w.event != nil {
+		lines = w.event.lines
+	}
+
+	w.event = &event{lines: []string{text}, last: now}
+
+	return lines
+}
+
+// flush returns the lines of an event that has not had a new line within the flush timeout.
+// If force is true, any event is returned.
+func (w *WatchFile) flush(now time.Time, force bool) []string {
+	if w.event == nil || (!force && now.Sub(w.event.last) < w.Flush.Duration) {
+		return nil
+	}
+
+	lines := w.event.lines
+	w.event = nil
+
+	return lines
+}
@@ -0,0 +1,256 @@
+package filewatch
+
+/* This file reads log lines from sources other than files: the systemd journal and docker containers. */
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/nxadm/tail"
+)
+
+// Log sources. The default source is a file.
+const (
+	SourceFile     = "file"
+	SourceJournald = "journald"
+	SourceDocker   = "docker"
+)
+
+const (
+	defaultDockerSocket = "/var/run/docker.sock"
+	dockerHeaderSize    = 8
+	maxLineSize         = 1024 * 1024
+)
+
+var (
+	ErrInvalidSource = errors.New("invalid source")
+	ErrStreamClosed  = errors.New("log stream closed")
+	ErrDockerStatus  = errors.New("unexpected docker response")
+)
+
+// stream reads lines from a journal or container, and sends them like a file tail.
+type stream struct {
+	lines  chan *tail.Line
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+// setupSource validates the source.
+func (w *WatchFile) setupSource() error {
+	switch w.Source = strings.ToLower(strings.TrimSpace(w.Source)); w.Source {
+	case SourceFile:
+		w.Source = ""
+	case "", SourceJournald, SourceDocker:
+	default:
+		return fmt.Errorf("%w: unknown source '%s', ignored: %s", ErrInvalidSource, w.Source, w.Path)
+	}
+
+	return nil
+}
+
+// newStream starts reading from the watcher's journal or container.
+func (w *WatchFile) newStream() (*stream, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &stream{lines: make(chan *tail.Line), cancel: cancel, done: make(chan struct{})}
+
+	var (
+		reader io.ReadCloser
+		wait   func() error
+		err    error
+	)
+
+	switch w.Source {
+	case SourceJournald:
+		reader, wait, err = journal(ctx, w.Path)
+	case SourceDocker:
+		reader, err = dockerLogs(ctx, w.Path)
+		wait = func() error { return nil }
+	}
+
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go stream.read(ctx, reader, wait)
+
+	return stream, nil
+}
+
+// read sends every line from the reader to the lines channel, until the reader ends or the stream is stopped.
+func (s *stream) read(ctx context.Context, reader io.ReadCloser, wait func() error) {
+	defer close(s.done)
+	defer close(s.lines)
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+
+	for scanner.Scan() {
+		select {
+		case s.lines <- &tail.Line{Text: strings.TrimRight(scanner.Text(), "\r"), Time: time.Now()}:
+		case <-ctx.Done():
+		}
+	}
+
+	reader.Close()
+
+	err := scanner.Err()
+	if waitErr := wait(); err == nil {
+		err = waitErr
+	}
+
+	switch {
+	case ctx.Err() != nil:
+		s.err = nil // stopped on purpose.
+	case err != nil:
+		s.err = fmt.Errorf("%w: %w", ErrStreamClosed, err)
+	default:
+		s.err = ErrStreamClosed
+	}
+}
+
+// Stop stops reading the stream, and returns the reason it ended if it was not stopped.
+func (s *stream) Stop() error {
+	s.cancel()
+	<-s.done
+
+	return s.err
+}
+
+// journal follows the systemd journal with journalctl. The path is a unit name, like sonarr.service,
+// or a journal field match, like SYSLOG_IDENTIFIER=sonarr.
+func journal(ctx context.Context, path string) (io.ReadCloser, func() error, error) {
+	args := []string{"--follow", "--lines=0", "--output=cat", "--no-pager"}
+	if strings.Contains(path, "=") {
+		args = append(args, path)
+	} else {
+		args = append(args, "--unit="+path)
+	}
+
+	cmd := exec.CommandContext(ctx, "journalctl", args...)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, nil, fmt.Errorf("journalctl stdout: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, nil, fmt.Errorf("starting journalctl: %w", err)
+	}
+
+	return stdout, cmd.Wait, nil
+}
+
+// dockerLogs follows a container's log through the docker API.
+// The DOCKER_HOST environment variable is used if set, otherwise the local socket.
+func dockerLogs(ctx context.Context, container string) (io.ReadCloser, error) {
+	client, host := dockerClient()
+	path := host + "/containers/" + url.PathEscape(container)
+
+	var info struct {
+		Config struct {
+			Tty bool `json:"Tty"`
+		} `json:"Config"`
+	}
+
+	resp, err := dockerGet(ctx, client, path+"/json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("decoding docker container %s: %w", container, err)
+	}
+
+	resp, err = dockerGet(ctx, client, path+"/logs?follow=true&stdout=true&stderr=true&tail=0")
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Config.Tty {
+		return resp.Body, nil
+	}
+
+	return &demuxer{ReadCloser: resp.Body}, nil
+}
+
+// dockerClient returns an http client and base url for the docker API.
+func dockerClient() (*http.Client, string) {
+	host := os.Getenv("DOCKER_HOST")
+	if after, ok := strings.CutPrefix(host, "tcp://"); ok {
+		return &http.Client{}, "http://" + after
+	}
+
+	socket := strings.TrimPrefix(host, "unix://")
+	if socket == "" {
+		socket = defaultDockerSocket
+	}
+
+	return &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+		},
+	}}, "http://docker"
+}
+
+func dockerGet(ctx context.Context, client *http.Client, uri string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating docker request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("docker request: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxLineSize))
+		resp.Body.Close()
+
+		return nil, fmt.Errorf("%w: %s: %s", ErrDockerStatus, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return resp, nil
+}
+
+// demuxer removes the stream headers from a docker log that is not a TTY.
+// Each frame has an 8 byte header: stream type, 3 empty bytes, and a 4 byte big endian size.
+type demuxer struct {
+	io.ReadCloser
+	left uint32
+}
+
+func (d *demuxer) Read(data []byte) (int, error) {
+	for d.left == 0 {
+		header := make([]byte, dockerHeaderSize)
+		if _, err := io.ReadFull(d.ReadCloser, header); err != nil {
+			return 0, err //nolint:wrapcheck // io.EOF must not be wrapped.
+		}
+
+		d.left = binary.BigEndian.Uint32(header[4:])
+	}
+
+	if len(data) > int(d.left) {
+		data = data[:d.left]
+	}
+
+	size, err := d.ReadCloser.Read(data)
+	d.left -= uint32(size) //nolint:gosec // size is never more than left.
+
+	return size, err //nolint:wrapcheck // io.EOF must not be wrapped.
+}
@@ -0,0 +1,155 @@
+package filewatch
+
+/* This file rate limits, de-duplicates and batches matches, per watcher, before they are sent. */
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/nxadm/tail/ratelimiter"
+)
+
+const maxDigest = 100 // matches kept in one digest. More are counted, but not included.
+
+// throttle holds the rate limit, repeated matches and digest for one watcher.
+// Children of a glob watcher share their parent's throttle. Only used in the tailFiles go routine.
+type throttle struct {
+	path    string
+	dedup   time.Duration
+	digest  time.Duration
+	limiter *ratelimiter.LeakyBucket
+	actions *ratelimiter.LeakyBucket // actions are limited apart from sent matches.
+	repeats map[string]*repeat
+	batch   *Match // the digest being collected.
+	started time.Time
+}
+
+// repeat is a match that was sent, and how many times it repeated in the dedup window.
+type repeat struct {
+	match *Match
+	first time.Time
+	count int
+}
+
+// setupThrottle creates the rate limiter, with defaults for a missing burst or rate.
+func (w *WatchFile) setupThrottle() {
+	if w.parent != nil && w.parent.throttle != nil {
+		w.throttle = w.parent.throttle
+		return
+	}
+
+	burst, rate := w.Burst, w.Rate.Duration
+	if burst <= 0 {
+		burst = burstRate
+	}
+
+	if rate <= 0 {
+		rate = requestPer
+	}
+
+	w.throttle = &throttle{
+		path:    w.Path,
+		dedup:   w.Dedup.Duration,
+		digest:  w.Digest.Duration,
+		limiter: ratelimiter.NewLeakyBucket(uint16(min(burst, math.MaxUint16)), rate),
+		actions: ratelimiter.NewLeakyBucket(uint16(min(burst, math.MaxUint16)), rate),
+		repeats: make(map[string]*repeat),
+	}
+}
+
+// repeated returns true if an identical match was seen in the dedup window, and counts it.
+func (t *throttle) repeated(match *Match, now time.Time) bool {
+	if t.dedup <= 0 {
+		return false
+	}
+
+	key := match.key()
+	if rep := t.repeats[key]; rep != nil {
+		rep.count++
+		return true
+	}
+
+	t.repeats[key] = &repeat{match: match, first: now}
+
+	return false
+}
+
+// collect returns the matches to send now: the match, or nothing if it was added to the digest.
+func (t *throttle) collect(match *Match, now time.Time) []*Match {
+	if t.digest <= 0 {
+		return []*Match{match}
+	}
+
+	if t.batch == nil {
+		t.batch = &Match{File: t.path, Line: match.Line, Matches: []string{}}
+		t.started = now
+	}
+
+	t.batch.Count += max(1, match.Repeated)
+	if len(t.batch.Digest) < maxDigest {
+		t.batch.Digest = append(t.batch.Digest, match)
+		t.batch.Matches = append(t.batch.Matches, match.Matches...)
+	}
+
+	return nil
+}
+
+// tick returns repeat counts for expired dedup windows, and digests that are due.
+// If force is true, everything pending is returned.
+func (t *throttle) tick(now time.Time, force bool) []*Match {
+	var send []*Match
+
+	for key, rep := range t.repeats {
+		if !force && now.Sub(rep.first) < t.dedup {
+			continue
+		}
+
+		delete(t.repeats, key)
+
+		if rep.count > 0 {
+			repeated := *rep.match
+			repeated.Repeated = rep.count
+			send = append(send, t.collect(&repeated, now)...)
+		}
+	}
+
+	if t.batch != nil && (force || now.Sub(t.started) >= t.digest) {
+		send = append(send, t.batch)
+		t.batch = nil
+	}
+
+	return send
+}
+
+// allow returns false if the watcher's rate limit is exceeded.
+func (t *throttle) allow() bool {
+	return t.limiter.Pour(1)
+}
+
+// allowActions returns false if the watcher's action rate limit is exceeded.
+func (t *throttle) allowActions() bool {
+	return t.actions.Pour(1)
+}
+
+// key identifies identical matches: the same regexp matches, or the same line if there are none.
+func (m *Match) key() string {
+	if len(m.Matches) > 0 {
+		return strings.Join(m.Matches, "\x00")
+	}
+
+	return m.Line
+}
+
+// summary is logged when a match is sent.
+func (m *Match) summary() string {
+	switch {
+	case m.Count > 0:
+		return fmt.Sprintf("%d matches in digest, first: %s", m.Count, m.Line)
+	case m.Repeated > 0:
+		return fmt.Sprintf("%s (repeated %d times)", m.Line, m.Repeated)
+	default:
+		return m.Line
+	}
+}
@@ -3,6 +3,7 @@ package triggers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"path"
@@ -14,6 +15,7 @@ import (
 	"github.com/Notifiarr/notifiarr/pkg/logs/share"
 	"github.com/Notifiarr/notifiarr/pkg/mnd"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/filewatch"
 	"github.com/Notifiarr/notifiarr/pkg/ui"
 	"github.com/Notifiarr/notifiarr/pkg/website"
 	"github.com/Notifiarr/notifiarr/pkg/website/clientinfo"
@@ -23,6 +25,9 @@ import (
 	"golift.io/starr"
 )
 
+// ErrActionFailed is returned when a file watcher action cannot run.
+var ErrActionFailed = errors.New("action failed")
+
 // APIHandler is passed into the webserver so triggers can be executed from the API.
 func (a *Actions) APIHandler(req *http.Request) (int, any) {
 	return a.handleTrigger(req, website.EventAPI)
@@ -47,11 +52,13 @@ func (a *Actions) Handler(response http.ResponseWriter, req *http.Request) {
 
 type timer struct {
 	Name string `json:"name"`
-	Dur  string `json:"interval"`
+	Dur  string `json:"interval"` // or the cron expression.
 	// Use this ID to trigger this timer with the trigger/custom endpoint.
 	Idx int `json:"id"`
 	// The client API path to trigger this custom timer.
 	Path string `json:"apiPath"`
+	// Next contains the next few run times of a timer with a cron expression.
+	Next []time.Time `json:"next,omitempty"`
 }
 
 type triggerOutput struct {
@@ -63,6 +70,7 @@ type triggerOutput struct {
 
 // HandleGetTriggers handles the GET request to get the list of triggers and website timers.
 // @Description	Returns a list of triggers and website timers with their intervals, if configured.
+// @Description	Schedules and website timers with a cron expression include their next 5 run times.
 // @Summary		Get trigger list
 // @Tags			Triggers
 // @Produce		json
@@ -79,11 +87,17 @@ func (a *Actions) HandleGetTriggers(_ *http.Request) (int, any) {
 	}
 
 	for idx, action := range cronTimers {
+		dur := action.Interval.String()
+		if action.Cron != "" {
+			dur = action.Cron
+		}
+
 		reply.Timers[idx] = &timer{
 			Name: action.Name,
-			Dur:  action.Interval.String(),
+			Dur:  dur,
 			Idx:  idx,
 			Path: path.Join(a.Apps.URLBase, fmt.Sprint("api/trigger/custom/", idx)),
+			Next: action.NextRuns(),
 		}
 	}
 
@@ -108,6 +122,39 @@ func (a *Actions) handleTrigger(req *http.Request, event website.EventType) (int
 	return a.runTrigger(req, input, trigger, content)
 }
 
+// fileWatchAction runs a command, endpoint or trigger when a watched file has a matching line.
+func (a *Actions) fileWatchAction(input *common.ActionInput, kind, name string) error {
+	switch kind {
+	case filewatch.ActionCommand:
+		cmd := a.Commands.GetByName(name)
+		if cmd == nil {
+			return fmt.Errorf("%w: command '%s' not found", ErrActionFailed, name)
+		}
+
+		cmd.Run(input)
+	case filewatch.ActionEndpoint:
+		endpoint := a.Endpoints.List().Get(name)
+		if endpoint == nil {
+			return fmt.Errorf("%w: endpoint '%s' not found", ErrActionFailed, name)
+		}
+
+		endpoint.Run(input)
+	default:
+		trigger, content, _ := strings.Cut(name, "/")
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+		if err != nil {
+			return fmt.Errorf("creating trigger request: %w", err)
+		}
+
+		if code, msg := a.runTrigger(req, input, trigger, content); code != http.StatusOK {
+			return fmt.Errorf("%w: %s: %s", ErrActionFailed, http.StatusText(code), msg)
+		}
+	}
+
+	return nil
+}
+
 //nolint:cyclop,funlen,gocyclo
 func (a *Actions) runTrigger(req *http.Request, input *common.ActionInput, trigger, content string) (int, string) {
 	mnd.Log.Trace(input.ReqID, "start: Actions.runTrigger", input.Type, trigger, content != "")
@@ -134,6 +181,18 @@ func (a *Actions) runTrigger(req *http.Request, input *common.ActionInput, trigg
 		return a.sessions(input)
 	case "stuckitems", "TrigStuckItems":
 		return a.stuckitems(input)
+	case "stalled", "TrigStalledTorrents":
+		return a.stalled(input)
+	case "stuckrules", "TrigStuckRules":
+		return a.stuckrules(input)
+	case "imports", "TrigImportTracker":
+		return a.imports(input)
+	case "seeding", "TrigSeedingPolicy":
+		return a.seeding(input)
+	case "orphans", "TrigOrphans":
+		return a.orphans(input)
+	case "usenet", "TrigUsenetHealth":
+		return a.usenet(input)
 	case "dashboard", "TrigDashboard":
 		return a.dashboard(input)
 	case "snapshot", "TrigSnapshot":
@@ -374,6 +433,109 @@ func (a *Actions) stuckitems(input *common.ActionInput) (int, string) {
 	return http.StatusOK, "Stuck Queue Items triggered."
 }
 
+// @Description	Checks the torrent clients for stalled torrents and applies the configured actions.
+// @Summary		Check for stalled torrents
+// @Tags			Triggers
+// @Produce		json
+// @Success		200	{object}	apps.APIResponse{message=string}	"success"
+// @Failure		501	{object}	apps.APIResponse{message=string}	"stalled torrent detector not enabled"
+// @Failure		404	{object}	string								"bad token or api key"
+// @Router			/trigger/stalled [get]
+// @Security		ApiKeyAuth
+func (a *Actions) stalled(input *common.ActionInput) (int, string) {
+	if !a.StarrQueue.StalledTorrents(input) {
+		return http.StatusNotImplemented, "Stalled torrent detector is not enabled."
+	}
+
+	return http.StatusOK, "Stalled torrent check triggered."
+}
+
+// @Description	Fetches the starr app queues and applies the stuck item rules now.
+// @Summary		Apply stuck item rules
+// @Tags			Triggers
+// @Produce		json
+// @Success		200	{object}	apps.APIResponse{message=string}	"success"
+// @Failure		501	{object}	apps.APIResponse{message=string}	"no stuck item rules configured"
+// @Failure		404	{object}	string								"bad token or api key"
+// @Router			/trigger/stuckrules [get]
+// @Security		ApiKeyAuth
+func (a *Actions) stuckrules(input *common.ActionInput) (int, string) {
+	if !a.StarrQueue.StuckRules(input) {
+		return http.StatusNotImplemented, "No stuck item rules are configured."
+	}
+
+	return http.StatusOK, "Stuck item rules triggered."
+}
+
+// @Description	Polls the starr app queues for the import tracker now, and sends any new import events.
+// @Summary		Track download imports
+// @Tags			Triggers
+// @Produce		json
+// @Success		200	{object}	apps.APIResponse{message=string}	"success"
+// @Failure		501	{object}	apps.APIResponse{message=string}	"import tracker not enabled"
+// @Failure		404	{object}	string								"bad token or api key"
+// @Router			/trigger/imports [get]
+// @Security		ApiKeyAuth
+func (a *Actions) imports(input *common.ActionInput) (int, string) {
+	if !a.StarrQueue.TrackImports(input) {
+		return http.StatusNotImplemented, "Import tracker is not enabled."
+	}
+
+	return http.StatusOK, "Import tracker triggered."
+}
+
+// @Description	Enforces the seeding policies on the torrent clients now.
+// @Summary		Enforce seeding policies
+// @Tags			Triggers
+// @Produce		json
+// @Success		200	{object}	apps.APIResponse{message=string}	"success"
+// @Failure		501	{object}	apps.APIResponse{message=string}	"no seeding policies configured"
+// @Failure		404	{object}	string								"bad token or api key"
+// @Router			/trigger/seeding [get]
+// @Security		ApiKeyAuth
+func (a *Actions) seeding(input *common.ActionInput) (int, string) {
+	if !a.Seeding.Enforce(input) {
+		return http.StatusNotImplemented, "No seeding policies are configured."
+	}
+
+	return http.StatusOK, "Seeding policies triggered."
+}
+
+// @Description	Scans the download folders for orphaned files now. Get the results from the orphans endpoint.
+// @Summary		Find orphaned download files
+// @Tags			Triggers
+// @Produce		json
+// @Success		200	{object}	apps.APIResponse{message=string}	"success"
+// @Failure		501	{object}	apps.APIResponse{message=string}	"orphaned file finder not enabled"
+// @Failure		404	{object}	string								"bad token or api key"
+// @Router			/trigger/orphans [get]
+// @Security		ApiKeyAuth
+func (a *Actions) orphans(input *common.ActionInput) (int, string) {
+	if !a.Orphans.Find(input) {
+		return http.StatusNotImplemented, "Orphaned file finder is not enabled."
+	}
+
+	return http.StatusOK, "Orphaned file finder triggered."
+}
+
+// @Description	Analyzes usenet download failures now, and sends alerts for failure rate spikes.
+// @Description	Get the results from the usenet health endpoint.
+// @Summary		Analyze usenet download failures
+// @Tags			Triggers
+// @Produce		json
+// @Success		200	{object}	apps.APIResponse{message=string}	"success"
+// @Failure		501	{object}	apps.APIResponse{message=string}	"usenet failure analysis not enabled"
+// @Failure		404	{object}	string								"bad token or api key"
+// @Router			/trigger/usenet [get]
+// @Security		ApiKeyAuth
+func (a *Actions) usenet(input *common.ActionInput) (int, string) {
+	if !a.Usenet.Analyze(input) {
+		return http.StatusNotImplemented, "Usenet failure analysis is not enabled."
+	}
+
+	return http.StatusOK, "Usenet failure analysis triggered."
+}
+
 // @Description	Collects dashboard data and sends a notification.
 // @Summary		Send a dashboard notification
 // @Tags			Triggers
@@ -0,0 +1,57 @@
+package orphans
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golift.io/cnfg"
+)
+
+const (
+	defaultMinAge = 24 * time.Hour
+	minimumEvery  = 10 * time.Minute
+)
+
+// Config enables the orphaned file finder. Every is how often to scan; 0 only scans when triggered.
+type Config struct {
+	Every cnfg.Duration `json:"every"       toml:"every"        xml:"every"        yaml:"every"`
+	// Paths are download folders to scan in addition to the torrent clients' save paths.
+	// Usenet clients do not provide their completed folder, so add it here.
+	Paths []string `json:"paths" toml:"paths" xml:"paths" yaml:"paths"`
+	// MinAge ignores files and folders modified more recently than this. Default is 24 hours.
+	MinAge cnfg.Duration `json:"minAge" toml:"min_age" xml:"min_age" yaml:"minAge"`
+	// Exclude ignores files and folders whose name matches any of these glob patterns.
+	Exclude []string `json:"exclude" toml:"exclude" xml:"exclude" yaml:"exclude"`
+	// RootFolders also reports unmapped folders in the Lidarr, Radarr and Sonarr root folders.
+	RootFolders bool `json:"rootFolders" toml:"root_folders" xml:"root_folders" yaml:"rootFolders"`
+}
+
+// Enabled returns true if the orphaned file finder is configured.
+func (c *Config) Enabled() bool {
+	return c != nil
+}
+
+// excluded returns true if the name matches an exclude pattern, or is hidden.
+func (c *Config) excluded(name string) bool {
+	if strings.HasPrefix(name, ".") {
+		return true
+	}
+
+	for _, pattern := range c.Exclude {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// minAge returns the configured minimum age, or the default.
+func (c *Config) minAge() time.Duration {
+	if c.MinAge.Duration <= 0 {
+		return defaultMinAge
+	}
+
+	return c.MinAge.Duration
+}
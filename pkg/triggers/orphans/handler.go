@@ -0,0 +1,127 @@
+package orphans
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+)
+
+// Errors returned while deleting orphans.
+var (
+	ErrNotReported = errors.New("path is not in the orphan report; run a new scan")
+	ErrNowLinked   = errors.New("path is hard-linked now; not an orphan")
+)
+
+// DeleteRequest is the input to delete orphans. Paths must be in the last report.
+type DeleteRequest struct {
+	Paths []string `json:"paths"`
+}
+
+// ReportHandler is passed into the webserver as an HTTP handler.
+//
+//	@Description	Returns the orphaned files and folders from the last scan.
+//	@Description	A new scan is run if there is no report yet, or refresh is true. Scans may take a while.
+//	@Summary		Orphaned download files report
+//	@Tags			Downloaders
+//	@Produce		json
+//	@Param			refresh	query		bool								false	"run a new scan"
+//	@Success		200		{object}	apps.APIResponse{message=Report}	"orphan report"
+//	@Failure		501		{object}	apps.APIResponse{message=string}	"orphaned file finder not enabled"
+//	@Failure		404		{object}	string								"bad token or api key"
+//	@Router			/orphans [get]
+//	@Security		ApiKeyAuth
+func (a *Action) ReportHandler(req *http.Request) (int, any) {
+	if !a.cmd.orphans.Enabled() {
+		return http.StatusNotImplemented, "orphaned file finder not enabled"
+	}
+
+	a.cmd.mu.Lock()
+	report := a.cmd.report
+	a.cmd.mu.Unlock()
+
+	if report == nil || req.URL.Query().Get("refresh") == "true" {
+		report = a.cmd.scan(req.Context())
+	}
+
+	return http.StatusOK, report
+}
+
+// DeleteHandler is passed into the webserver as an HTTP handler.
+//
+//	@Description	Deletes orphaned files and folders. Only paths in the last report may be deleted,
+//	@Description	and each is checked for new hard links first. Returns an error, or "deleted", for each path.
+//	@Summary		Delete orphaned download files
+//	@Tags			Downloaders
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		DeleteRequest									true	"paths to delete"
+//	@Success		200		{object}	apps.APIResponse{message=map[string]string}		"result per path"
+//	@Failure		400		{object}	apps.APIResponse{message=string}				"invalid json or no paths"
+//	@Failure		501		{object}	apps.APIResponse{message=string}				"orphaned file finder not enabled"
+//	@Failure		404		{object}	string											"bad token or api key"
+//	@Router			/orphans [delete]
+//	@Security		ApiKeyAuth
+func (a *Action) DeleteHandler(req *http.Request) (int, any) {
+	if !a.cmd.orphans.Enabled() {
+		return http.StatusNotImplemented, "orphaned file finder not enabled"
+	}
+
+	var input DeleteRequest
+	if err := json.NewDecoder(req.Body).Decode(&input); err != nil {
+		return http.StatusBadRequest, fmt.Errorf("decoding request: %w", err)
+	} else if len(input.Paths) == 0 {
+		return http.StatusBadRequest, "no paths provided"
+	}
+
+	reqID := mnd.GetID(req.Context())
+	output := make(map[string]string, len(input.Paths))
+
+	for _, path := range input.Paths {
+		if err := a.cmd.delete(path); err != nil {
+			output[path] = err.Error()
+			continue
+		}
+
+		output[path] = "deleted"
+		mnd.Log.Printf(reqID, "Deleted orphaned download path: %s", path)
+	}
+
+	return http.StatusOK, output
+}
+
+// delete removes an orphan from disk and from the report.
+func (c *cmd) delete(path string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.report == nil {
+		return ErrNotReported
+	}
+
+	for idx, orphan := range c.report.Orphans {
+		if orphan.Path != path {
+			continue
+		}
+
+		if linked, err := mnd.HardLinked(path); err != nil {
+			return err
+		} else if linked {
+			return ErrNowLinked
+		}
+
+		if err := os.RemoveAll(path); err != nil {
+			return fmt.Errorf("deleting: %w", err)
+		}
+
+		c.report.Size -= orphan.Size
+		c.report.Orphans = append(c.report.Orphans[:idx], c.report.Orphans[idx+1:]...)
+
+		return nil
+	}
+
+	return ErrNotReported
+}
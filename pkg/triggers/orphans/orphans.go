@@ -0,0 +1,377 @@
+// Package orphans finds files and folders in download folders and starr root folders that nothing
+// knows about anymore; leftovers from failed imports and removed downloads. They are reported, and
+// may be deleted through the API after review.
+package orphans
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"golift.io/cnfg"
+	"golift.io/starr"
+)
+
+const TrigOrphans common.TriggerName = "Finding orphaned download files."
+
+// Kinds of orphans.
+const (
+	KindDownload   = "download"   // Not in any download client, and not imported.
+	KindRootFolder = "rootFolder" // Unmapped folder in a starr app root folder.
+)
+
+// Action contains the exported methods for this package.
+type Action struct {
+	cmd *cmd
+}
+
+type cmd struct {
+	*common.Config
+	orphans *Config
+	mu      sync.Mutex
+	report  *Report
+}
+
+// Report is the result of an orphaned file scan.
+type Report struct {
+	Orphans []*Orphan `json:"orphans"`
+	Size    int64     `json:"size"`
+	Scanned []string  `json:"scanned"` // Folders that were scanned.
+	Errors  []string  `json:"errors,omitempty"`
+	Date    time.Time `json:"date"`
+	Elapsed string    `json:"elapsed"`
+}
+
+// Orphan is a file or folder that no download client or starr app knows about.
+type Orphan struct {
+	Path     string    `json:"path"`
+	Kind     string    `json:"kind"`
+	Source   string    `json:"source"` // The app and instance, or config, that provided the parent folder.
+	Size     int64     `json:"size"`
+	Dir      bool      `json:"dir"`
+	Modified time.Time `json:"modified"`
+}
+
+// scanDir is a folder to look for orphans in.
+type scanDir struct {
+	path   string
+	kind   string
+	source string
+}
+
+// New configures the library.
+func New(config *common.Config, orphans *Config) *Action {
+	return &Action{cmd: &cmd{Config: config, orphans: orphans}}
+}
+
+// Create initializes the library.
+func (a *Action) Create() {
+	a.cmd.create(mnd.ReqID())
+}
+
+func (c *cmd) create(reqID string) {
+	if !c.orphans.Enabled() {
+		return
+	}
+
+	action := &common.Action{
+		Key:  "TrigOrphans",
+		Name: TrigOrphans,
+		Fn:   c.find,
+		C:    make(chan *common.ActionInput, 1),
+	}
+
+	if c.orphans.Every.Duration > 0 && c.orphans.Every.Duration < minimumEvery {
+		c.orphans.Every.Duration = minimumEvery
+	}
+
+	if c.orphans.Every.Duration > 0 {
+		action.D = cnfg.Duration{Duration: c.orphans.Every.Duration}
+	}
+
+	mnd.Log.Printf(reqID, "==> Orphaned File Finder Enabled, paths:%d min_age:%s root_folders:%v interval:%s",
+		len(c.orphans.Paths), c.orphans.minAge(), c.orphans.RootFolders, c.orphans.Every)
+	c.Add(action)
+}
+
+// Find scans for orphaned files now. Returns false if the finder is not enabled.
+func (a *Action) Find(input *common.ActionInput) bool {
+	return a.cmd.Exec(input, TrigOrphans)
+}
+
+func (c *cmd) find(ctx context.Context, input *common.ActionInput) {
+	report := c.scan(mnd.WithID(ctx, input.ReqID))
+
+	for _, err := range report.Errors {
+		mnd.Log.Errorf(input.ReqID, "[%s requested] Orphaned file finder: %s", input.Type, err)
+	}
+
+	mnd.Log.Printf(input.ReqID, "[%s requested] Orphaned file finder scanned %d folders in %s; found %d orphans using %s.",
+		input.Type, len(report.Scanned), report.Elapsed, len(report.Orphans), mnd.FormatBytes(report.Size))
+}
+
+// scan finds the orphans and saves the report for the API.
+func (c *cmd) scan(ctx context.Context) *Report {
+	start := time.Now()
+	report := &Report{Orphans: []*Orphan{}, Scanned: []string{}, Date: start}
+	known := map[string]bool{}
+	dirs := c.downloadDirs(ctx, report, known)
+	// Root folders are always collected, so a library inside a download folder is never reported.
+	unmapped := c.rootFolders(ctx, report, known)
+	// A download folder inside a scanned folder is not an orphan.
+	addParents(known)
+
+	if c.orphans.RootFolders {
+		dirs = append(dirs, unmapped...)
+	}
+
+	cutoff := start.Add(-c.orphans.minAge())
+
+	for _, dir := range dirs {
+		if dir.kind == KindRootFolder {
+			// Unmapped root folder items are given to us directly.
+			c.check(report, dir, dir.path, cutoff)
+			continue
+		}
+
+		entries, err := os.ReadDir(dir.path)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("reading %s: %v", dir.path, err))
+			continue
+		}
+
+		report.Scanned = append(report.Scanned, dir.path)
+
+		for _, entry := range entries {
+			if entryPath := filepath.Join(dir.path, entry.Name()); !known[entryPath] && !c.orphans.excluded(entry.Name()) {
+				c.check(report, dir, entryPath, cutoff)
+			}
+		}
+	}
+
+	report.Elapsed = time.Since(start).Round(time.Millisecond).String()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.report = report
+
+	return report
+}
+
+// check adds the path to the report if it's old enough and not hard-linked into a library.
+func (c *cmd) check(report *Report, dir *scanDir, entryPath string, cutoff time.Time) {
+	info, err := os.Stat(entryPath)
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+		return
+	}
+
+	size, modified, err := mnd.DiskUsage(entryPath)
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+		return
+	}
+
+	if modified.After(cutoff) {
+		return
+	}
+
+	if linked, err := mnd.HardLinked(entryPath); err != nil {
+		report.Errors = append(report.Errors, err.Error())
+		return
+	} else if linked {
+		return // Imported into a library.
+	}
+
+	report.Size += size
+	report.Orphans = append(report.Orphans, &Orphan{
+		Path:     entryPath,
+		Kind:     dir.kind,
+		Source:   dir.source,
+		Size:     size,
+		Dir:      info.IsDir(),
+		Modified: modified,
+	})
+}
+
+// downloader is an enabled download client.
+type downloader struct {
+	apps.Downloader
+	source string
+	usenet bool
+}
+
+// downloadDirs returns the folders downloads are saved in, and fills known with every download's path.
+func (c *cmd) downloadDirs(ctx context.Context, report *Report, known map[string]bool) []*scanDir {
+	clients := []*downloader{}
+
+	for _, app := range apps.DownloaderApps() {
+		for idx, client := range c.Apps.Downloaders(app) {
+			if client.Enabled() {
+				clients = append(clients, &downloader{
+					Downloader: client,
+					source:     fmt.Sprint(app, " ", idx+1),
+					usenet:     app == apps.AppNZBGet || app == apps.AppSabNZB,
+				})
+			}
+		}
+	}
+
+	return downloadDirs(ctx, report, known, c.orphans.Paths, clients)
+}
+
+// downloadDirs returns the configured paths and the clients' save folders. Returns nothing if any client fails,
+// because the transfers in that client are unknown, and anything in the download folders may belong to one.
+func downloadDirs(
+	ctx context.Context, report *Report, known map[string]bool, paths []string, clients []*downloader,
+) []*scanDir {
+	dirs := []*scanDir{}
+	seen := map[string]bool{}
+	add := func(dir, source string) {
+		if dir = filepath.Clean(dir); dir != "." && !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, &scanDir{path: dir, kind: KindDownload, source: source})
+		}
+	}
+
+	for _, dir := range paths {
+		add(dir, "config")
+	}
+
+	// Save folders of clients with a transfer in an unknown location. Anything in them may belong to that transfer.
+	unknown := map[string]string{}
+
+	failed := []string{}
+
+	for _, client := range clients {
+		xfers, err := client.Transfers(ctx)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("getting %s transfers: %v", client.source, err))
+			failed = append(failed, client.source)
+
+			continue
+		}
+
+		saveDirs, missing := knownPaths(xfers, client.usenet, known)
+		for _, dir := range saveDirs {
+			if missing > 0 {
+				unknown[dir] = fmt.Sprintf("%s has %d transfers with no save folder", client.source, missing)
+			} else {
+				add(dir, client.source)
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		for _, dir := range dirs {
+			report.Errors = append(report.Errors, fmt.Sprintf("skipped %s: transfers unknown for %s",
+				dir.path, strings.Join(failed, ", ")))
+		}
+
+		return []*scanDir{}
+	}
+
+	output := make([]*scanDir, 0, len(dirs))
+
+	for _, dir := range dirs {
+		if reason := unknown[dir.path]; reason != "" {
+			report.Errors = append(report.Errors, fmt.Sprintf("skipped %s: %s", dir.path, reason))
+		} else {
+			output = append(output, dir)
+		}
+	}
+
+	return output
+}
+
+// knownPaths adds the path of every transfer to known. Returns the torrent save folders,
+// and the count of transfers with no path. Usenet clients return no save folders.
+func knownPaths(xfers []*apps.Transfer, usenet bool, known map[string]bool) ([]string, int) {
+	var (
+		saveDirs []string
+		missing  int
+	)
+
+	for _, xfer := range xfers {
+		if xfer.Path == "" {
+			if !usenet { // Queued usenet items have no path yet.
+				missing++
+			}
+
+			continue
+		}
+
+		saveDir := filepath.Clean(xfer.Path)
+		// Some clients provide the content path instead of the save folder.
+		if filepath.Base(saveDir) == xfer.Name || usenet {
+			known[saveDir] = true
+			saveDir = filepath.Dir(saveDir)
+		}
+
+		known[filepath.Join(saveDir, xfer.Name)] = true
+
+		if !usenet && !slices.Contains(saveDirs, saveDir) {
+			saveDirs = append(saveDirs, saveDir)
+		}
+	}
+
+	return saveDirs, missing
+}
+
+// addParents adds the parent folders of every known path to known.
+func addParents(known map[string]bool) {
+	for knownPath := range known {
+		for dir := filepath.Dir(knownPath); !known[dir]; dir = filepath.Dir(dir) {
+			known[dir] = true
+		}
+	}
+}
+
+// rootFolders returns the unmapped folders in the starr app root folders, and adds the root folders to known.
+func (c *cmd) rootFolders(ctx context.Context, report *Report, known map[string]bool) []*scanDir {
+	dirs := []*scanDir{}
+
+	for _, instance := range c.Apps.StarrInstances(starr.Lidarr, starr.Radarr, starr.Sonarr) {
+		if !instance.Enabled() {
+			continue
+		}
+
+		var folders []*struct {
+			Path     string        `json:"path"`
+			Unmapped []*starr.Path `json:"unmappedFolders"`
+		}
+
+		err := instance.StarrClient().GetInto(ctx, starr.Request{URI: instance.Starr().APIPath("rootFolder")}, &folders)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("getting %s %d root folders: %v",
+				instance.App(), instance.Instance(), err))
+			continue
+		}
+
+		source := fmt.Sprint(strings.ToLower(instance.App().String()), " ", instance.Instance())
+
+		for _, folder := range folders {
+			known[filepath.Clean(folder.Path)] = true
+
+			if c.orphans.RootFolders {
+				report.Scanned = append(report.Scanned, folder.Path)
+			}
+
+			for _, unmapped := range folder.Unmapped {
+				if unmapped != nil && unmapped.Path != "" && !c.orphans.excluded(unmapped.Name) {
+					dirs = append(dirs, &scanDir{path: filepath.Clean(unmapped.Path), kind: KindRootFolder, source: source})
+				}
+			}
+		}
+	}
+
+	return dirs
+}
@@ -0,0 +1,91 @@
+package orphans //nolint:testpackage
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKnownPaths(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	downloads := filepath.Join("/data", "torrents")
+	known := map[string]bool{}
+	xfers := []*apps.Transfer{
+		{Name: "single.mkv", Path: downloads},                                    // save folder.
+		{Name: "Some.Show.S01", Path: filepath.Join(downloads, "Some.Show.S01")}, // content path.
+		{Name: "stopped", Path: ""},
+	}
+
+	saveDirs, missing := knownPaths(xfers, false, known)
+	assert.Equal([]string{downloads}, saveDirs, "both transfers are in the same save folder")
+	assert.Equal(1, missing, "one transfer has no path")
+	assert.True(known[filepath.Join(downloads, "single.mkv")])
+	assert.True(known[filepath.Join(downloads, "Some.Show.S01")])
+
+	known = map[string]bool{}
+	saveDirs, missing = knownPaths([]*apps.Transfer{
+		{Name: "queued"},
+		{Name: "done", Path: filepath.Join("/data", "usenet", "done")},
+	}, true, known)
+	assert.Empty(saveDirs, "usenet clients provide no save folders")
+	assert.Zero(missing, "queued usenet items have no path yet")
+	assert.True(known[filepath.Join("/data", "usenet", "done")])
+}
+
+var errOffline = errors.New("client offline")
+
+// fakeClient is a download client that returns transfers or an error.
+type fakeClient struct {
+	apps.Downloader
+	xfers []*apps.Transfer
+	err   error
+}
+
+func (f *fakeClient) Transfers(_ context.Context) ([]*apps.Transfer, error) {
+	return f.xfers, f.err
+}
+
+func TestDownloadDirs(t *testing.T) {
+	t.Parallel()
+
+	complete := filepath.Join("/downloads", "complete")
+	torrents := filepath.Join("/downloads", "torrents")
+	qbit := &downloader{
+		Downloader: &fakeClient{xfers: []*apps.Transfer{{Name: "movie.mkv", Path: torrents}}},
+		source:     "qbittorrent 1",
+	}
+	down := &downloader{Downloader: &fakeClient{err: errOffline}, source: "deluge 1"}
+
+	report := &Report{}
+	known := map[string]bool{}
+	dirs := downloadDirs(t.Context(), report, known, []string{complete}, []*downloader{qbit})
+	require.Len(t, dirs, 2)
+	assert.Equal(t, complete, dirs[0].path)
+	assert.Equal(t, torrents, dirs[1].path)
+	assert.Empty(t, report.Errors)
+
+	report = &Report{}
+	dirs = downloadDirs(t.Context(), report, map[string]bool{}, []string{complete}, []*downloader{qbit, down})
+	assert.Empty(t, dirs, "nothing is scanned while a client's transfers are unknown")
+	assert.Len(t, report.Errors, 3, "the client error, and both skipped folders")
+}
+
+func TestAddParents(t *testing.T) {
+	t.Parallel()
+
+	known := map[string]bool{filepath.Join("/downloads", "complete", "tv", "Some.Show.S01"): true}
+	addParents(known)
+
+	for _, dir := range []string{filepath.Join("/downloads", "complete", "tv"), filepath.Join("/downloads", "complete")} {
+		assert.True(t, known[dir], "a configured parent folder is not an orphan: %s", dir)
+	}
+
+	assert.False(t, known[filepath.Join("/downloads", "complete", "movies")])
+}
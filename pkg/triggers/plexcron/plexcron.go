@@ -2,6 +2,7 @@ package plexcron
 
 import (
 	"context"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -27,14 +28,16 @@ type Action struct {
 
 type cmd struct {
 	*common.Config
-	Plex *apps.Plex
-	sent map[string]struct{} // Tracks Finished sessions already sent.
+	Plex     *apps.Plex
+	sent     map[string]struct{} // Tracks Finished sessions already sent.
+	throttle throttle            // Tracks download client throttling.
 	sync.Mutex
 }
 
 const (
 	TrigPlexSessions      common.TriggerName = "Gathering and sending Plex Sessions."
 	TrigPlexSessionsCheck common.TriggerName = "Checking Plex for completed sessions."
+	TrigPlexThrottle      common.TriggerName = "Checking Plex sessions for download throttling."
 )
 
 // Statuses for an item being played on Plex.
@@ -47,15 +50,21 @@ const (
 	statusSent     = "sent"
 )
 
-// New configures the library.
-func New(config *common.Config, plex *apps.Plex) *Action {
-	return &Action{
+// New configures the library. The throttled speed limits are saved next to the config file.
+func New(config *common.Config, plex *apps.Plex, configFile string) *Action {
+	action := &Action{
 		cmd: &cmd{
 			Config: config,
 			Plex:   plex,
 			sent:   make(map[string]struct{}),
 		},
 	}
+
+	if configFile != "" {
+		action.cmd.throttle.file = filepath.Join(filepath.Dir(configFile), ThrottleFile)
+	}
+
+	return action
 }
 
 // Send sends plex sessions in a go routine through a channel.
@@ -94,6 +103,21 @@ func (c *cmd) run(reqID string) {
 		D:    cnfg.Duration{Duration: dur},
 	})
 
+	if c.Plex.Throttle.Enabled() {
+		mnd.Log.Printf(reqID,
+			"==> Plex Download Throttle Started, URL: %s, interval:%s sessions:%d bandwidth:%dkbps clients:%q",
+			c.Plex.Server.URL, throttleInterval, c.Plex.Throttle.Sessions, c.Plex.Throttle.Bandwidth, c.Plex.Throttle.Clients)
+
+		c.Add(&common.Action{
+			Key:  "TrigPlexThrottle",
+			Name: TrigPlexThrottle,
+			Hide: true, // do not log this one.
+			Fn:   c.checkThrottle,
+			D: cnfg.Duration{Duration: throttleInterval +
+				time.Duration(c.Config.Rand().Intn(randomMilliseconds2))*time.Millisecond},
+		})
+	}
+
 	if cfg.MoviesPC != 0 || cfg.SeriesPC != 0 || cfg.TrackSess {
 		mnd.Log.Printf(reqID,
 			"==> Plex Sessions Tracker Started, URL: %s, interval:1m timeout:%s movies:%d%% series:%d%% play:%v",
@@ -0,0 +1,279 @@
+package plexcron
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/plex"
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"golift.io/starr"
+)
+
+const (
+	throttleInterval = 30 * time.Second
+	// defaultRestore is used when the throttle config has no restore duration.
+	defaultRestore = 2 * time.Minute
+	transcode      = "transcode"
+)
+
+// ThrottleFile is saved next to the config file while download clients are throttled.
+// It holds the speed limits to restore, so a restart does not leave the clients throttled.
+const ThrottleFile = "plex_throttle.json"
+
+// throttle tracks the state of the download client throttle.
+type throttle struct {
+	active bool
+	calm   time.Time                    // when the sessions dropped below the thresholds.
+	saved  map[string]*apps.SpeedLimits // limits each client had before throttling, by client name.
+	file   string                       // Empty keeps the saved limits in memory only.
+	loaded bool                         // true after the throttle file is checked at startup.
+}
+
+// checkThrottle is fired by a timer. It applies the alternate speed limits when Plex is busy,
+// and restores the saved limits once Plex has been calm for the restore duration.
+// Plex is calm when it's below the restore thresholds, which may be lower than the throttle thresholds.
+func (c *cmd) checkThrottle(ctx context.Context, input *common.ActionInput) {
+	cfg := c.Plex.Throttle
+
+	if !c.throttle.loaded {
+		c.throttle.loaded = true
+		c.loadThrottle(input.ReqID)
+	}
+
+	sessions, err := c.getSessions(ctx, throttleInterval)
+	if err != nil {
+		mnd.Log.Errorf(input.ReqID, "Getting Plex sessions for throttle: %v", err)
+		return
+	}
+
+	streams, bandwidth := countStreams(sessions)
+	busy, calm := thresholds(cfg, streams, bandwidth)
+	restore := cfg.Restore.Duration
+
+	if restore <= 0 {
+		restore = defaultRestore
+	}
+
+	switch c.throttle.next(busy, calm, restore, time.Now()) {
+	case throttleStart:
+		mnd.Log.Printf(input.ReqID, "Plex is busy (%d streams, %d kbps); throttling download clients.", streams, bandwidth)
+		c.saveSpeedLimits(ctx, input.ReqID)
+		c.eachClient(func(name string, client apps.Downloader) {
+			c.setSpeedLimits(ctx, input.ReqID, name, client, &apps.SpeedLimits{
+				Download: cfg.Download * mnd.Kilobyte,
+				Upload:   cfg.Upload * mnd.Kilobyte,
+			})
+		})
+	case throttleRestore:
+		mnd.Log.Printf(input.ReqID, "Plex has been calm for %s; restoring download client speed limits.", restore)
+		c.restoreSpeedLimits(ctx, input.ReqID)
+	case throttleWait:
+	}
+}
+
+// throttleStep is what checkThrottle does after a check.
+type throttleStep int
+
+const (
+	throttleWait throttleStep = iota
+	throttleStart
+	throttleRestore
+)
+
+// thresholds returns true for busy if Plex reached either throttle threshold,
+// and true for calm if Plex is below both restore thresholds. Plex may be neither.
+func thresholds(cfg *apps.PlexThrottle, streams int, bandwidth int64) (bool, bool) {
+	restoreSessions, restoreBandwidth := cfg.RestoreSessions, cfg.RestoreBandwidth
+	if restoreSessions <= 0 || restoreSessions > cfg.Sessions {
+		restoreSessions = cfg.Sessions
+	}
+
+	if restoreBandwidth <= 0 || restoreBandwidth > cfg.Bandwidth {
+		restoreBandwidth = cfg.Bandwidth
+	}
+
+	busy := (cfg.Sessions > 0 && streams >= cfg.Sessions) || (cfg.Bandwidth > 0 && bandwidth >= cfg.Bandwidth)
+	calm := (cfg.Sessions <= 0 || streams < restoreSessions) && (cfg.Bandwidth <= 0 || bandwidth < restoreBandwidth)
+
+	return busy, calm
+}
+
+// next updates the throttle state with the current Plex activity, and returns the step to take.
+// The restore timer runs while Plex is calm, and starts over when it is not.
+func (t *throttle) next(busy, calm bool, restore time.Duration, now time.Time) throttleStep {
+	switch {
+	case busy:
+		t.calm = time.Time{}
+
+		if !t.active {
+			t.active = true
+			return throttleStart
+		}
+	case !t.active:
+	case !calm:
+		t.calm = time.Time{}
+	case t.calm.IsZero():
+		t.calm = now
+	case now.Sub(t.calm) >= restore:
+		return throttleRestore
+	}
+
+	return throttleWait
+}
+
+// saveSpeedLimits stores the current speed limits of every throttled client, and writes the throttle file.
+func (c *cmd) saveSpeedLimits(ctx context.Context, reqID string) {
+	c.throttle.saved = make(map[string]*apps.SpeedLimits)
+
+	c.eachClient(func(name string, client apps.Downloader) {
+		limits, err := client.SpeedLimits(ctx)
+		if err != nil {
+			mnd.Log.Errorf(reqID, "Getting %s speed limits; they will be unlimited after throttling: %v", name, err)
+			return
+		}
+
+		c.throttle.saved[name] = limits
+	})
+
+	if c.throttle.file == "" {
+		return
+	}
+
+	data, err := json.Marshal(c.throttle.saved)
+	if err == nil {
+		err = os.WriteFile(c.throttle.file, data, mnd.Mode0600)
+	}
+
+	if err != nil {
+		mnd.Log.Errorf(reqID, "Saving download client speed limits to %s: %v", c.throttle.file, err)
+	}
+}
+
+// restoreSpeedLimits sets the saved speed limits on every throttled client, and removes the throttle file.
+// The normal limits in the throttle config override the saved limits when they are not zero.
+func (c *cmd) restoreSpeedLimits(ctx context.Context, reqID string) {
+	cfg := c.Plex.Throttle
+
+	c.eachClient(func(name string, client apps.Downloader) {
+		limits := &apps.SpeedLimits{}
+		if saved := c.throttle.saved[name]; saved != nil {
+			limits = saved
+		}
+
+		if cfg.NormalDownload > 0 {
+			limits.Download = cfg.NormalDownload * mnd.Kilobyte
+		}
+
+		if cfg.NormalUpload > 0 {
+			limits.Upload = cfg.NormalUpload * mnd.Kilobyte
+		}
+
+		c.setSpeedLimits(ctx, reqID, name, client, limits)
+	})
+
+	c.throttle = throttle{file: c.throttle.file, loaded: true}
+
+	if c.throttle.file == "" {
+		return
+	}
+
+	if err := os.Remove(c.throttle.file); err != nil && !errors.Is(err, os.ErrNotExist) {
+		mnd.Log.Errorf(reqID, "Removing %s: %v", c.throttle.file, err)
+	}
+}
+
+// loadThrottle reads the throttle file left by a previous run that stopped while throttling.
+// The clients are treated as throttled, so their limits are restored once Plex is calm.
+func (c *cmd) loadThrottle(reqID string) {
+	if c.throttle.file == "" {
+		return
+	}
+
+	data, err := os.ReadFile(c.throttle.file)
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	} else if err != nil {
+		mnd.Log.Errorf(reqID, "Reading download client speed limits: %v", err)
+		return
+	}
+
+	saved := make(map[string]*apps.SpeedLimits)
+	if err := json.Unmarshal(data, &saved); err != nil {
+		mnd.Log.Errorf(reqID, "Decoding download client speed limits %s: %v", c.throttle.file, err)
+		return
+	}
+
+	mnd.Log.Printf(reqID, "Download clients were throttled before the last restart; restoring their limits when Plex is calm.")
+
+	c.throttle.active = true
+	c.throttle.saved = saved
+}
+
+// countStreams returns the number of remote or transcoding streams, and the total bandwidth of all active sessions.
+func countStreams(sessions *plex.Sessions) (int, int64) {
+	var (
+		streams   int
+		bandwidth int64
+	)
+
+	for _, session := range sessions.Sessions {
+		if session.Player.State == paused {
+			continue
+		}
+
+		bandwidth += session.Session.Bandwidth
+
+		if !session.Player.Local || session.TranscodeSession.VideoDecision == transcode {
+			streams++
+		}
+	}
+
+	return streams, bandwidth
+}
+
+// eachClient calls fn for every enabled download client in the throttle config.
+// The name identifies the client, like "Qbit 1".
+func (c *cmd) eachClient(fn func(name string, client apps.Downloader)) {
+	for _, app := range apps.DownloaderApps() {
+		if !c.throttleClient(app) {
+			continue
+		}
+
+		for idx, client := range c.Apps.Downloaders(app) {
+			if client.Enabled() {
+				fn(fmt.Sprintf("%s %d", app, idx+1), client)
+			}
+		}
+	}
+}
+
+// setSpeedLimits sets the speed limits on a download client.
+func (c *cmd) setSpeedLimits(ctx context.Context, reqID, name string, client apps.Downloader, limits *apps.SpeedLimits) {
+	if err := client.SetSpeedLimits(ctx, limits); err != nil {
+		mnd.Log.Errorf(reqID, "Setting %s speed limits: %v", name, err)
+	} else {
+		mnd.Log.Debugf(reqID, "Set %s speed limits: download=%d upload=%d bytes/s", name, limits.Download, limits.Upload)
+	}
+}
+
+// throttleClient returns true if the download client app is included in the throttle config.
+func (c *cmd) throttleClient(app starr.App) bool {
+	if len(c.Plex.Throttle.Clients) == 0 {
+		return true
+	}
+
+	for _, name := range c.Plex.Throttle.Clients {
+		if strings.EqualFold(name, string(app)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,134 @@
+package plexcron //nolint:testpackage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/plex"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSession(state string, local bool, decision string, bandwidth int64) *plex.Session {
+	session := &plex.Session{}
+	session.Player.State = state
+	session.Player.Local = local
+	session.TranscodeSession.VideoDecision = decision
+	session.Session.Bandwidth = bandwidth
+
+	return session
+}
+
+func TestCountStreams(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		sessions  []*plex.Session
+		streams   int
+		bandwidth int64
+	}{
+		{name: "none", streams: 0, bandwidth: 0},
+		{name: "local direct play", sessions: []*plex.Session{newSession("playing", true, "directplay", 100)}, bandwidth: 100},
+		{name: "remote", sessions: []*plex.Session{newSession("playing", false, "directplay", 200)}, streams: 1, bandwidth: 200},
+		{name: "local transcode", sessions: []*plex.Session{newSession("playing", true, transcode, 300)}, streams: 1, bandwidth: 300},
+		{name: "paused remote", sessions: []*plex.Session{newSession(paused, false, transcode, 400)}},
+		{
+			name: "mixed",
+			sessions: []*plex.Session{
+				newSession("playing", false, "directplay", 100),
+				newSession("buffering", true, transcode, 200),
+				newSession("playing", true, "copy", 300),
+				newSession(paused, false, "directplay", 400),
+			},
+			streams:   2,
+			bandwidth: 600,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			streams, bandwidth := countStreams(&plex.Sessions{Sessions: test.sessions})
+			assert.Equal(t, test.streams, streams, "wrong stream count")
+			assert.Equal(t, test.bandwidth, bandwidth, "wrong bandwidth")
+		})
+	}
+}
+
+func TestThrottleNext(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	var (
+		state   throttle
+		now     = time.Now()
+		restore = 2 * time.Minute
+	)
+
+	assert.Equal(throttleWait, state.next(false, true, restore, now), "calm and not throttled does nothing")
+	assert.Equal(throttleStart, state.next(true, false, restore, now), "busy starts throttling")
+	assert.True(state.active)
+	assert.Equal(throttleWait, state.next(true, false, restore, now), "busy while throttled does nothing")
+	assert.Equal(throttleWait, state.next(false, true, restore, now), "the first calm check starts the restore timer")
+	assert.Equal(now, state.calm)
+	assert.Equal(throttleWait, state.next(false, true, restore, now.Add(time.Minute)), "calm for less than restore")
+	assert.Equal(throttleWait, state.next(true, false, restore, now.Add(time.Minute)), "busy again resets the timer")
+	assert.True(state.calm.IsZero())
+	assert.Equal(throttleWait, state.next(false, true, restore, now.Add(2*time.Minute)))
+	assert.Equal(throttleWait, state.next(false, true, restore, now.Add(3*time.Minute)))
+	assert.Equal(throttleWait, state.next(false, false, restore, now.Add(4*time.Minute)), "between the thresholds")
+	assert.True(state.calm.IsZero(), "not calm resets the timer")
+	assert.Equal(throttleWait, state.next(false, true, restore, now.Add(5*time.Minute)))
+	assert.Equal(throttleRestore, state.next(false, true, restore, now.Add(7*time.Minute)), "calm for the restore duration")
+}
+
+func TestThresholds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		config    apps.PlexThrottle
+		streams   int
+		bandwidth int64
+		busy      bool
+		calm      bool
+	}{
+		{name: "sessions busy", config: apps.PlexThrottle{Sessions: 2}, streams: 2, busy: true},
+		{name: "sessions calm", config: apps.PlexThrottle{Sessions: 2}, streams: 1, calm: true},
+		{name: "restore sessions", config: apps.PlexThrottle{Sessions: 3, RestoreSessions: 1}, streams: 1},
+		{name: "restore sessions calm", config: apps.PlexThrottle{Sessions: 3, RestoreSessions: 1}, calm: true},
+		{name: "restore over sessions", config: apps.PlexThrottle{Sessions: 2, RestoreSessions: 5}, streams: 2, busy: true},
+		{name: "bandwidth busy", config: apps.PlexThrottle{Bandwidth: 1000}, bandwidth: 1000, busy: true},
+		{name: "restore bandwidth", config: apps.PlexThrottle{Bandwidth: 1000, RestoreBandwidth: 500}, bandwidth: 700},
+		{
+			name:      "restore bandwidth calm",
+			config:    apps.PlexThrottle{Bandwidth: 1000, RestoreBandwidth: 500},
+			bandwidth: 400,
+			calm:      true,
+		},
+		{
+			name:      "calm needs both",
+			config:    apps.PlexThrottle{Sessions: 2, Bandwidth: 1000, RestoreBandwidth: 500},
+			bandwidth: 700,
+		},
+		{
+			name:      "either is busy",
+			config:    apps.PlexThrottle{Sessions: 2, Bandwidth: 1000},
+			streams:   2,
+			bandwidth: 10,
+			busy:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			busy, calm := thresholds(&test.config, test.streams, test.bandwidth)
+			assert.Equal(t, test.busy, busy, "wrong busy")
+			assert.Equal(t, test.calm, calm, "wrong calm")
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package seeding
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common/scheduler"
+	"golift.io/cnfg"
+	"golift.io/starr"
+)
+
+// Actions a policy may take on a torrent that satisfied its seeding requirements.
+const (
+	ActionPause  = "pause"
+	ActionRemove = "remove"
+)
+
+// Config is the seeding policy configuration. Policies are evaluated in order; the first match wins.
+// The embedded CronJob schedules the enforcement. Every is used if the CronJob has no frequency or cron expression.
+type Config struct {
+	scheduler.CronJob
+	Every    cnfg.Duration `json:"every"    toml:"every"     xml:"every"     yaml:"every"`
+	DryRun   bool          `json:"dryRun"   toml:"dry_run"   xml:"dry_run"   yaml:"dryRun"`
+	Policies []*Policy     `json:"policies" toml:"policy"    xml:"policy"    yaml:"policies"`
+}
+
+// Policy matches torrents by tracker and category, and pauses or removes them once the minimum
+// ratio and seed time are both satisfied. A zero minimum is always satisfied.
+type Policy struct {
+	Name string `json:"name" toml:"name" xml:"name" yaml:"name"`
+	// Trackers match any part of the torrent's tracker URL. Empty matches every tracker.
+	Trackers []string `json:"trackers" toml:"trackers" xml:"trackers" yaml:"trackers"`
+	// Categories match the torrent's category or label exactly. Empty matches every category.
+	Categories []string `json:"categories" toml:"categories" xml:"categories" yaml:"categories"`
+	// Clients limits the policy to these torrent clients, like qbit or deluge. Empty means all.
+	Clients  []string      `json:"clients"  toml:"clients"   xml:"clients"   yaml:"clients"`
+	Ratio    float64       `json:"ratio"    toml:"ratio"     xml:"ratio"     yaml:"ratio"`
+	SeedTime cnfg.Duration `json:"seedTime" toml:"seed_time" xml:"seed_time" yaml:"seedTime"`
+	// Action is pause or remove. DeleteData removes the torrent's data too.
+	Action     string `json:"action"     toml:"action"      xml:"action"      yaml:"action"`
+	DeleteData bool   `json:"deleteData" toml:"delete_data" xml:"delete_data" yaml:"deleteData"`
+	// KeepLinked skips torrents with any hard-linked file; those were imported into a library.
+	KeepLinked bool `json:"keepLinked" toml:"keep_linked" xml:"keep_linked" yaml:"keepLinked"`
+	// Disabled policies still match torrents (so later policies don't), but take no action.
+	Disabled bool `json:"disabled" toml:"disabled" xml:"disabled" yaml:"disabled"`
+}
+
+// Enabled returns true if there are policies configured.
+func (c *Config) Enabled() bool {
+	return c != nil && len(c.Policies) > 0
+}
+
+// validate normalizes the policy's action, and returns an error if it is not pause or remove.
+func (p *Policy) validate() error {
+	p.Action = strings.ToLower(strings.TrimSpace(p.Action))
+
+	switch p.Action {
+	case ActionPause, ActionRemove:
+		return nil
+	default:
+		return fmt.Errorf("%w: '%s', must be %s or %s", ErrBadAction, p.Action, ActionPause, ActionRemove)
+	}
+}
+
+// matches returns true if the torrent belongs to this policy.
+func (p *Policy) matches(app starr.App, xfer *apps.Transfer) bool {
+	return matchAny(p.Clients, func(s string) bool { return strings.EqualFold(s, string(app)) }) &&
+		matchAny(p.Categories, func(s string) bool { return strings.EqualFold(s, xfer.Category) }) &&
+		matchAny(p.Trackers, func(s string) bool {
+			return strings.Contains(strings.ToLower(xfer.Tracker), strings.ToLower(s))
+		})
+}
+
+// satisfied returns true if the torrent is complete and met the policy's minimums.
+func (p *Policy) satisfied(xfer *apps.Transfer) bool {
+	return xfer.Progress >= 100 && xfer.Ratio >= p.Ratio && //nolint:mnd // percent
+		xfer.SeedTime >= int64(p.SeedTime.Seconds())
+}
+
+// matchAny returns true if the list is empty, or match returns true for any item in the list.
+func matchAny(list []string, match func(string) bool) bool {
+	if len(list) == 0 {
+		return true
+	}
+
+	for _, item := range list {
+		if match(item) {
+			return true
+		}
+	}
+
+	return false
+}
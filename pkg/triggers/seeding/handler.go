@@ -0,0 +1,23 @@
+package seeding
+
+import "net/http"
+
+// ReportHandler is passed into the webserver as an HTTP handler.
+//
+//	@Description	Evaluates the seeding policies without taking any action, and returns the torrents
+//	@Description	that satisfied a policy and what would be done with them.
+//	@Summary		Seeding policy dry run report
+//	@Tags			Downloaders
+//	@Produce		json
+//	@Success		200	{object}	apps.APIResponse{message=Report}	"dry run report"
+//	@Failure		501	{object}	apps.APIResponse{message=string}	"no seeding policies configured"
+//	@Failure		404	{object}	string								"bad token or api key"
+//	@Router			/seeding/report [get]
+//	@Security		ApiKeyAuth
+func (a *Action) ReportHandler(req *http.Request) (int, any) {
+	if !a.cmd.seeding.Enabled() {
+		return http.StatusNotImplemented, "no seeding policies configured"
+	}
+
+	return http.StatusOK, a.cmd.evaluate(req.Context(), true)
+}
@@ -0,0 +1,269 @@
+// Package seeding enforces seeding ratio and seed time policies on torrent clients.
+// Torrents that satisfied their policy are paused or removed, so disks don't fill up from over-seeding.
+package seeding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"golift.io/cnfg"
+	"golift.io/starr"
+)
+
+const TrigSeedingPolicy common.TriggerName = "Enforcing torrent seeding policies."
+
+const defaultEvery = time.Hour
+
+// Reasons a satisfied torrent was kept.
+const (
+	keepLinked   = "hard-linked in library"
+	keepDisabled = "policy disabled"
+	keepPaused   = "already paused"
+)
+
+// ErrBadAction is returned for a policy with an unknown action.
+var ErrBadAction = errors.New("unknown seeding policy action")
+
+// Action contains the exported methods for this package.
+type Action struct {
+	cmd *cmd
+}
+
+type cmd struct {
+	*common.Config
+	seeding *Config
+}
+
+// Report is the result of evaluating the seeding policies.
+type Report struct {
+	// DryRun is true when no actions were taken; decisions without a kept reason show what would happen.
+	DryRun    bool        `json:"dryRun"`
+	Decisions []*Decision `json:"decisions"`
+	// Freed is the total size of the torrents removed with their data, or that would be removed in a dry run.
+	Freed int64     `json:"freed"`
+	Date  time.Time `json:"date"`
+}
+
+// Decision is a torrent that satisfied a policy, and what was (or would be) done with it.
+type Decision struct {
+	App      starr.App `json:"app"`
+	Instance int       `json:"instance"`
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	Category string    `json:"category"`
+	Tracker  string    `json:"tracker"`
+	Size     int64     `json:"size"`
+	Ratio    float64   `json:"ratio"`
+	SeedTime int64     `json:"seedTime"`
+	Policy   string    `json:"policy"`
+	Action   string    `json:"action"`
+	Kept     string    `json:"kept,omitempty"` // Reason no action was taken.
+	Error    string    `json:"error,omitempty"`
+	policy   *Policy
+}
+
+// New configures the library.
+func New(config *common.Config, seeding *Config) *Action {
+	return &Action{cmd: &cmd{Config: config, seeding: seeding}}
+}
+
+// Create initializes the library.
+func (a *Action) Create() {
+	a.cmd.create(mnd.ReqID())
+}
+
+func (c *cmd) create(reqID string) {
+	if !c.seeding.Enabled() {
+		return
+	}
+
+	for idx, policy := range c.seeding.Policies {
+		if err := policy.validate(); err != nil {
+			mnd.Log.Errorf(reqID, "Seeding policy %d '%s' disabled: %v", idx+1, policy.Name, err)
+			policy.Disabled = true
+		}
+	}
+
+	action := &common.Action{
+		Key:  "TrigSeedingPolicy",
+		Name: TrigSeedingPolicy,
+		Fn:   c.enforce,
+		C:    make(chan *common.ActionInput, 1),
+	}
+
+	if c.seeding.CronJob.Enabled() {
+		action.J = &c.seeding.CronJob
+		mnd.Log.Printf(reqID, "==> Seeding Policies Enabled, policies:%d dry_run:%v schedule: %s",
+			len(c.seeding.Policies), c.seeding.DryRun, c.seeding.CronJob.String())
+	} else {
+		if c.seeding.Every.Duration <= 0 {
+			c.seeding.Every.Duration = defaultEvery
+		}
+
+		action.D = cnfg.Duration{Duration: c.seeding.Every.Duration}
+		mnd.Log.Printf(reqID, "==> Seeding Policies Enabled, policies:%d dry_run:%v interval:%s",
+			len(c.seeding.Policies), c.seeding.DryRun, c.seeding.Every)
+	}
+
+	c.Add(action)
+}
+
+// Enforce runs the seeding policies now. Returns false if there are no policies.
+func (a *Action) Enforce(input *common.ActionInput) bool {
+	return a.cmd.Exec(input, TrigSeedingPolicy)
+}
+
+func (c *cmd) enforce(ctx context.Context, input *common.ActionInput) {
+	ctx = mnd.WithID(ctx, input.ReqID)
+	report := c.evaluate(ctx, c.seeding.DryRun)
+
+	for _, decision := range report.Decisions {
+		switch {
+		case decision.Error != "":
+			mnd.Log.Errorf(input.ReqID, "[%s requested] Seeding policy '%s': %s %d '%s': %s failed: %s",
+				input.Type, decision.Policy, decision.App, decision.Instance, decision.Name, decision.Action, decision.Error)
+		case decision.Kept != "":
+			mnd.Log.Debugf(input.ReqID, "[%s requested] Seeding policy '%s': %s %d '%s': kept, %s",
+				input.Type, decision.Policy, decision.App, decision.Instance, decision.Name, decision.Kept)
+		default:
+			mnd.Log.Printf(input.ReqID, "[%s requested] Seeding policy '%s': %s %d '%s': %s (ratio %.2f, seeded %s)",
+				input.Type, decision.Policy, decision.App, decision.Instance, decision.Name, decision.Action,
+				decision.Ratio, time.Duration(decision.SeedTime)*time.Second)
+		}
+	}
+
+	mnd.Log.Printf(input.ReqID, "[%s requested] Seeding policies evaluated; %d torrents satisfied a policy, freed %s.",
+		input.Type, len(report.Decisions), mnd.FormatBytes(report.Freed))
+}
+
+// evaluate checks every torrent against the policies, and acts on them unless dryRun is true.
+func (c *cmd) evaluate(ctx context.Context, dryRun bool) *Report {
+	report := &Report{DryRun: dryRun, Decisions: []*Decision{}, Date: time.Now()}
+
+	for _, app := range []starr.App{apps.AppDeluge, apps.AppQbit, apps.AppRtorrent, apps.AppTransmission} {
+		for idx, client := range c.Apps.Downloaders(app) {
+			if !client.Enabled() {
+				continue
+			}
+
+			xfers, err := client.Transfers(ctx)
+			if err != nil {
+				mnd.Log.Errorf(mnd.GetID(ctx), "Getting %s %d transfers for seeding policies: %v", app, idx+1, err)
+				continue
+			}
+
+			for _, xfer := range xfers {
+				if decision := c.decide(app, xfer); decision != nil {
+					decision.Instance = idx + 1
+					report.Decisions = append(report.Decisions, decision)
+					report.Freed += apply(ctx, client, decision, dryRun)
+				}
+			}
+		}
+	}
+
+	return report
+}
+
+// decide returns a decision if the torrent satisfied the first policy it matches.
+func (c *cmd) decide(app starr.App, xfer *apps.Transfer) *Decision {
+	for idx, policy := range c.seeding.Policies {
+		if !policy.matches(app, xfer) {
+			continue
+		}
+
+		if !policy.satisfied(xfer) {
+			return nil
+		}
+
+		decision := &Decision{
+			App:      app,
+			ID:       xfer.ID,
+			Name:     xfer.Name,
+			Category: xfer.Category,
+			Tracker:  xfer.Tracker,
+			Size:     xfer.Size,
+			Ratio:    xfer.Ratio,
+			SeedTime: xfer.SeedTime,
+			Policy:   policy.Name,
+			Action:   policy.Action,
+			policy:   policy,
+		}
+
+		if decision.Policy == "" {
+			decision.Policy = fmt.Sprint("policy ", idx+1)
+		}
+
+		switch {
+		case policy.Disabled:
+			decision.Kept = keepDisabled
+		case policy.Action == ActionPause && xfer.Paused:
+			decision.Kept = keepPaused
+		case policy.KeepLinked:
+			if linked, err := mnd.HardLinked(contentPath(xfer)); err != nil {
+				decision.Kept = keepLinked
+				decision.Error = fmt.Sprintf("checking hard links: %v", err)
+			} else if linked {
+				decision.Kept = keepLinked
+			}
+		}
+
+		return decision
+	}
+
+	return nil
+}
+
+// apply takes the action on a torrent. Returns the size of the data deleted (or that would be), if any.
+func apply(ctx context.Context, client apps.Downloader, decision *Decision, dryRun bool) int64 {
+	deleteData := decision.policy.DeleteData
+
+	var err error
+
+	switch {
+	case decision.Kept != "":
+		return 0
+	case dryRun && decision.Action == ActionRemove && deleteData:
+		return decision.Size
+	case dryRun:
+		return 0
+	}
+
+	switch decision.Action {
+	case ActionPause:
+		err = client.PauseTransfers(ctx, []string{decision.ID})
+	case ActionRemove:
+		err = client.RemoveTransfers(ctx, []string{decision.ID}, deleteData)
+	default:
+		err = fmt.Errorf("%w: %s", ErrBadAction, decision.Action)
+	}
+
+	if err != nil {
+		decision.Error = err.Error()
+		return 0
+	}
+
+	if decision.Action == ActionRemove && deleteData {
+		return decision.Size
+	}
+
+	return 0
+}
+
+// contentPath returns the path to a torrent's file or folder.
+// Most clients provide the save folder, but rTorrent provides the content path.
+func contentPath(xfer *apps.Transfer) string {
+	content := filepath.Join(xfer.Path, xfer.Name)
+	if _, err := os.Stat(content); err != nil && filepath.Base(xfer.Path) == xfer.Name {
+		return xfer.Path
+	}
+
+	return content
+}
@@ -0,0 +1,119 @@
+package seeding //nolint:testpackage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golift.io/cnfg"
+)
+
+func TestPolicyMatches(t *testing.T) {
+	t.Parallel()
+
+	xfer := &apps.Transfer{Category: "Radarr", Tracker: "https://Tracker.Example.org/announce"}
+	tests := []struct {
+		name   string
+		policy *Policy
+		want   bool
+	}{
+		{name: "empty matches all", policy: &Policy{}, want: true},
+		{name: "client", policy: &Policy{Clients: []string{"deluge", "qbit"}}, want: true},
+		{name: "wrong client", policy: &Policy{Clients: []string{"deluge"}}, want: false},
+		{name: "category", policy: &Policy{Categories: []string{"radarr"}}, want: true},
+		{name: "wrong category", policy: &Policy{Categories: []string{"sonarr"}}, want: false},
+		{name: "tracker part", policy: &Policy{Trackers: []string{"tracker.example"}}, want: true},
+		{name: "wrong tracker", policy: &Policy{Trackers: []string{"other.example"}}, want: false},
+		{
+			name:   "all must match",
+			policy: &Policy{Clients: []string{"qbit"}, Categories: []string{"radarr"}, Trackers: []string{"other"}},
+			want:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.want, test.policy.matches(apps.AppQbit, xfer))
+		})
+	}
+}
+
+func TestPolicyValidate(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	policy := &Policy{Action: " Remove "}
+	require.NoError(t, policy.validate())
+	assert.Equal(ActionRemove, policy.Action, "the action must be normalized")
+	assert.ErrorIs((&Policy{Action: "delete"}).validate(), ErrBadAction)
+	assert.ErrorIs((&Policy{}).validate(), ErrBadAction)
+}
+
+func TestDecide(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "linked.mkv"), []byte("data"), 0o600))
+	require.NoError(t, os.Link(filepath.Join(dir, "linked.mkv"), filepath.Join(dir, "library.mkv")))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "single.mkv"), []byte("data"), 0o600))
+
+	day := cnfg.Duration{Duration: 24 * time.Hour}
+	action := &cmd{seeding: &Config{Policies: []*Policy{
+		{Name: "tv", Categories: []string{"sonarr"}, Ratio: 2, Action: ActionPause},
+		{Name: "movies", Categories: []string{"radarr"}, SeedTime: day, Action: ActionRemove, KeepLinked: true},
+		{Categories: []string{"off"}, Action: ActionRemove, Disabled: true},
+	}}}
+
+	tests := []struct {
+		name   string
+		xfer   *apps.Transfer
+		policy string // empty means no decision.
+		kept   string
+	}{
+		{name: "no policy", xfer: &apps.Transfer{Category: "other", Progress: 100}},
+		{name: "incomplete", xfer: &apps.Transfer{Category: "sonarr", Progress: 50, Ratio: 3}},
+		{name: "low ratio", xfer: &apps.Transfer{Category: "sonarr", Progress: 100, Ratio: 1}},
+		{name: "ratio met", xfer: &apps.Transfer{Category: "sonarr", Progress: 100, Ratio: 2}, policy: "tv"},
+		{
+			name:   "already paused",
+			xfer:   &apps.Transfer{Category: "sonarr", Progress: 100, Ratio: 2, Paused: true},
+			policy: "tv",
+			kept:   keepPaused,
+		},
+		{name: "short seed time", xfer: &apps.Transfer{Category: "radarr", Progress: 100, SeedTime: 3600}},
+		{
+			name:   "hard linked",
+			xfer:   &apps.Transfer{Category: "radarr", Progress: 100, SeedTime: 86400, Path: dir, Name: "linked.mkv"},
+			policy: "movies",
+			kept:   keepLinked,
+		},
+		{
+			name:   "not linked",
+			xfer:   &apps.Transfer{Category: "radarr", Progress: 100, SeedTime: 86400, Path: dir, Name: "single.mkv"},
+			policy: "movies",
+		},
+		{name: "disabled", xfer: &apps.Transfer{Category: "off", Progress: 100}, policy: "policy 3", kept: keepDisabled},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			decision := action.decide(apps.AppQbit, test.xfer)
+			if test.policy == "" {
+				assert.Nil(t, decision)
+				return
+			}
+
+			require.NotNil(t, decision)
+			assert.Equal(t, test.policy, decision.Policy)
+			assert.Equal(t, test.kept, decision.Kept)
+			assert.Empty(t, decision.Error)
+		})
+	}
+}
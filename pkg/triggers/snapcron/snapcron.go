@@ -42,21 +42,22 @@ func (a *Action) Send(input *common.ActionInput) {
 }
 
 func (c *cmd) create(reqID string) {
-	var dur time.Duration
+	action := &common.Action{
+		Key:  "TrigSnapshot",
+		Name: TrigSnapshot,
+		Fn:   c.sendSnapshot,
+		C:    make(chan *common.ActionInput, 1),
+	}
 
-	if c.Snapshot.Interval.Duration > 0 {
+	if c.Snapshot.Cron != "" {
+		action.J = c.Snapshot.CronExpr.Job()
+	} else if c.Snapshot.Interval.Duration > 0 {
 		randomTime := time.Duration(c.Config.Rand().Intn(randomMilliseconds)) * time.Millisecond
-		dur = c.Snapshot.Interval.Duration + randomTime
+		action.D = cnfg.Duration{Duration: c.Snapshot.Interval.Duration + randomTime}
 	}
 
 	c.printLog(reqID)
-	c.Add(&common.Action{
-		Key:  "TrigSnapshot",
-		Name: TrigSnapshot,
-		Fn:   c.sendSnapshot,
-		C:    make(chan *common.ActionInput, 1),
-		D:    cnfg.Duration{Duration: dur},
-	})
+	c.Add(action)
 }
 
 func (c *cmd) printLog(reqID string) {
@@ -87,6 +88,12 @@ func (c *cmd) printLog(reqID string) {
 		enabled += key
 	}
 
+	if c.Snapshot.Cron != "" {
+		mnd.Log.Printf(reqID, "==> System Snapshot Collection Started, schedule: %s, timeout: %v, enabled: %s",
+			c.Snapshot.CronExpr.Job().String(), c.Snapshot.Timeout, enabled)
+		return
+	}
+
 	if c.Snapshot.Interval.Duration == 0 {
 		mnd.Log.Printf(reqID, "==> System Snapshot Collection Disabled, timeout: %v, configured: %s",
 			c.Snapshot.Timeout, enabled)
@@ -0,0 +1,314 @@
+package starrqueue
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"golift.io/cnfg"
+	"golift.io/starr"
+)
+
+/* This file follows downloads from "finished in the download client" to "imported by the starr app". */
+
+const TrigImportTracker common.TriggerName = "Tracking completed downloads until they are imported."
+
+// Import tracker events.
+const (
+	EventDownloadCompleted = "downloadCompleted"
+	EventImportSucceeded   = "importSucceeded"
+	EventImportFailed      = "importFailed"
+	EventImportOverdue     = "importOverdue"
+)
+
+const (
+	defaultImportInterval = 2 * time.Minute
+	minimumImportInterval = 30 * time.Second
+	defaultImportOverdue  = time.Hour
+	historyPageSize       = "20"
+)
+
+// ImportConfig enables the import tracker. Overdue is how long after a download completes
+// before an import is taking too long. Apps limits tracking to these starr apps; empty means all.
+type ImportConfig struct {
+	Interval cnfg.Duration `json:"interval" toml:"interval" xml:"interval" yaml:"interval"`
+	Overdue  cnfg.Duration `json:"overdue"  toml:"overdue"  xml:"overdue"  yaml:"overdue"`
+	Apps     []string      `json:"apps"     toml:"apps"     xml:"apps"     yaml:"apps"`
+}
+
+// ImportEvent is a change in a tracked download. These are logged.
+type ImportEvent struct {
+	App        starr.App `json:"app"`
+	Instance   int       `json:"instance"`
+	Event      string    `json:"event"`
+	DownloadID string    `json:"downloadId"`
+	Title      string    `json:"title"`
+	Client     string    `json:"downloadClient"`
+	Completed  time.Time `json:"completed"`
+	// Delta is the time between the download completing and this event, in seconds.
+	Delta    int64  `json:"delta"`
+	Duration string `json:"duration"`
+	Message  string `json:"message,omitempty"`
+}
+
+// imports tracks downloads across queue polls.
+type imports struct {
+	config    *ImportConfig
+	downloads map[string]*trackedDownload
+}
+
+// trackedDownload is a download in a starr app queue, and what was already reported about it.
+type trackedDownload struct {
+	instance   apps.StarrInstance
+	downloadID string
+	title      string
+	client     string
+	completed  time.Time
+	imported   bool // import success was reported.
+	failed     bool // import failure was reported.
+	overdue    bool
+}
+
+// historyRecord is the part of a starr history record the import tracker needs.
+type historyRecord struct {
+	EventType string    `json:"eventType"`
+	Date      time.Time `json:"date"`
+}
+
+// Enabled returns true if the import tracker is configured.
+func (i *ImportConfig) Enabled() bool {
+	return i != nil
+}
+
+func (c *cmd) setupImports(reqID string) {
+	if !c.imports.config.Enabled() {
+		return
+	}
+
+	cfg := c.imports.config
+	if cfg.Interval.Duration == 0 {
+		cfg.Interval.Duration = defaultImportInterval
+	} else if cfg.Interval.Duration < minimumImportInterval {
+		cfg.Interval.Duration = minimumImportInterval
+	}
+
+	if cfg.Overdue.Duration <= 0 {
+		cfg.Overdue.Duration = defaultImportOverdue
+	}
+
+	mnd.Log.Printf(reqID, "==> Import Tracker Enabled, interval:%s overdue:%s apps:%q",
+		cfg.Interval, cfg.Overdue, cfg.Apps)
+
+	c.Add(&common.Action{
+		Key:  "TrigImportTracker",
+		Name: TrigImportTracker,
+		Fn:   c.trackImports,
+		C:    make(chan *common.ActionInput, 1),
+		D:    cfg.Interval,
+	})
+}
+
+// TrackImports polls the starr app queues for the import tracker now.
+// Returns false if the import tracker is not enabled.
+func (a *Action) TrackImports(input *common.ActionInput) bool {
+	return a.cmd.Exec(input, TrigImportTracker)
+}
+
+// trackImports polls every queue, compares it to the last poll, and sends any new events.
+func (c *cmd) trackImports(ctx context.Context, input *common.ActionInput) {
+	var (
+		now       = time.Now()
+		events    = []*ImportEvent{}
+		downloads = make(map[string]*trackedDownload)
+	)
+
+	ctx = mnd.WithID(ctx, input.ReqID)
+
+	for _, instance := range c.Apps.StarrInstances(starr.Lidarr, starr.Radarr, starr.Readarr, starr.Sonarr) {
+		if !instance.Enabled() || !c.imports.tracks(instance.App()) {
+			continue
+		}
+
+		records, err := getQueueRecords(ctx, instance)
+		if err != nil {
+			mnd.Log.Errorf(input.ReqID, "[%s requested] Getting %s %d queue for import tracker: %v",
+				input.Type, instance.App(), instance.Instance(), err)
+			// Keep tracking this instance's downloads until its queue can be fetched again.
+			for key, download := range c.imports.downloads {
+				if download.instance.App() == instance.App() && download.instance.Index() == instance.Index() {
+					downloads[key] = download
+				}
+			}
+
+			continue
+		}
+
+		for _, record := range records {
+			key := fmt.Sprintf("%s/%d/%s", instance.App(), instance.Index(), strings.ToLower(record.DownloadID))
+			if record.DownloadID == "" || downloads[key] != nil {
+				continue // Delayed items, or another record for the same download (season packs).
+			}
+
+			download := c.imports.downloads[key]
+			if download == nil {
+				download = &trackedDownload{instance: instance, downloadID: record.DownloadID}
+			}
+
+			download.title, download.client = record.Title, record.DownloadClient
+			downloads[key] = download
+			events = append(events, download.update(record, now, c.imports.config.Overdue.Duration)...)
+		}
+	}
+
+	// Downloads that left a queue were imported, failed, or removed. History knows which.
+	for key, download := range c.imports.downloads {
+		if downloads[key] != nil || download.completed.IsZero() || download.imported {
+			continue
+		}
+
+		if event := download.finish(ctx, now); event.Event == EventImportSucceeded || !download.failed {
+			events = append(events, event)
+		}
+	}
+
+	c.imports.downloads = downloads
+
+	if len(events) == 0 {
+		return
+	}
+
+	for _, event := range events {
+		mnd.Log.Printf(input.ReqID, "[%s requested] Import tracker: %s", input.Type, event)
+	}
+}
+
+// String describes the download and what happened to it.
+func (e *ImportEvent) String() string {
+	msg := fmt.Sprintf("%s %d: %s: %s after %s", e.App, e.Instance, e.Title, e.Event, e.Duration)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+
+	return msg
+}
+
+// tracks returns true if the starr app is included in the import tracker config.
+func (i *imports) tracks(app starr.App) bool {
+	return len(i.config.Apps) == 0 || containsFold(i.config.Apps, string(app))
+}
+
+// update compares a queue record to the download's last state, and returns any new events.
+func (t *trackedDownload) update(record *queueRecord, now time.Time, overdue time.Duration) []*ImportEvent {
+	state := strings.ToLower(record.TrackedDownloadState)
+	status := strings.ToLower(record.Status)
+	events := []*ImportEvent{}
+
+	switch {
+	case state == "failedpending" || state == "failed" || status == failed:
+		// The download failed in the client; it never completed.
+	case t.completed.IsZero() && (status == completed || strings.HasPrefix(state, "import")):
+		t.completed = now
+		events = append(events, t.event(EventDownloadCompleted, now, ""))
+	}
+
+	switch {
+	case t.completed.IsZero() || t.imported:
+	case state == "imported":
+		t.imported = true
+		events = append(events, t.event(EventImportSucceeded, now, ""))
+	case !t.failed && (state == "importblocked" || state == "importfailed" || status == warning):
+		t.failed = true
+		events = append(events, t.event(EventImportFailed, now, queueMessage(record)))
+	case !t.overdue && now.Sub(t.completed) >= overdue:
+		t.overdue = true
+		events = append(events, t.event(EventImportOverdue, now, queueMessage(record)))
+	}
+
+	return events
+}
+
+// finish checks history for a completed download that left the queue, and returns the outcome.
+// An import may still succeed after a failure was reported, if someone fixed it by hand.
+func (t *trackedDownload) finish(ctx context.Context, now time.Time) *ImportEvent {
+	history, err := getDownloadHistory(ctx, t.instance, t.downloadID)
+	if err != nil {
+		mnd.Log.Errorf(mnd.GetID(ctx), "Getting %s %d history for '%s': %v",
+			t.instance.App(), t.instance.Instance(), t.title, err)
+		return t.event(EventImportFailed, now, "left the queue; checking history failed: "+err.Error())
+	}
+
+	for _, record := range history {
+		switch eventType := strings.ToLower(record.EventType); {
+		case strings.Contains(eventType, "imported"):
+			if record.Date.IsZero() || record.Date.Before(t.completed) {
+				record.Date = now
+			}
+
+			return t.event(EventImportSucceeded, record.Date, "")
+		case strings.Contains(eventType, "failed"):
+			return t.event(EventImportFailed, now, "history: "+record.EventType)
+		}
+	}
+
+	return t.event(EventImportFailed, now, "removed from the queue without an import")
+}
+
+// event returns an import event for this download.
+func (t *trackedDownload) event(name string, when time.Time, message string) *ImportEvent {
+	delta := when.Sub(t.completed).Round(time.Second)
+
+	return &ImportEvent{
+		App:        t.instance.App(),
+		Instance:   t.instance.Instance(),
+		Event:      name,
+		DownloadID: t.downloadID,
+		Title:      t.title,
+		Client:     t.client,
+		Completed:  t.completed,
+		Delta:      int64(delta.Seconds()),
+		Duration:   delta.String(),
+		Message:    message,
+	}
+}
+
+// queueMessage returns the first error or status message on a queue record.
+func queueMessage(record *queueRecord) string {
+	if record.ErrorMessage != "" {
+		return record.ErrorMessage
+	}
+
+	for _, status := range record.StatusMessages {
+		if len(status.Messages) > 0 {
+			return status.Messages[0]
+		}
+	}
+
+	return ""
+}
+
+// getDownloadHistory returns the newest history records for a download.
+func getDownloadHistory(ctx context.Context, instance apps.StarrInstance, downloadID string) ([]*historyRecord, error) {
+	var history struct {
+		Records []*historyRecord `json:"records"`
+	}
+
+	err := instance.StarrClient().GetInto(ctx, starr.Request{
+		URI: instance.Starr().APIPath("history"),
+		Query: url.Values{
+			"downloadId":    {downloadID},
+			"pageSize":      {historyPageSize},
+			"sortKey":       {"date"},
+			"sortDirection": {"descending"},
+		},
+	}, &history)
+	if err != nil {
+		return nil, fmt.Errorf("getting history: %w", err)
+	}
+
+	return history.Records, nil
+}
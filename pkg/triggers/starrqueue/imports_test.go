@@ -0,0 +1,160 @@
+package starrqueue //nolint:testpackage
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golift.io/starr"
+	"golift.io/starr/radarr"
+)
+
+// testInstance is a Radarr instance with any client.
+type testInstance struct{ client apps.StarrClient }
+
+func (testInstance) App() starr.App                  { return starr.Radarr }
+func (testInstance) Index() int                      { return 0 }
+func (testInstance) Instance() int                   { return 1 }
+func (testInstance) Starr() apps.StarrApp            { return apps.StarrApp{} }
+func (testInstance) Enabled() bool                   { return true }
+func (t testInstance) StarrClient() apps.StarrClient { return t.client }
+
+func TestTrackedDownloadUpdate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		start    = time.Now()
+		overdue  = time.Hour
+		download = &trackedDownload{instance: testInstance{}, downloadID: "abc", title: "Movie"}
+	)
+
+	// Each step polls the queue once, in order, against the same download.
+	steps := []struct {
+		name   string
+		record *queueRecord
+		after  time.Duration
+		events []string
+	}{
+		{name: "downloading", record: &queueRecord{Status: "downloading", TrackedDownloadState: "downloading"}},
+		{
+			name:   "completed",
+			record: &queueRecord{Status: completed, TrackedDownloadState: "importPending"},
+			events: []string{EventDownloadCompleted},
+		},
+		{name: "pending", record: &queueRecord{Status: completed, TrackedDownloadState: "importPending"}, after: time.Minute},
+		{
+			name:   "overdue",
+			record: &queueRecord{Status: completed, TrackedDownloadState: "importPending", ErrorMessage: "waiting"},
+			after:  overdue,
+			events: []string{EventImportOverdue},
+		},
+		{
+			name:   "overdue once",
+			record: &queueRecord{Status: completed, TrackedDownloadState: "importPending"},
+			after:  2 * overdue,
+		},
+		{
+			name:   "blocked",
+			record: &queueRecord{Status: completed, TrackedDownloadState: "importBlocked"},
+			after:  2 * overdue,
+			events: []string{EventImportFailed},
+		},
+		{name: "failed once", record: &queueRecord{Status: warning, TrackedDownloadState: "importFailed"}, after: 2 * overdue},
+		{
+			name:   "fixed by hand",
+			record: &queueRecord{Status: completed, TrackedDownloadState: "imported"},
+			after:  3 * overdue,
+			events: []string{EventImportSucceeded},
+		},
+		{name: "imported once", record: &queueRecord{Status: completed, TrackedDownloadState: "imported"}, after: 3 * overdue},
+	}
+
+	for _, step := range steps {
+		events := download.update(step.record, start.Add(step.after), overdue)
+		names := []string{}
+
+		for _, event := range events {
+			names = append(names, event.Event)
+		}
+
+		assert.ElementsMatch(t, step.events, names, step.name)
+	}
+
+	assert.Equal(t, start, download.completed, "the completed time must not change")
+
+	broken := &trackedDownload{instance: testInstance{}}
+	assert.Empty(t, broken.update(&queueRecord{Status: failed, TrackedDownloadState: "failedPending"}, start, overdue),
+		"a download that failed in the client never completed")
+	assert.True(t, broken.completed.IsZero())
+}
+
+func TestTrackedDownloadFinish(t *testing.T) {
+	t.Parallel()
+
+	completedAt := time.Now().Add(-time.Hour).Round(time.Second)
+	importedAt := completedAt.Add(10 * time.Minute)
+
+	tests := []struct {
+		name    string
+		history []*historyRecord
+		event   string
+		delta   int64
+		message string
+	}{
+		{
+			name:    "imported",
+			history: []*historyRecord{{EventType: "downloadFolderImported", Date: importedAt}},
+			event:   EventImportSucceeded,
+			delta:   600,
+		},
+		{
+			name:    "failed",
+			history: []*historyRecord{{EventType: "downloadFailed", Date: importedAt}},
+			event:   EventImportFailed,
+			message: "history: downloadFailed",
+		},
+		{
+			name: "newest record wins",
+			history: []*historyRecord{
+				{EventType: "grabbed", Date: importedAt},
+				{EventType: "movieFileImported", Date: importedAt},
+				{EventType: "downloadFailed", Date: completedAt},
+			},
+			event: EventImportSucceeded,
+			delta: 600,
+		},
+		{name: "removed", event: EventImportFailed, message: "removed from the queue without an import"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "abc", r.URL.Query().Get("downloadId"))
+				_ = json.NewEncoder(w).Encode(map[string]any{"records": test.history})
+			}))
+			defer server.Close()
+
+			download := &trackedDownload{
+				instance:   testInstance{client: radarr.New(starr.New("key", server.URL, 0))},
+				downloadID: "abc",
+				completed:  completedAt,
+			}
+
+			event := download.finish(t.Context(), time.Now())
+			require.NotNil(t, event)
+			assert.Equal(t, test.event, event.Event)
+			assert.Equal(t, test.message, event.Message)
+
+			if test.delta != 0 {
+				assert.Equal(t, test.delta, event.Delta)
+			}
+		})
+	}
+}
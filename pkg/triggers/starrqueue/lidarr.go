@@ -2,15 +2,12 @@ package starrqueue
 
 import (
 	"context"
-	"time"
 
 	"github.com/Notifiarr/notifiarr/pkg/apps"
 	"github.com/Notifiarr/notifiarr/pkg/logs"
 	"github.com/Notifiarr/notifiarr/pkg/mnd"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/data"
-	"github.com/Notifiarr/notifiarr/pkg/website/clientinfo"
-	"golift.io/cnfg"
 )
 
 const TrigLidarrQueue common.TriggerName = "Storing Lidarr instance %d queue."
@@ -42,41 +39,3 @@ func (app *lidarrApp) storeQueue(ctx context.Context, input *common.ActionInput)
 		input.Type, len(queue.Records), app.idx+1, app.app.Name)
 	data.SaveWithID("lidarr", app.idx, queue)
 }
-
-func (c *cmd) setupLidarr(reqID string) bool {
-	logs.Log.Trace(reqID, "start: setupLidarr")
-	defer logs.Log.Trace(reqID, "end: setupLidarr")
-
-	var enabled bool
-
-	for idx, app := range c.Apps.Lidarr {
-		info := clientinfo.Get()
-		if !app.Enabled() || info == nil {
-			continue
-		}
-
-		var dur time.Duration
-
-		instance := idx + 1
-		if info.Actions.Apps.Lidarr.Finished(instance) {
-			dur = finishedDuration
-		} else if info.Actions.Apps.Lidarr.Stuck(instance) {
-			dur = stuckDuration
-		}
-
-		if dur != 0 {
-			enabled = true
-
-			c.Add(&common.Action{
-				Key:  "TrigLidarrQueue",
-				Hide: true,
-				Name: TrigLidarrQueue.WithInstance(instance),
-				Fn:   (&lidarrApp{app: &app, cmd: c, idx: idx}).storeQueue,
-				C:    make(chan *common.ActionInput, 1),
-				D:    cnfg.Duration{Duration: dur},
-			})
-		}
-	}
-
-	return enabled
-}
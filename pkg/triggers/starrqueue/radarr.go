@@ -2,15 +2,12 @@ package starrqueue
 
 import (
 	"context"
-	"time"
 
 	"github.com/Notifiarr/notifiarr/pkg/apps"
 	"github.com/Notifiarr/notifiarr/pkg/logs"
 	"github.com/Notifiarr/notifiarr/pkg/mnd"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/data"
-	"github.com/Notifiarr/notifiarr/pkg/website/clientinfo"
-	"golift.io/cnfg"
 )
 
 const TrigRadarrQueue common.TriggerName = "Storing Radarr instance %d queue."
@@ -42,41 +39,3 @@ func (app *radarrApp) storeQueue(ctx context.Context, input *common.ActionInput)
 		input.Type, len(queue.Records), app.idx+1, app.app.Name)
 	data.SaveWithID("radarr", app.idx, queue)
 }
-
-func (c *cmd) setupRadarr(reqID string) bool {
-	logs.Log.Trace(reqID, "start: setupRadarr")
-	defer logs.Log.Trace(reqID, "end: setupRadarr")
-
-	var enabled bool
-
-	for idx, app := range c.Apps.Radarr {
-		info := clientinfo.Get()
-		if !app.Enabled() || info == nil {
-			continue
-		}
-
-		var dur time.Duration
-
-		instance := idx + 1
-		if info.Actions.Apps.Radarr.Finished(instance) {
-			dur = finishedDuration
-		} else if info.Actions.Apps.Radarr.Stuck(instance) {
-			dur = stuckDuration
-		}
-
-		if dur != 0 {
-			enabled = true
-
-			c.Add(&common.Action{
-				Key:  "TrigRadarrQueue",
-				Hide: true,
-				Name: TrigRadarrQueue.WithInstance(instance),
-				Fn:   (&radarrApp{app: &app, cmd: c, idx: idx}).storeQueue,
-				C:    make(chan *common.ActionInput, 1),
-				D:    cnfg.Duration{Duration: dur},
-			})
-		}
-	}
-
-	return enabled
-}
@@ -2,15 +2,12 @@ package starrqueue
 
 import (
 	"context"
-	"time"
 
 	"github.com/Notifiarr/notifiarr/pkg/apps"
 	"github.com/Notifiarr/notifiarr/pkg/logs"
 	"github.com/Notifiarr/notifiarr/pkg/mnd"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/data"
-	"github.com/Notifiarr/notifiarr/pkg/website/clientinfo"
-	"golift.io/cnfg"
 )
 
 const TrigReadarrQueue common.TriggerName = "Storing Readarr instance %d queue."
@@ -40,43 +37,3 @@ func (app *readarrApp) storeQueue(ctx context.Context, input *common.ActionInput
 		input.Type, len(queue.Records), app.idx+1, app.app.Name)
 	data.SaveWithID("readarr", app.idx, queue)
 }
-
-func (c *cmd) setupReadarr(reqID string) bool {
-	logs.Log.Trace(reqID, "start: setupReadarr")
-	defer logs.Log.Trace(reqID, "end: setupReadarr")
-
-	var enable bool
-
-	for idx, app := range c.Apps.Readarr {
-		info := clientinfo.Get()
-		if !app.Enabled() || info == nil {
-			continue
-		}
-
-		var dur time.Duration
-
-		instance := idx + 1
-
-		switch {
-		case info.Actions.Apps.Readarr.Finished(instance):
-			enable = true
-			dur = finishedDuration
-		case info.Actions.Apps.Readarr.Stuck(instance):
-			enable = true
-			dur = stuckDuration
-		default:
-			continue
-		}
-
-		c.Add(&common.Action{
-			Key:  "TrigReadarrQueue",
-			Hide: true,
-			Name: TrigReadarrQueue.WithInstance(instance),
-			Fn:   (&readarrApp{app: &app, cmd: c, idx: idx}).storeQueue,
-			C:    make(chan *common.ActionInput, 1),
-			D:    cnfg.Duration{Duration: dur},
-		})
-	}
-
-	return enable
-}
@@ -1,12 +1,15 @@
 package starrqueue
 
 import (
+	"context"
 	"time"
 
+	"github.com/Notifiarr/notifiarr/pkg/apps"
 	"github.com/Notifiarr/notifiarr/pkg/logs"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
 	"github.com/Notifiarr/notifiarr/pkg/website/clientinfo"
 	"golift.io/cnfg"
+	"golift.io/starr"
 )
 
 /* This file contains the procedures to send stuck download queue items to notifiarr. */
@@ -19,7 +22,10 @@ type Action struct {
 type cmd struct {
 	*common.Config
 	// We set empty to true after we send 1 "empty downloads" payload.
-	empty bool
+	empty   bool
+	stalled *stalled
+	stuck   *stuckRules
+	imports *imports
 }
 
 const (
@@ -54,11 +60,21 @@ type QueuesPaylod struct {
 }
 
 // New configures the library.
-func New(config *common.Config) *Action {
+func New(
+	config *common.Config,
+	stalledConfig *StalledConfig,
+	stuckConfig *StuckConfig,
+	importConfig *ImportConfig,
+) *Action {
 	reqID := logs.Log.Trace("", "start: common.New")
 	defer logs.Log.Trace(reqID, "end: common.New")
 
-	return &Action{cmd: &cmd{Config: config}}
+	return &Action{cmd: &cmd{
+		Config:  config,
+		stalled: &stalled{config: stalledConfig},
+		stuck:   &stuckRules{config: stuckConfig},
+		imports: &imports{config: importConfig},
+	}}
 }
 
 // Create initializes the library.
@@ -66,12 +82,11 @@ func (a *Action) Create() {
 	reqID := logs.Log.Trace("", "start: Action.Create")
 	defer logs.Log.Trace(reqID, "end: Action.Create")
 
-	lidarr := a.cmd.setupLidarr(reqID)
-	radarr := a.cmd.setupRadarr(reqID)
-	readarr := a.cmd.setupReadarr(reqID)
-	sonarr := a.cmd.setupSonarr(reqID)
+	a.cmd.setupStalled(reqID)
+	a.cmd.setupStuckRules(reqID)
+	a.cmd.setupImports(reqID)
 
-	if lidarr || radarr || readarr || sonarr {
+	if a.cmd.setupQueues(reqID) {
 		a.cmd.Add(&common.Action{
 			Key:  "TrigStuckItems",
 			Name: TrigStuckItems,
@@ -94,6 +109,67 @@ func (a *Action) Create() {
 	}
 }
 
+// setupQueues adds a queue-storing timer for every starr instance with stuck or finished items enabled.
+// Returns true if any timers were added.
+func (c *cmd) setupQueues(reqID string) bool {
+	logs.Log.Trace(reqID, "start: setupQueues")
+	defer logs.Log.Trace(reqID, "end: setupQueues")
+
+	var enabled bool
+
+	for _, instance := range c.Apps.StarrInstances(starr.Lidarr, starr.Radarr, starr.Readarr, starr.Sonarr) {
+		info := clientinfo.Get()
+		if !instance.Enabled() || info == nil {
+			continue
+		}
+
+		var dur time.Duration
+
+		switch site := info.Actions.Apps.Get(instance.App()); {
+		case site.Finished(instance.Instance()):
+			dur = finishedDuration
+		case site.Stuck(instance.Instance()):
+			dur = stuckDuration
+		default:
+			continue
+		}
+
+		name, storeQueue := c.queueStorer(instance)
+		if storeQueue == nil {
+			continue
+		}
+
+		enabled = true
+
+		c.Add(&common.Action{
+			Key:  "Trig" + instance.App().String() + "Queue",
+			Hide: true,
+			Name: name.WithInstance(instance.Instance()),
+			Fn:   storeQueue,
+			C:    make(chan *common.ActionInput, 1),
+			D:    cnfg.Duration{Duration: dur},
+		})
+	}
+
+	return enabled
+}
+
+// queueStorer returns the trigger name and the procedure that stores the queue for a starr instance.
+func (c *cmd) queueStorer(instance apps.StarrInstance) (common.TriggerName, func(context.Context, *common.ActionInput)) {
+	switch app := instance.(type) {
+	case apps.Lidarr:
+		return TrigLidarrQueue, (&lidarrApp{app: &app, cmd: c, idx: app.Index()}).storeQueue
+	case apps.Radarr:
+		return TrigRadarrQueue, (&radarrApp{app: &app, cmd: c, idx: app.Index()}).storeQueue
+	case apps.Readarr:
+		return TrigReadarrQueue, (&readarrApp{app: &app, cmd: c, idx: app.Index()}).storeQueue
+	case apps.Sonarr:
+		return TrigSonarrQueue, (&sonarrApp{app: &app, cmd: c, idx: app.Index()}).storeQueue
+	default:
+		return "", nil
+	}
+}
+
 // listItem is data formatted for sending a json payload to the website.
 type listItem struct {
 	Name  string `json:"name"`
@@ -0,0 +1,391 @@
+package starrqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"golift.io/cnfg"
+	"golift.io/starr"
+)
+
+/* This file inspects torrents in the download clients directly and deals with the stalled ones. */
+
+const TrigStalledTorrents common.TriggerName = "Checking download clients for stalled torrents."
+
+// Actions that may be taken on a stalled torrent.
+const (
+	StalledNotify    = "notify"    // Log it only.
+	StalledRemove    = "remove"    // Remove the torrent (and data for stalled torrents) from the client.
+	StalledBlocklist = "blocklist" // Remove and blocklist the release in the owning starr app.
+	StalledResearch  = "research"  // Remove and blocklist the release, and search for a replacement.
+)
+
+const (
+	defaultStalledInterval = 15 * time.Minute
+	minimumStalledInterval = time.Minute
+)
+
+// Errors returned while acting on stalled torrents.
+var (
+	ErrNoStarrOwner  = errors.New("no starr app queue contains this download")
+	ErrDeleteLimited = errors.New("starr app delete limit reached, or deletes not enabled")
+	ErrBadAction     = errors.New("unknown stalled torrent action")
+)
+
+// StalledConfig enables the stalled torrent detector. Every duration is how long a torrent must be in
+// that condition before it's considered stalled. A zero value disables that check.
+type StalledConfig struct {
+	Interval cnfg.Duration `json:"interval" toml:"interval"  xml:"interval"  yaml:"interval"`
+	NoSeeds  cnfg.Duration `json:"noSeeds"  toml:"no_seeds"  xml:"no_seeds"  yaml:"noSeeds"`
+	Metadata cnfg.Duration `json:"metadata" toml:"metadata"  xml:"metadata"  yaml:"metadata"`
+	NoETA    cnfg.Duration `json:"noEta"    toml:"no_eta"    xml:"no_eta"    yaml:"noEta"`
+	// Action is notify, remove, blocklist or research.
+	Action string `json:"action" toml:"action" xml:"action" yaml:"action"`
+	// Clients limits the detector to these torrent clients, like qbit or deluge. Empty means all.
+	Clients []string `json:"clients" toml:"clients" xml:"clients" yaml:"clients"`
+}
+
+// StalledItem is a stalled torrent and what was done about it. These are logged.
+type StalledItem struct {
+	App      starr.App `json:"app"`
+	Instance int       `json:"instance"`
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	Reason   string    `json:"reason"`
+	Action   string    `json:"action"`
+	Since    time.Time `json:"since"`
+	Owner    string    `json:"owner,omitempty"` // starr app that owns the download, ie. Radarr 1.
+	Error    string    `json:"error,omitempty"`
+}
+
+// stalled tracks how long each torrent has been in each condition, and what was already reported.
+type stalled struct {
+	config *StalledConfig
+	since  map[string]time.Time
+	acted  map[string]struct{}
+	owners map[string]*queueOwner // lazily filled once per check.
+}
+
+// queueOwner is a starr app queue record for a download.
+type queueOwner struct {
+	instance apps.StarrInstance
+	queueID  int64
+}
+
+// Enabled returns true if any stalled torrent checks are configured.
+func (s *StalledConfig) Enabled() bool {
+	return s != nil && (s.NoSeeds.Duration > 0 || s.Metadata.Duration > 0 || s.NoETA.Duration > 0)
+}
+
+func (c *cmd) setupStalled(reqID string) {
+	if !c.stalled.config.Enabled() {
+		return
+	}
+
+	cfg := c.stalled.config
+	if cfg.Interval.Duration == 0 {
+		cfg.Interval.Duration = defaultStalledInterval
+	} else if cfg.Interval.Duration < minimumStalledInterval {
+		cfg.Interval.Duration = minimumStalledInterval
+	}
+
+	if cfg.Action == "" {
+		cfg.Action = StalledNotify
+	}
+
+	mnd.Log.Printf(reqID, "==> Stalled Torrent Detector Started, interval:%s no_seeds:%s metadata:%s no_eta:%s "+
+		"action:%s clients:%q", cfg.Interval, cfg.NoSeeds, cfg.Metadata, cfg.NoETA, cfg.Action, cfg.Clients)
+
+	c.Add(&common.Action{
+		Key:  "TrigStalledTorrents",
+		Name: TrigStalledTorrents,
+		Fn:   c.checkStalled,
+		C:    make(chan *common.ActionInput, 1),
+		D:    cfg.Interval,
+	})
+}
+
+// StalledTorrents checks the download clients for stalled torrents now.
+// Returns false if the stalled torrent detector is not enabled.
+func (a *Action) StalledTorrents(input *common.ActionInput) bool {
+	return a.cmd.Exec(input, TrigStalledTorrents)
+}
+
+// checkStalled inspects every torrent and acts on the stalled ones.
+func (c *cmd) checkStalled(ctx context.Context, input *common.ActionInput) {
+	var (
+		now   = time.Now()
+		since = make(map[string]time.Time)
+		acted = make(map[string]struct{})
+		items = []*StalledItem{}
+	)
+
+	ctx = mnd.WithID(ctx, input.ReqID)
+	c.stalled.owners = nil
+
+	for _, app := range []starr.App{apps.AppDeluge, apps.AppQbit, apps.AppRtorrent, apps.AppTransmission} {
+		if !c.stalled.watches(app) {
+			continue
+		}
+
+		for idx, client := range c.Apps.Downloaders(app) {
+			if !client.Enabled() {
+				continue
+			}
+
+			xfers, err := client.Transfers(ctx)
+			if err != nil {
+				mnd.Log.Errorf(input.ReqID, "[%s requested] Getting %s %d transfers: %v", input.Type, app, idx+1, err)
+				continue
+			}
+
+			for _, xfer := range xfers {
+				reason := c.stalled.reason(xfer)
+				if reason == "" {
+					continue
+				}
+
+				key := fmt.Sprintf("%s/%d/%s/%s", app, idx, xfer.ID, reason)
+				if since[key] = c.stalled.since[key]; since[key].IsZero() {
+					since[key] = now
+				}
+
+				if _, ok := c.stalled.acted[key]; ok {
+					acted[key] = struct{}{}
+					continue
+				} else if now.Sub(since[key]) < c.stalled.wait(reason) {
+					continue
+				}
+
+				acted[key] = struct{}{}
+				item := &StalledItem{
+					App: app, Instance: idx + 1, ID: xfer.ID, Name: xfer.Name, Reason: reason, Since: since[key],
+				}
+
+				// Try again next time if the delete limit was reached.
+				if err := c.actOnStalled(ctx, client, item); errors.Is(err, ErrDeleteLimited) {
+					delete(acted, key)
+				}
+
+				items = append(items, item)
+			}
+		}
+	}
+
+	// Replacing the maps drops torrents that are no longer stalled (or no longer exist).
+	c.stalled.since, c.stalled.acted = since, acted
+
+	if len(items) == 0 {
+		mnd.Log.Debugf(input.ReqID, "[%s requested] No new stalled torrents found.", input.Type)
+		return
+	}
+
+	for _, item := range items {
+		mnd.Log.Printf(input.ReqID, "[%s requested] Stalled torrent: %s", input.Type, item)
+	}
+}
+
+// String describes the stalled torrent and what was done with it.
+func (s *StalledItem) String() string {
+	msg := fmt.Sprintf("%s %d: %s: %s, action: %s", s.App, s.Instance, s.Name, s.Reason, s.Action)
+	if s.Error != "" {
+		msg += ", error: " + s.Error
+	}
+
+	return msg
+}
+
+// watches returns true if the download client app is included in the stalled config.
+func (s *stalled) watches(app starr.App) bool {
+	if len(s.config.Clients) == 0 {
+		return true
+	}
+
+	for _, name := range s.config.Clients {
+		if strings.EqualFold(name, string(app)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Reasons a torrent may be stalled.
+const (
+	reasonMetadata = "stuck downloading metadata"
+	reasonNoSeeds  = "no connected seeds"
+	reasonNoETA    = "infinite ETA"
+)
+
+// reason returns the reason a transfer is stalled, if it is. Completed and paused transfers are not stalled.
+func (s *stalled) reason(xfer *apps.Transfer) string {
+	switch {
+	case xfer.Progress >= 100 || xfer.Paused: //nolint:mnd // percent
+		return ""
+	case s.config.Metadata.Duration > 0 && xfer.Metadata:
+		return reasonMetadata
+	case s.config.NoSeeds.Duration > 0 && xfer.Seeds == 0:
+		return reasonNoSeeds
+	case s.config.NoETA.Duration > 0 && xfer.ETA < 0:
+		return reasonNoETA
+	default:
+		return ""
+	}
+}
+
+// wait returns how long a torrent must be in a condition before it's stalled.
+func (s *stalled) wait(reason string) time.Duration {
+	switch reason {
+	case reasonMetadata:
+		return s.config.Metadata.Duration
+	case reasonNoSeeds:
+		return s.config.NoSeeds.Duration
+	case reasonNoETA:
+		return s.config.NoETA.Duration
+	default:
+		return 0
+	}
+}
+
+// actOnStalled applies the configured action to a stalled torrent, and records the result on the item.
+func (c *cmd) actOnStalled(ctx context.Context, client apps.Downloader, item *StalledItem) error {
+	item.Action = c.stalled.config.Action
+
+	var err error
+
+	switch item.Action {
+	case StalledNotify:
+	case StalledRemove:
+		err = c.removeStalled(ctx, client, item)
+	case StalledBlocklist, StalledResearch:
+		err = c.blocklistStalled(ctx, item, item.Action == StalledResearch)
+	default:
+		err = fmt.Errorf("%w: %s", ErrBadAction, item.Action)
+	}
+
+	reqID := mnd.GetID(ctx)
+	if err != nil {
+		item.Error = err.Error()
+		mnd.Log.Errorf(reqID, "Stalled torrent %s %d '%s' (%s): %s failed: %v",
+			item.App, item.Instance, item.Name, item.Reason, item.Action, err)
+	} else {
+		mnd.Log.Printf(reqID, "Stalled torrent %s %d '%s' (%s): %s",
+			item.App, item.Instance, item.Name, item.Reason, item.Action)
+	}
+
+	return err
+}
+
+// removeStalled removes a torrent and its data from its client. If a starr app owns it, that app's delete limit applies.
+func (c *cmd) removeStalled(ctx context.Context, client apps.Downloader, item *StalledItem) error {
+	if owner := c.stalledOwner(ctx, item.ID); owner != nil {
+		item.Owner = fmt.Sprintf("%s %d", owner.instance.App(), owner.instance.Instance())
+		if !owner.instance.Starr().DelOK() {
+			return ErrDeleteLimited
+		}
+	}
+
+	err := client.RemoveTransfers(ctx, []string{item.ID}, true)
+	if errors.Is(err, apps.ErrUnsupported) {
+		err = client.RemoveTransfers(ctx, []string{item.ID}, false)
+	}
+
+	if err != nil {
+		return fmt.Errorf("removing transfer: %w", err)
+	}
+
+	return nil
+}
+
+// blocklistStalled removes a download from the owning starr app's queue, and blocklists the release.
+func (c *cmd) blocklistStalled(ctx context.Context, item *StalledItem, search bool) error {
+	owner := c.stalledOwner(ctx, item.ID)
+	if owner == nil {
+		return ErrNoStarrOwner
+	}
+
+	item.Owner = fmt.Sprintf("%s %d", owner.instance.App(), owner.instance.Instance())
+	if !owner.instance.Starr().DelOK() {
+		return ErrDeleteLimited
+	}
+
+	return deleteQueueItem(ctx, owner.instance, owner.queueID, &starr.QueueDeleteOpts{
+		BlockList:      true,
+		SkipRedownload: !search,
+	})
+}
+
+// stalledOwner returns the starr app queue item for a download ID. The queues are fetched once per check.
+func (c *cmd) stalledOwner(ctx context.Context, downloadID string) *queueOwner {
+	if c.stalled.owners == nil {
+		c.stalled.owners = make(map[string]*queueOwner)
+
+		for _, instance := range c.Apps.StarrInstances(starr.Lidarr, starr.Radarr, starr.Readarr, starr.Sonarr) {
+			if !instance.Enabled() {
+				continue
+			}
+
+			records, err := getQueueRecords(ctx, instance)
+			if err != nil {
+				mnd.Log.Errorf(mnd.GetID(ctx), "Getting %s %d queue: %v", instance.App(), instance.Instance(), err)
+				continue
+			}
+
+			for _, record := range records {
+				c.stalled.owners[strings.ToLower(record.DownloadID)] = &queueOwner{instance: instance, queueID: record.ID}
+			}
+		}
+	}
+
+	return c.stalled.owners[strings.ToLower(downloadID)]
+}
+
+// queueRecord is the part of a starr queue record these procedures need. It works with every starr app.
+type queueRecord struct {
+	ID                   int64                  `json:"id"`
+	DownloadID           string                 `json:"downloadId"`
+	Title                string                 `json:"title"`
+	Status               string                 `json:"status"`
+	TrackedDownloadState string                 `json:"trackedDownloadState"`
+	DownloadClient       string                 `json:"downloadClient"`
+	ErrorMessage         string                 `json:"errorMessage"`
+	StatusMessages       []*starr.StatusMessage `json:"statusMessages"`
+}
+
+// getQueueRecords returns every record in a starr app's queue.
+func getQueueRecords(ctx context.Context, instance apps.StarrInstance) ([]*queueRecord, error) {
+	var queue struct {
+		Records []*queueRecord `json:"records"`
+	}
+
+	err := instance.StarrClient().GetInto(ctx, starr.Request{
+		URI:   instance.Starr().APIPath("queue"),
+		Query: url.Values{"pageSize": {strconv.Itoa(queueItemsMax)}, "includeUnknownMovieItems": {"true"}},
+	}, &queue)
+	if err != nil {
+		return nil, fmt.Errorf("getting queue: %w", err)
+	}
+
+	return queue.Records, nil
+}
+
+// deleteQueueItem removes an item from a starr app's queue and its download client.
+func deleteQueueItem(ctx context.Context, instance apps.StarrInstance, id int64, opts *starr.QueueDeleteOpts) error {
+	err := instance.StarrClient().DeleteAny(ctx, starr.Request{
+		URI:   instance.Starr().APIPath("queue", strconv.FormatInt(id, mnd.Base10)),
+		Query: opts.Values(),
+	})
+	if err != nil {
+		return fmt.Errorf("deleting queue item %d: %w", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package starrqueue //nolint:testpackage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/stretchr/testify/assert"
+	"golift.io/cnfg"
+)
+
+func TestStalledReason(t *testing.T) {
+	t.Parallel()
+
+	hour := cnfg.Duration{Duration: time.Hour}
+	detector := &stalled{config: &StalledConfig{NoSeeds: hour, Metadata: hour, NoETA: hour}}
+
+	tests := []struct {
+		name   string
+		xfer   *apps.Transfer
+		reason string
+	}{
+		{name: "healthy", xfer: &apps.Transfer{Progress: 50, Seeds: 3, ETA: 60}},
+		{name: "metadata", xfer: &apps.Transfer{Metadata: true, ETA: -1}, reason: reasonMetadata},
+		{name: "no seeds", xfer: &apps.Transfer{Progress: 10, ETA: -1}, reason: reasonNoSeeds},
+		{name: "no eta", xfer: &apps.Transfer{Progress: 10, Seeds: 1, ETA: -1}, reason: reasonNoETA},
+		{name: "paused", xfer: &apps.Transfer{Progress: 10, Paused: true}},
+		{name: "seeding", xfer: &apps.Transfer{Progress: 100, Ratio: 1, SeedTime: 3600, ETA: -1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.reason, detector.reason(test.xfer))
+		})
+	}
+
+	// Only configured checks apply.
+	reason := (&stalled{config: &StalledConfig{NoETA: hour}}).reason(&apps.Transfer{Metadata: true, ETA: -1})
+	assert.Equal(t, reasonNoETA, reason)
+}
+
+func TestStalledWaitAndWatches(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	detector := &stalled{config: &StalledConfig{
+		NoSeeds:  cnfg.Duration{Duration: time.Hour},
+		Metadata: cnfg.Duration{Duration: time.Minute},
+		NoETA:    cnfg.Duration{Duration: time.Second},
+	}}
+
+	assert.Equal(time.Hour, detector.wait(reasonNoSeeds))
+	assert.Equal(time.Minute, detector.wait(reasonMetadata))
+	assert.Equal(time.Second, detector.wait(reasonNoETA))
+	assert.True(detector.watches(apps.AppQbit), "no clients means all clients")
+
+	detector.config.Clients = []string{"deluge"}
+	assert.True(detector.watches(apps.AppDeluge), "client names are not case sensitive")
+	assert.False(detector.watches(apps.AppQbit))
+}
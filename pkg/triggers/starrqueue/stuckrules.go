@@ -0,0 +1,519 @@
+package starrqueue
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"golift.io/cnfg"
+	"golift.io/starr"
+)
+
+/* This file applies local rules to stuck queue items. It does not need the website, or the stored queues. */
+
+const TrigStuckRules common.TriggerName = "Applying rules to stuck queue items."
+
+// Actions a stuck item rule may take.
+const (
+	StuckRemove    = "remove"    // Remove the item from the queue and download client.
+	StuckBlocklist = "blocklist" // Remove the item, and blocklist the release.
+	StuckResearch  = "research"  // Remove the item, blocklist the release, and search for a replacement.
+	StuckImport    = "import"    // Manually import the files the starr app matched to media.
+)
+
+const (
+	defaultStuckInterval = 5 * time.Minute
+	minimumStuckInterval = time.Minute
+)
+
+// Errors returned while applying stuck item rules.
+var (
+	ErrNothingToImport = errors.New("no files matched to media to import")
+	ErrBadStuckAction  = errors.New("unknown stuck item rule action")
+	ErrImportRefused   = errors.New("the starr app refuses to import this item")
+)
+
+// noImportReasons are parts of queue messages for items the starr app refuses to import on purpose.
+// Importing these anyway replaces better files or imports samples, so the import action is not allowed.
+var noImportReasons = []string{
+	"sample", "not an upgrade", "not a custom format upgrade", "not a quality revision upgrade",
+}
+
+// StuckConfig is the local stuck queue item rule engine. Rules are checked in order; the first match wins.
+type StuckConfig struct {
+	Interval cnfg.Duration `json:"interval" toml:"interval" xml:"interval" yaml:"interval"`
+	// DryRun logs the actions that would be taken, and takes none.
+	DryRun bool         `json:"dryRun" toml:"dry_run" xml:"dry_run" yaml:"dryRun"`
+	Rules  []*StuckRule `json:"rules"  toml:"rule"    xml:"rule"    yaml:"rules"`
+}
+
+// StuckRule matches stuck queue items by status message or tracked download state.
+// A rule without messages or states never matches.
+type StuckRule struct {
+	Name string `json:"name" toml:"name" xml:"name" yaml:"name"`
+	// Messages match any part of the item's status or error messages. Case-insensitive.
+	Messages []string `json:"messages" toml:"messages" xml:"messages" yaml:"messages"`
+	// States match the tracked download state, like importPending, importBlocked or failedPending.
+	States []string `json:"states" toml:"states" xml:"states" yaml:"states"`
+	// Apps limits the rule to these starr apps, like sonarr or radarr. Empty means all.
+	Apps []string `json:"apps" toml:"apps" xml:"apps" yaml:"apps"`
+	// Wait is how long an item must match the rule before the action is taken.
+	Wait     cnfg.Duration `json:"wait"     toml:"wait"     xml:"wait"     yaml:"wait"`
+	Action   string        `json:"action"   toml:"action"   xml:"action"   yaml:"action"`
+	Disabled bool          `json:"disabled" toml:"disabled" xml:"disabled" yaml:"disabled"`
+}
+
+// StuckAction is a stuck queue item and what a rule did about it. These are logged.
+type StuckAction struct {
+	App      starr.App `json:"app"`
+	Instance int       `json:"instance"`
+	QueueID  int64     `json:"queueId"`
+	Title    string    `json:"title"`
+	Rule     string    `json:"rule"`
+	Action   string    `json:"action"`
+	Since    time.Time `json:"since"`
+	DryRun   bool      `json:"dryRun,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// stuckRules tracks how long each queue item has matched a rule, and which items were acted on.
+type stuckRules struct {
+	config *StuckConfig
+	since  map[string]time.Time
+	acted  map[string]struct{}
+}
+
+// Enabled returns true if any stuck item rules are configured.
+func (s *StuckConfig) Enabled() bool {
+	return s != nil && len(s.Rules) > 0
+}
+
+func (c *cmd) setupStuckRules(reqID string) {
+	if !c.stuck.config.Enabled() {
+		return
+	}
+
+	cfg := c.stuck.config
+	if cfg.Interval.Duration == 0 {
+		cfg.Interval.Duration = defaultStuckInterval
+	} else if cfg.Interval.Duration < minimumStuckInterval {
+		cfg.Interval.Duration = minimumStuckInterval
+	}
+
+	for idx, rule := range cfg.Rules {
+		if err := rule.validate(); err != nil {
+			mnd.Log.Errorf(reqID, "Stuck item rule %d '%s' disabled: %v", idx+1, rule.Name, err)
+			rule.Disabled = true
+		}
+	}
+
+	mnd.Log.Printf(reqID, "==> Stuck Item Rules Enabled, interval:%s rules:%d dry_run:%v",
+		cfg.Interval, len(cfg.Rules), cfg.DryRun)
+
+	c.Add(&common.Action{
+		Key:  "TrigStuckRules",
+		Name: TrigStuckRules,
+		Fn:   c.applyStuckRules,
+		C:    make(chan *common.ActionInput, 1),
+		D:    cfg.Interval,
+	})
+}
+
+// StuckRules applies the stuck item rules now. Returns false if no rules are configured.
+func (a *Action) StuckRules(input *common.ActionInput) bool {
+	return a.cmd.Exec(input, TrigStuckRules)
+}
+
+// applyStuckRules fetches every starr queue and acts on the items that matched a rule long enough.
+func (c *cmd) applyStuckRules(ctx context.Context, input *common.ActionInput) {
+	var (
+		now     = time.Now()
+		since   = make(map[string]time.Time)
+		acted   = make(map[string]struct{})
+		actions = []*StuckAction{}
+	)
+
+	ctx = mnd.WithID(ctx, input.ReqID)
+
+	for _, instance := range c.Apps.StarrInstances(starr.Lidarr, starr.Radarr, starr.Readarr, starr.Sonarr) {
+		if !instance.Enabled() {
+			continue
+		}
+
+		records, err := getQueueRecords(ctx, instance)
+		if err != nil {
+			mnd.Log.Errorf(input.ReqID, "[%s requested] Getting %s %d queue for stuck item rules: %v",
+				input.Type, instance.App(), instance.Instance(), err)
+			continue
+		}
+
+		for _, record := range records {
+			rule, name := c.stuck.match(instance.App(), record)
+			if rule == nil {
+				continue
+			}
+
+			key := fmt.Sprintf("%s/%d/%d/%s", instance.App(), instance.Index(), record.ID, name)
+			if since[key] = c.stuck.since[key]; since[key].IsZero() {
+				since[key] = now
+			}
+
+			if _, ok := c.stuck.acted[key]; ok {
+				acted[key] = struct{}{}
+				continue
+			} else if now.Sub(since[key]) < rule.Wait.Duration {
+				continue
+			}
+
+			acted[key] = struct{}{}
+
+			action := &StuckAction{
+				App:      instance.App(),
+				Instance: instance.Instance(),
+				QueueID:  record.ID,
+				Title:    record.Title,
+				Rule:     name,
+				Action:   rule.Action,
+				Since:    since[key],
+				DryRun:   c.stuck.config.DryRun,
+			}
+
+			// Try again next time if the delete limit was reached.
+			if err := c.actOnStuck(ctx, instance, record, action); errors.Is(err, ErrDeleteLimited) {
+				delete(acted, key)
+			}
+
+			actions = append(actions, action)
+		}
+	}
+
+	// Replacing the maps drops items that are no longer in a queue (or no longer match).
+	c.stuck.since, c.stuck.acted = since, acted
+
+	if len(actions) == 0 {
+		mnd.Log.Debugf(input.ReqID, "[%s requested] No stuck queue items matched a rule.", input.Type)
+		return
+	}
+
+	for _, action := range actions {
+		mnd.Log.Printf(input.ReqID, "[%s requested] Stuck item rule: %s", input.Type, action)
+	}
+}
+
+// String describes the queue item, the rule it matched and what was done with it.
+func (s *StuckAction) String() string {
+	msg := fmt.Sprintf("%s %d: %s: rule: %s, action: %s", s.App, s.Instance, s.Title, s.Rule, s.Action)
+	if s.DryRun {
+		msg += " (dry run)"
+	}
+
+	if s.Error != "" {
+		msg += ", error: " + s.Error
+	}
+
+	return msg
+}
+
+// validate normalizes the rule's action, and returns an error if the action is unknown,
+// or if the rule imports items the starr app refuses to import.
+func (r *StuckRule) validate() error {
+	r.Action = strings.ToLower(strings.TrimSpace(r.Action))
+
+	switch r.Action {
+	case StuckRemove, StuckBlocklist, StuckResearch:
+		return nil
+	case StuckImport:
+	default:
+		return fmt.Errorf("%w: '%s'", ErrBadStuckAction, r.Action)
+	}
+
+	for _, msg := range r.Messages {
+		if refusedImport(msg) {
+			return fmt.Errorf("%w: the import action may not be used for '%s' messages", ErrImportRefused, msg)
+		}
+	}
+
+	return nil
+}
+
+// refusedImport returns true if the message is a reason the starr app refuses to import an item.
+func refusedImport(msg string) bool {
+	msg = strings.ToLower(msg)
+
+	for _, reason := range noImportReasons {
+		if strings.Contains(msg, reason) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// messages returns the error and status messages of a queue item.
+func (r *queueRecord) messages() []string {
+	messages := []string{r.ErrorMessage}
+	for _, status := range r.StatusMessages {
+		messages = append(messages, status.Title)
+		messages = append(messages, status.Messages...)
+	}
+
+	return messages
+}
+
+// match returns the first rule that matches a queue item, and the rule's name.
+func (s *stuckRules) match(app starr.App, record *queueRecord) (*StuckRule, string) {
+	if st := strings.ToLower(record.Status); st != completed && st != warning && st != failed &&
+		st != errorstr && record.ErrorMessage == "" && len(record.StatusMessages) == 0 {
+		return nil, ""
+	}
+
+	for idx, rule := range s.config.Rules {
+		if !rule.matches(app, record) {
+			continue
+		}
+
+		if rule.Disabled {
+			return nil, "" // Disabled rules still stop later rules from matching.
+		}
+
+		if rule.Name == "" {
+			return rule, fmt.Sprint("rule ", idx+1)
+		}
+
+		return rule, rule.Name
+	}
+
+	return nil, ""
+}
+
+// matches returns true if the rule applies to the queue item.
+func (r *StuckRule) matches(app starr.App, record *queueRecord) bool {
+	if len(r.Messages) == 0 && len(r.States) == 0 {
+		return false
+	}
+
+	if len(r.Apps) > 0 && !containsFold(r.Apps, string(app)) {
+		return false
+	}
+
+	if len(r.States) > 0 && !containsFold(r.States, record.TrackedDownloadState) {
+		return false
+	}
+
+	if len(r.Messages) == 0 {
+		return true
+	}
+
+	for _, want := range r.Messages {
+		for _, msg := range record.messages() {
+			if msg != "" && strings.Contains(strings.ToLower(msg), strings.ToLower(want)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// containsFold returns true if the list contains the string, ignoring case.
+func containsFold(list []string, str string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, str) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// actOnStuck applies a rule's action to a queue item, and records the result on the action.
+func (c *cmd) actOnStuck(
+	ctx context.Context, instance apps.StarrInstance, record *queueRecord, action *StuckAction,
+) error {
+	var err error
+
+	switch action.Action {
+	case StuckRemove, StuckBlocklist, StuckResearch:
+		switch {
+		case action.DryRun:
+		case !instance.Starr().DelOK():
+			err = ErrDeleteLimited
+		default:
+			err = deleteQueueItem(ctx, instance, record.ID, &starr.QueueDeleteOpts{
+				BlockList:      action.Action != StuckRemove,
+				SkipRedownload: action.Action != StuckResearch,
+			})
+		}
+	case StuckImport:
+		if idx := slices.IndexFunc(record.messages(), refusedImport); idx >= 0 {
+			err = fmt.Errorf("%w: %s", ErrImportRefused, record.messages()[idx])
+		} else if !action.DryRun {
+			err = manualImport(ctx, instance, record.DownloadID)
+		}
+	default:
+		err = fmt.Errorf("%w: %s", ErrBadStuckAction, action.Action)
+	}
+
+	reqID := mnd.GetID(ctx)
+	if err != nil {
+		action.Error = err.Error()
+		mnd.Log.Errorf(reqID, "Stuck item rule '%s': %s %d '%s': %s failed: %v",
+			action.Rule, action.App, action.Instance, action.Title, action.Action, err)
+	} else {
+		mnd.Log.Printf(reqID, "Stuck item rule '%s': %s %d '%s': %s (dry run: %v)",
+			action.Rule, action.App, action.Instance, action.Title, action.Action, action.DryRun)
+	}
+
+	return err
+}
+
+// importID is an ID in a manual import candidate.
+type importID struct {
+	ID int64 `json:"id"`
+}
+
+// importRejection is a reason the starr app will not import a manual import candidate.
+type importRejection struct {
+	Reason string `json:"reason"`
+	Type   string `json:"type"` // permanent or temporary.
+}
+
+// importCandidate is a file the starr app found in a download, and the media it matched the file to.
+// Each app uses a different set of these fields.
+type importCandidate struct {
+	Path           string      `json:"path"`
+	DownloadID     string      `json:"downloadId"`
+	ReleaseGroup   string      `json:"releaseGroup"`
+	Quality        any         `json:"quality"`
+	Languages      any         `json:"languages"`
+	IndexerFlags   int64       `json:"indexerFlags"`
+	Movie          *importID   `json:"movie"`
+	Series         *importID   `json:"series"`
+	Episodes       []*importID `json:"episodes"`
+	Artist         *importID   `json:"artist"`
+	Album          *importID   `json:"album"`
+	AlbumReleaseID int64       `json:"albumReleaseId"`
+	Tracks         []*importID `json:"tracks"`
+	Author         *importID   `json:"author"`
+	Book           *importID   `json:"book"`
+	// Rejections are why the app would not import this file. The manual import dialog refuses these too.
+	Rejections []*importRejection `json:"rejections"`
+}
+
+// manualImport imports the files in a download that the starr app matched to media.
+// This is the same as choosing every matched file in the app's manual import dialog.
+func manualImport(ctx context.Context, instance apps.StarrInstance, downloadID string) error {
+	var candidates []*importCandidate
+
+	uri := instance.Starr().APIPath("manualimport")
+	query := url.Values{"downloadId": {downloadID}, "filterExistingFiles": {"true"}}
+
+	err := instance.StarrClient().GetInto(ctx, starr.Request{URI: uri, Query: query}, &candidates)
+	if err != nil {
+		return fmt.Errorf("getting manual import candidates: %w", err)
+	}
+
+	files, rejected := importFiles(instance.App(), candidates)
+	if len(files) == 0 && len(rejected) > 0 {
+		return fmt.Errorf("%w: %d rejected: %s", ErrNothingToImport, len(rejected), strings.Join(rejected, "; "))
+	} else if len(files) == 0 {
+		return ErrNothingToImport
+	}
+
+	body, err := json.Marshal(map[string]any{"name": "ManualImport", "importMode": "auto", "files": files})
+	if err != nil {
+		return fmt.Errorf("encoding manual import command: %w", err)
+	}
+
+	var command struct {
+		ID int64 `json:"id"`
+	}
+
+	err = instance.StarrClient().PostInto(ctx, starr.Request{
+		URI:  instance.Starr().APIPath("command"),
+		Body: bytes.NewReader(body),
+	}, &command)
+	if err != nil {
+		return fmt.Errorf("sending manual import command: %w", err)
+	}
+
+	return nil
+}
+
+// importFiles returns the manual import command files for the candidates that are matched to media,
+// and the rejection reasons of the candidates the starr app will not import.
+func importFiles(app starr.App, candidates []*importCandidate) ([]map[string]any, []string) {
+	files := []map[string]any{}
+	rejected := []string{}
+
+	for _, file := range candidates {
+		if len(file.Rejections) > 0 {
+			rejected = append(rejected, fmt.Sprintf("%s: %s", filepath.Base(file.Path), file.Rejections[0].Reason))
+		} else if item := file.importFile(app); item != nil {
+			files = append(files, item)
+		}
+	}
+
+	return files, rejected
+}
+
+// importFile returns the manual import command file for a candidate, or nil if it's not matched to media.
+func (i *importCandidate) importFile(app starr.App) map[string]any {
+	file := map[string]any{
+		"path":         i.Path,
+		"downloadId":   i.DownloadID,
+		"releaseGroup": i.ReleaseGroup,
+		"quality":      i.Quality,
+	}
+
+	switch app { //nolint:exhaustive // Only apps with a download queue.
+	case starr.Lidarr:
+		if i.Artist == nil || i.Album == nil || len(i.Tracks) == 0 {
+			return nil
+		}
+
+		file["artistId"], file["albumId"], file["albumReleaseId"] = i.Artist.ID, i.Album.ID, i.AlbumReleaseID
+		file["trackIds"] = importIDs(i.Tracks)
+	case starr.Radarr:
+		if i.Movie == nil {
+			return nil
+		}
+
+		file["movieId"], file["languages"], file["indexerFlags"] = i.Movie.ID, i.Languages, i.IndexerFlags
+	case starr.Readarr:
+		if i.Author == nil || i.Book == nil {
+			return nil
+		}
+
+		file["authorId"], file["bookId"] = i.Author.ID, i.Book.ID
+	default:
+		if i.Series == nil || len(i.Episodes) == 0 {
+			return nil
+		}
+
+		file["seriesId"], file["languages"], file["indexerFlags"] = i.Series.ID, i.Languages, i.IndexerFlags
+		file["episodeIds"] = importIDs(i.Episodes)
+	}
+
+	return file
+}
+
+// importIDs returns the IDs from a list of import IDs.
+func importIDs(list []*importID) []int64 {
+	ids := make([]int64, 0, len(list))
+	for _, item := range list {
+		ids = append(ids, item.ID)
+	}
+
+	return ids
+}
@@ -0,0 +1,125 @@
+package starrqueue //nolint:testpackage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golift.io/starr"
+)
+
+func TestStuckRuleMatch(t *testing.T) {
+	t.Parallel()
+
+	rules := &stuckRules{config: &StuckConfig{Rules: []*StuckRule{
+		{Name: "no files", Messages: []string{"No files found are eligible"}, Action: StuckResearch},
+		{Name: "off", Messages: []string{"disabled rule"}, Disabled: true},
+		{Messages: []string{"disabled rule", "unknown series"}, Apps: []string{"sonarr"}, Action: StuckRemove},
+		{Name: "blocked", States: []string{"importBlocked"}, Apps: []string{"radarr"}, Action: StuckImport},
+		{Name: "empty", Action: StuckRemove},
+	}}}
+
+	status := func(title string, messages ...string) []*starr.StatusMessage {
+		return []*starr.StatusMessage{{Title: title, Messages: messages}}
+	}
+
+	tests := []struct {
+		name   string
+		app    starr.App
+		record *queueRecord
+		want   string // empty means no rule.
+	}{
+		{
+			name:   "downloading",
+			app:    starr.Sonarr,
+			record: &queueRecord{Status: "downloading", TrackedDownloadState: "downloading"},
+		},
+		{
+			name: "status message",
+			app:  starr.Sonarr,
+			record: &queueRecord{
+				Status: "completed", StatusMessages: status("file.mkv", "no files found are eligible for import"),
+			},
+			want: "no files",
+		},
+		{
+			name:   "error message",
+			app:    starr.Radarr,
+			record: &queueRecord{Status: "warning", ErrorMessage: "No files found are eligible for import in /dl"},
+			want:   "no files",
+		},
+		{
+			name:   "disabled rule stops later rules",
+			app:    starr.Sonarr,
+			record: &queueRecord{Status: "completed", ErrorMessage: "Disabled Rule"},
+		},
+		{
+			name:   "unnamed rule",
+			app:    starr.Sonarr,
+			record: &queueRecord{Status: "completed", ErrorMessage: "Unknown Series"},
+			want:   "rule 3",
+		},
+		{
+			name:   "wrong app",
+			app:    starr.Lidarr,
+			record: &queueRecord{Status: "completed", ErrorMessage: "Unknown Series"},
+		},
+		{
+			name:   "state",
+			app:    starr.Radarr,
+			record: &queueRecord{Status: "completed", TrackedDownloadState: "ImportBlocked"},
+			want:   "blocked",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule, name := rules.match(test.app, test.record)
+			assert.Equal(t, test.want, name)
+			assert.Equal(t, test.want == "", rule == nil)
+		})
+	}
+}
+
+func TestStuckRuleValidate(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	rule := &StuckRule{Action: " Research ", Messages: []string{"Sample"}}
+	require.NoError(t, rule.validate())
+	assert.Equal(StuckResearch, rule.Action, "the action must be normalized")
+	require.NoError(t, (&StuckRule{Action: StuckImport, States: []string{"importPending"}}).validate())
+	assert.ErrorIs((&StuckRule{Action: "delete"}).validate(), ErrBadStuckAction)
+	assert.ErrorIs((&StuckRule{Action: StuckImport, Messages: []string{"Sample"}}).validate(), ErrImportRefused)
+	upgrade := &StuckRule{Action: "import", Messages: []string{"Not an upgrade for existing episode file(s)"}}
+	assert.ErrorIs(upgrade.validate(), ErrImportRefused)
+}
+
+func TestImportFiles(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	candidates := []*importCandidate{
+		{Path: "/dl/movie.mkv", Movie: &importID{ID: 5}},
+		{
+			Path: "/dl/sample.mkv", Movie: &importID{ID: 5},
+			Rejections: []*importRejection{{Reason: "Sample", Type: "permanent"}},
+		},
+		{Path: "/dl/unknown.mkv"},
+	}
+
+	files, rejected := importFiles(starr.Radarr, candidates)
+	assert.Len(files, 1, "only the matched file without rejections is imported")
+	assert.Equal(int64(5), files[0]["movieId"])
+	assert.Equal([]string{"sample.mkv: Sample"}, rejected)
+
+	files, rejected = importFiles(starr.Sonarr, []*importCandidate{
+		{Path: "/dl/ep.mkv", Series: &importID{ID: 1}, Episodes: []*importID{{ID: 2}, {ID: 3}}},
+		{Path: "/dl/noeps.mkv", Series: &importID{ID: 1}},
+	})
+	assert.Empty(rejected)
+	assert.Len(files, 1, "a series file without episodes is not matched")
+	assert.Equal([]int64{2, 3}, files[0]["episodeIds"])
+}
@@ -26,9 +26,12 @@ import (
 	"github.com/Notifiarr/notifiarr/pkg/triggers/filewatch"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/gaps"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/mdblist"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/orphans"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/plexcron"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/seeding"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/snapcron"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/starrqueue"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/usenet"
 	"github.com/Notifiarr/notifiarr/pkg/website"
 	"github.com/Notifiarr/notifiarr/pkg/website/clientinfo"
 	"github.com/go-co-op/gocron/v2"
@@ -42,6 +45,12 @@ type Config struct {
 	Endpoints  []*epconfig.Endpoint
 	LogFiles   []string
 	Commands   []*commands.Command
+	Stalled    *starrqueue.StalledConfig
+	Stuck      *starrqueue.StuckConfig
+	Imports    *starrqueue.ImportConfig
+	Seeding    *seeding.Config
+	Orphans    *orphans.Config
+	Usenet     *usenet.Config
 	ClientInfo *clientinfo.Config
 	ConfigFile string
 	AutoUpdate string
@@ -65,9 +74,12 @@ type Actions struct {
 	Gaps       *gaps.Action
 	MDbList    *mdblist.Action
 	Endpoints  *endpoints.Action
+	Orphans    *orphans.Action
 	PlexCron   *plexcron.Action
+	Seeding    *seeding.Action
 	SnapCron   *snapcron.Action
 	StarrQueue *starrqueue.Action
+	Usenet     *usenet.Action
 	inCh       chan inChData
 	outCh      chan string
 }
@@ -81,29 +93,34 @@ func New(ctx context.Context, config *Config) *Actions {
 		Services: config.Services,
 	}
 	common.Scheduler, _ = gocron.NewScheduler()
-	plex := plexcron.New(common, &config.Apps.Plex)
+	plex := plexcron.New(common, &config.Apps.Plex, config.ConfigFile)
 
 	actions := &Actions{
 		AutoUpdate: autoupdate.New(common, config.AutoUpdate, config.ConfigFile, config.UnstableCh),
 		Backups:    backups.New(common),
 		CFSync:     cfsync.New(common),
-		Commands:   commands.New(common, config.Commands),
+		Commands:   commands.New(common, config.Commands, config.ConfigFile),
 		Config:     common,
 		CronTimer:  crontimer.New(common),
-		Dashboard:  dashboard.New(common, plex),
+		Dashboard:  dashboard.New(common, plex, config.Usenet),
 		EmptyTrash: emptytrash.New(common),
 		FileUpload: fileupload.New(common),
-		FileWatch:  filewatch.New(common, config.WatchFiles, config.LogFiles),
 		Gaps:       gaps.New(common),
 		MDbList:    mdblist.New(common),
 		Endpoints:  endpoints.New(common, config.Endpoints),
+		Orphans:    orphans.New(common, config.Orphans),
 		PlexCron:   plex,
+		Seeding:    seeding.New(common, config.Seeding),
 		SnapCron:   snapcron.New(common),
-		StarrQueue: starrqueue.New(common),
+		StarrQueue: starrqueue.New(common, config.Stalled, config.Stuck, config.Imports),
+		Usenet:     usenet.New(common, config.Usenet),
 		inCh:       make(chan inChData),
 		outCh:      make(chan string),
 	}
 
+	// File watcher actions run other triggers, so it is created last.
+	actions.FileWatch = filewatch.New(common, config.WatchFiles, config.LogFiles, actions.fileWatchAction)
+
 	go actions.watchChan(ctx)
 
 	return actions
@@ -0,0 +1,77 @@
+package usenet
+
+import (
+	"strings"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/sabnzbd"
+	"golift.io/nzbget"
+)
+
+// Failure reasons. A successful download has no reason.
+const (
+	ReasonMissingArticles = "missing articles"
+	ReasonPassword        = "password protected"
+	ReasonParRepair       = "par repair failed"
+	ReasonUnpack          = "unpack failed"
+	ReasonDiskSpace       = "not enough disk space"
+	ReasonOther           = "other"
+)
+
+// ClassifySAB returns the failure reason for a SABnzbd history item.
+// The reason is empty for a successful download, and ok is false if the item isn't finished.
+func ClassifySAB(slot *sabnzbd.HistorySlots) (string, bool) {
+	switch strings.ToLower(slot.Status) {
+	case "completed":
+		return "", true
+	case "failed":
+		return failReason(slot.FailMessage), true
+	default:
+		return "", false
+	}
+}
+
+// ClassifyNZBGet returns the failure reason for an NZBGet history item.
+// The reason is empty for a successful download, and ok is false if it was deleted by hand, or is a duplicate.
+func ClassifyNZBGet(hist *nzbget.History) (string, bool) {
+	status, detail, _ := strings.Cut(strings.ToUpper(hist.Status), "/")
+
+	switch {
+	case status == "SUCCESS":
+		return "", true
+	case status == "DELETED" && detail != "HEALTH":
+		return "", false
+	case detail == "HEALTH":
+		return ReasonMissingArticles, true
+	case detail == "PAR" || detail == "DAMAGED" || detail == "REPAIRABLE":
+		return ReasonParRepair, true
+	case detail == "PASSWORD" || hist.UnpackStatus == nzbget.UnpackPASSWORD:
+		return ReasonPassword, true
+	case detail == "SPACE" || hist.UnpackStatus == nzbget.UnpackSPACE:
+		return ReasonDiskSpace, true
+	case detail == "UNPACK":
+		return ReasonUnpack, true
+	default:
+		return ReasonOther, true
+	}
+}
+
+// failReason turns a SABnzbd fail message into a failure reason.
+func failReason(message string) string {
+	message = strings.ToLower(message)
+
+	switch {
+	case strings.Contains(message, "password") || strings.Contains(message, "encrypt"):
+		return ReasonPassword
+	case strings.Contains(message, "article") || strings.Contains(message, "missing") ||
+		strings.Contains(message, "cannot be completed"):
+		return ReasonMissingArticles
+	case strings.Contains(message, "par") || strings.Contains(message, "repair") || strings.Contains(message, "verif"):
+		return ReasonParRepair
+	case strings.Contains(message, "disk") || strings.Contains(message, "space"):
+		return ReasonDiskSpace
+	case strings.Contains(message, "unpack") || strings.Contains(message, "unrar") || strings.Contains(message, "extract"):
+		return ReasonUnpack
+	default:
+		return ReasonOther
+	}
+}
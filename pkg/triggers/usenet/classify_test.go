@@ -0,0 +1,114 @@
+package usenet //nolint:testpackage
+
+import (
+	"testing"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/sabnzbd"
+	"github.com/stretchr/testify/assert"
+	"golift.io/nzbget"
+)
+
+func TestClassifySAB(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		status  string
+		message string
+		reason  string
+		ok      bool
+	}{
+		{status: "Completed", ok: true},
+		{status: "Failed", message: "Download failed - Not on your server(s)", reason: ReasonOther, ok: true},
+		{status: "Failed", message: "Aborted, cannot be completed", reason: ReasonMissingArticles, ok: true},
+		{status: "Failed", message: "Unpacking failed, archive requires a password", reason: ReasonPassword, ok: true},
+		{status: "Downloading"},
+		{status: "Extracting", message: "unpack"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.status+" "+test.message, func(t *testing.T) {
+			t.Parallel()
+
+			reason, ok := ClassifySAB(&sabnzbd.HistorySlots{Status: test.status, FailMessage: test.message})
+			assert.Equal(t, test.reason, reason)
+			assert.Equal(t, test.ok, ok)
+		})
+	}
+}
+
+func TestClassifyNZBGet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		status string
+		unpack nzbget.UnpackStatus
+		reason string
+		ok     bool
+	}{
+		{status: "SUCCESS/ALL", ok: true},
+		{status: "success/unpack", ok: true},
+		{status: "DELETED/MANUAL"},
+		{status: "DELETED/DUPE"},
+		{status: "DELETED/HEALTH", reason: ReasonMissingArticles, ok: true},
+		{status: "FAILURE/HEALTH", reason: ReasonMissingArticles, ok: true},
+		{status: "FAILURE/PAR", reason: ReasonParRepair, ok: true},
+		{status: "WARNING/DAMAGED", reason: ReasonParRepair, ok: true},
+		{status: "FAILURE/PASSWORD", reason: ReasonPassword, ok: true},
+		{status: "FAILURE/UNPACK", unpack: nzbget.UnpackPASSWORD, reason: ReasonPassword, ok: true},
+		{status: "FAILURE/UNPACK", unpack: nzbget.UnpackSPACE, reason: ReasonDiskSpace, ok: true},
+		{status: "FAILURE/UNPACK", unpack: nzbget.UnpackFAILURE, reason: ReasonUnpack, ok: true},
+		{status: "FAILURE/MOVE", reason: ReasonOther, ok: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.status+" "+string(test.unpack), func(t *testing.T) {
+			t.Parallel()
+
+			reason, ok := ClassifyNZBGet(&nzbget.History{Status: test.status, UnpackStatus: test.unpack})
+			assert.Equal(t, test.reason, reason)
+			assert.Equal(t, test.ok, ok)
+		})
+	}
+}
+
+func TestFailReason(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"":                                    ReasonOther,
+		"Aborted, cannot be completed":        ReasonMissingArticles,
+		"Download failed - Missing articles":  ReasonMissingArticles,
+		"Unpacking failed, Encrypted archive": ReasonPassword,
+		"PAR2 Repair failed, 5 blocks short":  ReasonParRepair,
+		"Verification failed":                 ReasonParRepair,
+		"Not enough disk space":               ReasonDiskSpace,
+		"Unpacking failed, unrar error":       ReasonUnpack,
+		"Failed to extract the archive":       ReasonUnpack,
+		"Something else went wrong":           ReasonOther,
+	}
+
+	for message, reason := range tests {
+		assert.Equal(t, reason, failReason(message), message)
+	}
+}
+
+func TestPercent(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	assert.Zero(percent(0, 0), "no downloads is no failure rate")
+	assert.Zero(percent(0, 10))
+	assert.InDelta(50.0, percent(1, 2), 0)
+	assert.InDelta(33.33, percent(1, 3), 0, "rounded down to two decimals")
+	assert.InDelta(100.0, percent(7, 7), 0)
+
+	counts := &Counts{Reasons: map[string]int64{}}
+	counts.add("")
+	counts.add(ReasonPassword)
+	counts.add(ReasonPassword)
+	counts.add(ReasonUnpack)
+	assert.Equal(int64(4), counts.Total)
+	assert.Equal(int64(3), counts.Failed)
+	assert.Equal(map[string]int64{ReasonPassword: 2, ReasonUnpack: 1}, counts.Reasons)
+	assert.InDelta(75.0, counts.Rate, 0)
+}
@@ -0,0 +1,35 @@
+package usenet
+
+import (
+	"net/http"
+)
+
+// HealthHandler is passed into the webserver as an HTTP handler.
+//
+//	@Description	Returns usenet download failures from SABnzbd and NZBGet, grouped by reason, indexer,
+//	@Description	category and provider, and any indexers or providers over the failure rate threshold.
+//	@Description	A new analysis is run if there is no report yet, or refresh is true.
+//	@Summary		Usenet failure analysis
+//	@Tags			Downloaders
+//	@Produce		json
+//	@Param			refresh	query		bool								false	"run a new analysis"
+//	@Success		200		{object}	apps.APIResponse{message=Report}	"usenet failure report"
+//	@Failure		501		{object}	apps.APIResponse{message=string}	"usenet failure analysis not enabled"
+//	@Failure		404		{object}	string								"bad token or api key"
+//	@Router			/usenet/health [get]
+//	@Security		ApiKeyAuth
+func (a *Action) HealthHandler(req *http.Request) (int, any) {
+	if !a.cmd.usenet.Enabled() {
+		return http.StatusNotImplemented, "usenet failure analysis not enabled"
+	}
+
+	a.cmd.mu.Lock()
+	report := a.cmd.report
+	a.cmd.mu.Unlock()
+
+	if report == nil || req.URL.Query().Get("refresh") == "true" {
+		report = a.cmd.buildReport(req.Context())
+	}
+
+	return http.StatusOK, report
+}
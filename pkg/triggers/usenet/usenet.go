@@ -0,0 +1,508 @@
+// Package usenet analyzes SABnzbd and NZBGet download history. Failures are grouped by reason,
+// indexer, category and usenet provider, and an alert is logged when an indexer's or a provider's
+// failure rate spikes.
+package usenet
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"golift.io/cnfg"
+	"golift.io/starr"
+)
+
+const TrigUsenetHealth common.TriggerName = "Analyzing usenet download failures."
+
+// Defaults for the config.
+const (
+	defaultInterval    = 15 * time.Minute
+	minimumInterval    = time.Minute
+	defaultWindow      = 24 * time.Hour
+	defaultThreshold   = 30
+	defaultMinSamples  = 5
+	defaultMinArticles = 10000
+	starrHistorySize   = "250"
+	unknownIndexer     = "unknown"
+)
+
+// Kinds of alerts.
+const (
+	KindIndexer  = "indexer"
+	KindProvider = "provider"
+)
+
+// Config enables the usenet failure analysis. Failures in the window are counted.
+// An alert is logged when at least min_samples downloads from an indexer, or min_articles
+// articles from a provider, fail at a rate of threshold percent or more.
+type Config struct {
+	Interval    cnfg.Duration `json:"interval"    toml:"interval"     xml:"interval"     yaml:"interval"`
+	Window      cnfg.Duration `json:"window"      toml:"window"       xml:"window"       yaml:"window"`
+	Threshold   float64       `json:"threshold"   toml:"threshold"    xml:"threshold"    yaml:"threshold"`
+	MinSamples  int64         `json:"minSamples"  toml:"min_samples"  xml:"min_samples"  yaml:"minSamples"`
+	MinArticles int64         `json:"minArticles" toml:"min_articles" xml:"min_articles" yaml:"minArticles"`
+	// Indexers looks up which indexer each download came from in the starr apps' grab history.
+	Indexers bool `json:"indexers" toml:"indexers" xml:"indexers" yaml:"indexers"`
+}
+
+// Action contains the exported methods for this package.
+type Action struct {
+	cmd *cmd
+}
+
+type cmd struct {
+	*common.Config
+	usenet  *Config
+	mu      sync.Mutex
+	report  *Report
+	alerted map[string]struct{}
+}
+
+// Report is the failure analysis for every usenet client.
+type Report struct {
+	Window  string    `json:"window"`
+	Clients []*Client `json:"clients"`
+	Alerts  []*Alert  `json:"alerts"`
+	Date    time.Time `json:"date"`
+}
+
+// Counts are the finished downloads and failures for a client, indexer or category.
+type Counts struct {
+	Total   int64            `json:"total"`
+	Failed  int64            `json:"failed"`
+	Rate    float64          `json:"rate"` // Failure percent.
+	Reasons map[string]int64 `json:"reasons"`
+}
+
+// Provider is the article statistics for a usenet server.
+type Provider struct {
+	Name     string  `json:"name"`
+	Articles int64   `json:"articles"`
+	Failed   int64   `json:"failed"`
+	Rate     float64 `json:"rate"` // Failure percent.
+}
+
+// Client is the failure analysis for one SABnzbd or NZBGet instance.
+type Client struct {
+	App        starr.App          `json:"app"`
+	Instance   int                `json:"instance"`
+	Name       string             `json:"name"`
+	Error      string             `json:"error,omitempty"`
+	Counts     *Counts            `json:"counts"`
+	Indexers   map[string]*Counts `json:"indexers"`
+	Categories map[string]*Counts `json:"categories"`
+	Providers  []*Provider        `json:"providers"`
+}
+
+// Alert is an indexer or provider with a failure rate over the threshold.
+type Alert struct {
+	App      starr.App        `json:"app"`
+	Instance int              `json:"instance"`
+	Kind     string           `json:"kind"`
+	Name     string           `json:"name"`
+	Rate     float64          `json:"rate"`
+	Failed   int64            `json:"failed"`
+	Total    int64            `json:"total"`
+	Reasons  map[string]int64 `json:"reasons,omitempty"`
+}
+
+// download is a finished download from either client.
+type download struct {
+	id       string
+	url      string
+	category string
+	reason   string
+	when     time.Time
+}
+
+// New configures the library.
+func New(config *common.Config, usenet *Config) *Action {
+	return &Action{cmd: &cmd{Config: config, usenet: usenet, alerted: make(map[string]struct{})}}
+}
+
+// Enabled returns true if the usenet failure analysis is configured.
+func (c *Config) Enabled() bool {
+	return c != nil
+}
+
+// Cutoff returns the start of the window. Downloads that finished before it are not counted.
+func (c *Config) Cutoff(now time.Time) time.Time {
+	if c == nil || c.Window.Duration <= 0 {
+		return now.Add(-defaultWindow)
+	}
+
+	return now.Add(-c.Window.Duration)
+}
+
+// Create initializes the library.
+func (a *Action) Create() {
+	a.cmd.create(mnd.ReqID())
+}
+
+func (c *cmd) create(reqID string) {
+	if !c.usenet.Enabled() {
+		return
+	}
+
+	cfg := c.usenet
+	if cfg.Interval.Duration == 0 {
+		cfg.Interval.Duration = defaultInterval
+	} else if cfg.Interval.Duration < minimumInterval {
+		cfg.Interval.Duration = minimumInterval
+	}
+
+	if cfg.Window.Duration <= 0 {
+		cfg.Window.Duration = defaultWindow
+	}
+
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = defaultThreshold
+	}
+
+	if cfg.MinSamples <= 0 {
+		cfg.MinSamples = defaultMinSamples
+	}
+
+	if cfg.MinArticles <= 0 {
+		cfg.MinArticles = defaultMinArticles
+	}
+
+	mnd.Log.Printf(reqID, "==> Usenet Failure Analysis Enabled, interval:%s window:%s threshold:%.0f%% "+
+		"min_samples:%d min_articles:%d indexers:%v", cfg.Interval, cfg.Window, cfg.Threshold,
+		cfg.MinSamples, cfg.MinArticles, cfg.Indexers)
+
+	c.Add(&common.Action{
+		Key:  "TrigUsenetHealth",
+		Name: TrigUsenetHealth,
+		Fn:   c.analyze,
+		C:    make(chan *common.ActionInput, 1),
+		D:    cfg.Interval,
+	})
+}
+
+// Analyze runs the usenet failure analysis now. Returns false if it's not enabled.
+func (a *Action) Analyze(input *common.ActionInput) bool {
+	return a.cmd.Exec(input, TrigUsenetHealth)
+}
+
+// analyze builds a new report, and logs alerts for new spikes.
+func (c *cmd) analyze(ctx context.Context, input *common.ActionInput) {
+	report := c.buildReport(mnd.WithID(ctx, input.ReqID))
+	alerted := make(map[string]struct{})
+	alerts := []*Alert{}
+
+	for _, alert := range report.Alerts {
+		key := fmt.Sprintf("%s/%d/%s/%s", alert.App, alert.Instance, alert.Kind, alert.Name)
+		if _, ok := c.alerted[key]; !ok {
+			alerts = append(alerts, alert)
+		}
+
+		alerted[key] = struct{}{}
+	}
+
+	// Replacing the map clears alerts that recovered, so they can alert again.
+	c.alerted = alerted
+
+	for _, client := range report.Clients {
+		if client.Error != "" {
+			mnd.Log.Errorf(input.ReqID, "[%s requested] Usenet failure analysis: %s %d: %s",
+				input.Type, client.App, client.Instance, client.Error)
+		}
+	}
+
+	if len(alerts) == 0 {
+		return
+	}
+
+	for _, alert := range alerts {
+		mnd.Log.Printf(input.ReqID, "[%s requested] Usenet failure rate is high: %s", input.Type, alert)
+	}
+}
+
+// String describes the failure rate of the indexer, category or provider.
+func (a *Alert) String() string {
+	return fmt.Sprintf("%s %d: %s %s: %.1f%% failed (%d of %d)",
+		a.App, a.Instance, a.Kind, a.Name, a.Rate, a.Failed, a.Total)
+}
+
+// buildReport analyzes every usenet client, and saves the report for the API.
+func (c *cmd) buildReport(ctx context.Context) *Report {
+	cutoff := c.usenet.Cutoff(time.Now())
+	report := &Report{
+		Window:  c.usenet.Window.String(),
+		Clients: []*Client{},
+		Alerts:  []*Alert{},
+		Date:    time.Now(),
+	}
+
+	var indexers map[string]string
+	if c.usenet.Indexers {
+		indexers = c.starrIndexers(ctx)
+	}
+
+	for idx := range c.Apps.SabNZB {
+		if app := &c.Apps.SabNZB[idx]; app.Enabled() {
+			client := &Client{App: apps.AppSabNZB, Instance: idx + 1, Name: app.Name}
+			downloads, providers, err := sabData(ctx, app, cutoff)
+			c.addClient(report, client, downloads, providers, indexers, err)
+		}
+	}
+
+	for idx := range c.Apps.NZBGet {
+		if app := &c.Apps.NZBGet[idx]; app.Enabled() {
+			client := &Client{App: apps.AppNZBGet, Instance: idx + 1, Name: app.Name}
+			downloads, providers, err := nzbgetData(ctx, app, cutoff)
+			c.addClient(report, client, downloads, providers, indexers, err)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.report = report
+
+	return report
+}
+
+// addClient counts a client's downloads, and adds it and its alerts to the report.
+func (c *cmd) addClient(
+	report *Report,
+	client *Client,
+	downloads []*download,
+	providers []*Provider,
+	indexers map[string]string,
+	err error,
+) {
+	report.Clients = append(report.Clients, client)
+	client.Counts = &Counts{Reasons: map[string]int64{}}
+	client.Indexers = map[string]*Counts{}
+	client.Categories = map[string]*Counts{}
+	client.Providers = providers
+
+	if err != nil {
+		client.Error = err.Error()
+		return
+	}
+
+	for _, dl := range downloads {
+		indexer := indexers[strings.ToLower(dl.id)]
+		if indexer == "" {
+			indexer = urlHost(dl.url)
+		}
+
+		client.Counts.add(dl.reason)
+		count(client.Indexers, indexer).add(dl.reason)
+		count(client.Categories, dl.category).add(dl.reason)
+	}
+
+	for name, counts := range client.Indexers {
+		if name != unknownIndexer && counts.Total >= c.usenet.MinSamples && counts.Rate >= c.usenet.Threshold {
+			report.Alerts = append(report.Alerts, &Alert{
+				App: client.App, Instance: client.Instance, Kind: KindIndexer, Name: name,
+				Rate: counts.Rate, Failed: counts.Failed, Total: counts.Total, Reasons: counts.Reasons,
+			})
+		}
+	}
+
+	for _, provider := range providers {
+		if provider.Articles >= c.usenet.MinArticles && provider.Rate >= c.usenet.Threshold {
+			report.Alerts = append(report.Alerts, &Alert{
+				App: client.App, Instance: client.Instance, Kind: KindProvider, Name: provider.Name,
+				Rate: provider.Rate, Failed: provider.Failed, Total: provider.Articles,
+			})
+		}
+	}
+}
+
+// count returns the counts for a name, creating them if needed.
+func count(counts map[string]*Counts, name string) *Counts {
+	if name == "" {
+		name = unknownIndexer
+	}
+
+	if counts[name] == nil {
+		counts[name] = &Counts{Reasons: map[string]int64{}}
+	}
+
+	return counts[name]
+}
+
+// add counts a finished download. An empty reason is a success.
+func (c *Counts) add(reason string) {
+	c.Total++
+
+	if reason != "" {
+		c.Failed++
+		c.Reasons[reason]++
+	}
+
+	c.Rate = percent(c.Failed, c.Total)
+}
+
+// percent returns part as a percent of total, rounded to 2 decimals.
+func percent(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	const hundred = 100
+
+	return float64(part*hundred*hundred/total) / hundred
+}
+
+// urlHost returns the host name from an NZB URL; the indexer that provided it.
+func urlHost(nzbURL string) string {
+	if u, err := url.Parse(nzbURL); err == nil && u.Hostname() != "" {
+		return u.Hostname()
+	}
+
+	return unknownIndexer
+}
+
+// sabData returns the finished downloads and provider article stats from SABnzbd.
+func sabData(ctx context.Context, app *apps.SabNZB, cutoff time.Time) ([]*download, []*Provider, error) {
+	hist, err := app.GetHistory(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting history: %w", err)
+	}
+
+	downloads := []*download{}
+
+	for idx := range hist.Slots {
+		slot := &hist.Slots[idx]
+		when := time.Unix(slot.Completed, 0)
+
+		if reason, ok := ClassifySAB(slot); ok && when.After(cutoff) {
+			downloads = append(downloads, &download{
+				id: slot.NzoID, url: slot.URL, category: slot.Category, reason: reason, when: when,
+			})
+		}
+	}
+
+	stats, err := app.GetServerStats(ctx)
+	if err != nil {
+		return downloads, nil, fmt.Errorf("getting server stats: %w", err)
+	}
+
+	providers := []*Provider{}
+	since := cutoff.Format(time.DateOnly)
+
+	for name, server := range stats.Servers {
+		provider := &Provider{Name: name}
+
+		for date, tried := range server.ArticlesTried {
+			if date >= since {
+				provider.Articles += tried
+				provider.Failed += tried - server.ArticlesSuccess[date]
+			}
+		}
+
+		provider.Rate = percent(provider.Failed, provider.Articles)
+		providers = append(providers, provider)
+	}
+
+	return downloads, providers, nil
+}
+
+// nzbgetData returns the finished downloads and provider article stats from NZBGet.
+func nzbgetData(ctx context.Context, app *apps.NZBGet, cutoff time.Time) ([]*download, []*Provider, error) {
+	hist, err := app.HistoryContext(ctx, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting history: %w", err)
+	}
+
+	downloads := []*download{}
+	servers := map[int64]*Provider{}
+
+	for _, item := range hist {
+		reason, ok := ClassifyNZBGet(item)
+		if !ok || item.HistoryTime.Before(cutoff) {
+			continue
+		}
+
+		downloads = append(downloads, &download{
+			id:       strconv.FormatInt(item.NZBID, mnd.Base10),
+			url:      item.URL,
+			category: item.Category,
+			reason:   reason,
+			when:     item.HistoryTime.Time,
+		})
+
+		for _, stat := range item.ServerStats {
+			if servers[stat.ServerID] == nil {
+				servers[stat.ServerID] = &Provider{Name: fmt.Sprint("server ", stat.ServerID)}
+			}
+
+			servers[stat.ServerID].Articles += stat.SuccessArticles + stat.FailedArticles
+			servers[stat.ServerID].Failed += stat.FailedArticles
+		}
+	}
+
+	// Server names are only in the config. Not having them is not worth failing over.
+	if config, err := app.ConfigContext(ctx); err == nil {
+		for _, param := range config {
+			id, ok := strings.CutSuffix(strings.TrimPrefix(param.Name, "Server"), ".Name")
+			if num, err := strconv.ParseInt(id, mnd.Base10, mnd.Bits64); ok && err == nil && servers[num] != nil {
+				servers[num].Name = param.Value
+			}
+		}
+	}
+
+	providers := make([]*Provider, 0, len(servers))
+
+	for _, provider := range servers {
+		provider.Rate = percent(provider.Failed, provider.Articles)
+		providers = append(providers, provider)
+	}
+
+	return downloads, providers, nil
+}
+
+// starrIndexers returns a map of download ID to indexer name from every starr app's grab history.
+func (c *cmd) starrIndexers(ctx context.Context) map[string]string {
+	indexers := make(map[string]string)
+
+	for _, instance := range c.Apps.StarrInstances(starr.Lidarr, starr.Radarr, starr.Readarr, starr.Sonarr) {
+		if !instance.Enabled() {
+			continue
+		}
+
+		var history struct {
+			Records []*struct {
+				DownloadID string `json:"downloadId"`
+				Data       struct {
+					Indexer string `json:"indexer"`
+				} `json:"data"`
+			} `json:"records"`
+		}
+
+		err := instance.StarrClient().GetInto(ctx, starr.Request{
+			URI: instance.Starr().APIPath("history"),
+			Query: url.Values{
+				"eventType":     {"1"}, // grabbed
+				"pageSize":      {starrHistorySize},
+				"sortKey":       {"date"},
+				"sortDirection": {"descending"},
+			},
+		}, &history)
+		if err != nil {
+			mnd.Log.Errorf(mnd.GetID(ctx), "Getting %s %d grab history for usenet indexers: %v",
+				instance.App(), instance.Instance(), err)
+			continue
+		}
+
+		for _, record := range history.Records {
+			if record.DownloadID != "" && record.Data.Indexer != "" {
+				indexers[strings.ToLower(record.DownloadID)] = record.Data.Indexer
+			}
+		}
+	}
+
+	return indexers
+}
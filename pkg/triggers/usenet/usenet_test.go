@@ -0,0 +1,55 @@
+package usenet //nolint:testpackage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddClientAlerts(t *testing.T) {
+	t.Parallel()
+
+	c := &cmd{usenet: &Config{Threshold: defaultThreshold, MinSamples: defaultMinSamples, MinArticles: defaultMinArticles}}
+	report := &Report{}
+	downloads := []*download{
+		{url: "https://bad.example/nzb/1", reason: ReasonMissingArticles},
+		{url: "https://bad.example/nzb/2", reason: ReasonMissingArticles},
+		{url: "https://bad.example/nzb/3", reason: ReasonPassword},
+		{url: "https://bad.example/nzb/4", reason: ReasonPassword},
+		{url: "https://bad.example/nzb/5", reason: ""},
+		{url: "https://few.example/nzb/1", reason: ReasonMissingArticles},
+	}
+	providers := []*Provider{
+		{Name: "small", Articles: 50, Failed: 40, Rate: percent(40, 50)},
+		{Name: "large", Articles: 20000, Failed: 8000, Rate: percent(8000, 20000)},
+		{Name: "healthy", Articles: 20000, Failed: 20, Rate: percent(20, 20000)},
+	}
+
+	c.addClient(report, &Client{Name: "sab"}, downloads, providers, nil, nil)
+	require.Len(t, report.Alerts, 2)
+
+	alerts := map[string]*Alert{}
+	for _, alert := range report.Alerts {
+		alerts[alert.Kind+":"+alert.Name] = alert
+	}
+
+	assert.Contains(t, alerts, KindIndexer+":bad.example", "5 downloads meets min_samples")
+	assert.NotContains(t, alerts, KindIndexer+":few.example", "1 download is under min_samples")
+	assert.Contains(t, alerts, KindProvider+":large")
+	assert.NotContains(t, alerts, KindProvider+":small", "a few failed articles are not a provider outage")
+	assert.Equal(t, int64(6), report.Clients[0].Counts.Total)
+}
+
+func TestCutoff(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	config := &Config{}
+	assert.Equal(t, now.Add(-defaultWindow), (*Config)(nil).Cutoff(now), "the dashboard counts without a usenet config")
+	assert.Equal(t, now.Add(-defaultWindow), config.Cutoff(now))
+
+	config.Window.Duration = time.Hour
+	assert.Equal(t, now.Add(-time.Hour), config.Cutoff(now))
+}
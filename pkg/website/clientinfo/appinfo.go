@@ -175,13 +175,23 @@ func (c *Config) Info(ctx context.Context, startup bool) *AppInfo { //nolint:fun
 			Retries:        webconf.Retries,
 			Apps:           c.getAppConfigs(ctx, startup),
 		},
-		Commands:  c.CmdList,
+		Commands:  c.commands(),
 		Endpoints: c.Endpoints,
 		Host:      host,
 		HostError: err.Error(),
 	}
 }
 
+// commands returns the commands with their secrets redacted.
+func (c *Config) commands() []*cmdconfig.Config {
+	output := make([]*cmdconfig.Config, len(c.CmdList))
+	for idx, cmd := range c.CmdList {
+		output[idx] = cmd.Redacted()
+	}
+
+	return output
+}
+
 func (c *Config) getAppConfigs(ctx context.Context, startup bool) *AppConfigs {
 	apps := new(AppConfigs)
 	add := func(i int, name string) *AppInfoAppConfig {
@@ -11,10 +11,12 @@ import (
 	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/plex"
 	"github.com/Notifiarr/notifiarr/pkg/mnd"
 	"github.com/Notifiarr/notifiarr/pkg/snapshot"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common/scheduler"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/data"
 	"github.com/Notifiarr/notifiarr/pkg/website"
 	"golang.org/x/crypto/bcrypt"
 	"golift.io/cnfg"
+	"golift.io/starr"
 )
 
 // ClientInfo is the client's startup data received from the website.
@@ -78,6 +80,8 @@ type CronConfig struct {
 	Interval cnfg.Duration `json:"interval"` // how often to GET this URI.
 	URI      string        `json:"endpoint"` // endpoint for the URI.
 	Desc     string        `json:"description"`
+	// CronExpr runs the timer on a cron schedule instead of the interval.
+	scheduler.CronExpr
 }
 
 // SyncConfig is the configuration returned from the notifiarr website for CF/RP TraSH sync.
@@ -103,6 +107,7 @@ type DashConfig struct {
 	Interval     cnfg.Duration `json:"interval"` // how often to fire.
 	Deluge       bool          `json:"deluge"`
 	Lidarr       bool          `json:"lidarr"`
+	Prowlarr     bool          `json:"prowlarr"`
 	Qbit         bool          `json:"qbit"`
 	Radarr       bool          `json:"radarr"`
 	Readarr      bool          `json:"readarr"`
@@ -210,6 +215,56 @@ func Get() *ClientInfo {
 	return cinfo
 }
 
+// Get returns the website's instance configurations for a starr app.
+func (a *AllAppConfigs) Get(app starr.App) InstanceConfig {
+	switch app { //nolint:exhaustive // We only have configs for starr apps.
+	case starr.Lidarr:
+		return a.Lidarr
+	case starr.Prowlarr:
+		return a.Prowlarr
+	case starr.Radarr:
+		return a.Radarr
+	case starr.Readarr:
+		return a.Readarr
+	case starr.Sonarr:
+		return a.Sonarr
+	default:
+		return nil
+	}
+}
+
+// Instances returns the instance IDs that sync custom formats and profiles for a starr app.
+func (s *SyncConfig) Instances(app starr.App) IntList {
+	switch app { //nolint:exhaustive // Only these apps have sync.
+	case starr.Lidarr:
+		return s.LidarrInstances
+	case starr.Radarr:
+		return s.RadarrInstances
+	case starr.Sonarr:
+		return s.SonarrInstances
+	default:
+		return nil
+	}
+}
+
+// Starr returns true if the dashboard is enabled for a starr app.
+func (d *DashConfig) Starr(app starr.App) bool {
+	switch app { //nolint:exhaustive // Only these apps have dashboard data.
+	case starr.Lidarr:
+		return d.Lidarr
+	case starr.Prowlarr:
+		return d.Prowlarr
+	case starr.Radarr:
+		return d.Radarr
+	case starr.Readarr:
+		return d.Readarr
+	case starr.Sonarr:
+		return d.Sonarr
+	default:
+		return false
+	}
+}
+
 func (i InstanceConfig) Finished(instance int) bool {
 	for _, app := range i {
 		if app.Instance == instance {